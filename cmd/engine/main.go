@@ -2,19 +2,26 @@ package main
 
 import (
 	"context"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
+	"github.com/Im-Manav/ome/internal/api"
+	"github.com/Im-Manav/ome/internal/api/admin"
 	"github.com/Im-Manav/ome/internal/cache"
 	"github.com/Im-Manav/ome/internal/config"
 	"github.com/Im-Manav/ome/internal/db"
 	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/internal/health"
 	"github.com/Im-Manav/ome/internal/kafka"
+	"github.com/Im-Manav/ome/internal/metrics"
 	"github.com/Im-Manav/ome/internal/service"
+	"github.com/Im-Manav/ome/internal/tracing"
 	"github.com/Im-Manav/ome/pkg/logger"
 	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
 )
 
@@ -35,6 +42,12 @@ func main() {
 
 	defer logger.Sync()
 
+	shutdownTracing, err := tracing.Init(context.Background(), "engine", cfg.TracesExporter)
+	if err != nil {
+		logger.Fatal("tracing init failed", logger.Err(err))
+	}
+	defer shutdownTracing(context.Background())
+
 	database, err := db.NewConnection(cfg)
 	if err != nil {
 		logger.Fatal("postgres connection failed", logger.Err(err))
@@ -50,7 +63,11 @@ func main() {
 
 	repo := db.NewRepository(database)
 
-	producer := kafka.NewProducer(cfg.KafkaBrokers)
+	codecs, err := kafka.ParseTopicCodecs(cfg.KafkaCodecs)
+	if err != nil {
+		logger.Fatal("invalid kafka codec config", logger.Err(err))
+	}
+	producer := kafka.NewProducer(cfg.KafkaBrokers, kafka.SourceEngine, codecs)
 	defer producer.Close()
 
 	// The matching engine itself — pure, in-memory, one instance
@@ -72,29 +89,87 @@ func main() {
 	)
 	defer consumer.Close()
 
+	// Orders the outbox relay redelivers after this engine restarts are
+	// checked against the database, so they aren't matched twice.
+	consumer.SetOrders(repo)
+
 	// Register the post-match handler: persist trades, update order,
 	// then publish to Redis so the gateway's WebSocket hub can forward it.
 	consumer.AddHandler(orderSvc.PostMatchHandler)
 
-	consumer.AddHandler(func(ctx context.Context, order models.Order, trades []models.Trade) error {
-		book := matcher.BookFor(order.Symbol)
-		bids, asks := book.Depth(20)
-		logger.Info("snapshot buil")
-		snap := models.OrderBookSnapshot{
-			Symbol:    order.Symbol,
-			Bids:      bids,
-			Asks:      asks,
-			Timestamp: time.Now().UTC(),
-		}
-		if err := redisClient.SetOrderBookSnapshot(ctx, order.Symbol, snap, 30*time.Second); err != nil {
-			logger.Error("failed to cache orderbook snapshot", logger.Err(err))
-		}
-		return redisClient.PublishOrderBookUpdate(ctx, snap)
-	})
+	// Refresh the cached order book snapshot and publish it to Redis
+	// so the gateway can serve GET /orderbook without touching the engine.
+	snapshots := service.NewSnapshotPublisher(matcher, redisClient, noopBroadcaster{})
+	consumer.AddHandler(snapshots.PostMatchHandler)
+	consumer.AddCancelHandler(snapshots.CancelHandler)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	// Readiness stays down until the books are rebuilt and the order
+	// consumer has started — until then this engine would match against
+	// an empty book.
+	booksRestored := health.NewGate("order books not rebuilt yet")
+	checks := health.New()
+	checks.AddLiveness("matcher", func(context.Context) error {
+		matcher.Symbols() // times out if a match is wedged holding the lock
+		return nil
+	})
+	checks.AddReadiness("postgres", health.Database(database))
+	checks.AddReadiness("redis", health.Cache(redisClient))
+	checks.AddReadiness("kafka", health.KafkaBrokers(cfg.KafkaBrokers))
+	checks.AddReadiness("orders_consumer_lag", health.ConsumerLag(consumer.Lag, cfg.HealthMaxConsumerLag))
+	checks.AddReadiness("book_rebuild", booksRestored.Check)
+
+	// HTTP on ENGINE_PORT — probes and metrics always, plus the admin
+	// API (live book state, halt/resume) when ENGINE_ADMIN_TOKEN is set.
+	// Started before the rebuild so probes can report its progress.
+	metrics.RegisterEngine(matcher)
+
+	if cfg.Env == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	r.GET("/livez", gin.WrapF(checks.LiveHandler))
+	r.GET("/readyz", gin.WrapF(checks.ReadyHandler))
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
+	if cfg.EngineAdminToken != "" {
+		adminRoutes := r.Group("", api.RequestLogger())
+		admin.NewHandler(matcher, snapshots, cfg.EngineAdminToken).RegisterAdminRoutes(adminRoutes)
+	} else {
+		logger.Warn("ENGINE_ADMIN_TOKEN not set, engine admin API disabled")
+	}
+
+	httpSrv := &http.Server{
+		Addr:         ":" + cfg.EnginePort,
+		Handler:      r,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+	}
+	go func() {
+		logger.Info("engine HTTP starting", zap.String("port", cfg.EnginePort))
+		if err := httpSrv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Fatal("engine HTTP failed", logger.Err(err))
+		}
+	}()
+
+	restored, err := service.RestoreBooks(ctx, repo, matcher)
+	if err != nil {
+		logger.Fatal("order book rebuild failed", logger.Err(err))
+	}
+	for _, symbol := range matcher.Symbols() {
+		if err := snapshots.Publish(ctx, symbol); err != nil {
+			logger.Warn("snapshot publish failed", logger.Err(err), zap.String("symbol", symbol))
+		}
+	}
+	booksRestored.Open()
+	logger.Info("order books rebuilt", zap.Int("orders", restored))
+
 	go func() {
 		if err := consumer.Start(ctx); err != nil {
 			logger.Error("order consumer stopped", logger.Err(err))
@@ -108,6 +183,11 @@ func main() {
 	<-quit
 
 	logger.Info("shutdown signal received")
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer shutdownCancel()
+	if err := httpSrv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("engine HTTP forced shutdown", logger.Err(err))
+	}
 	cancel()
 	time.Sleep(500 * time.Millisecond)
 	logger.Info("matching engine service stopped")
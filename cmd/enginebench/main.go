@@ -0,0 +1,173 @@
+// Command enginebench replays a seeded synthetic order stream through
+// the matcher and reports per-order latency percentiles and allocations.
+// Each run is appended to a JSON-lines results file keyed by commit, and
+// -baseline compares against a stored run, exiting non-zero on regression.
+//
+//	go run ./cmd/enginebench -orders 1000000
+//	go run ./cmd/enginebench -baseline a1b2c3d -threshold 10
+package main
+
+import (
+	"cmp"
+	"flag"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/engine"
+)
+
+func main() {
+	var (
+		seed      = flag.Uint64("seed", 1, "stream seed; same seed, same stream")
+		orders    = flag.Int("orders", 500_000, "number of stream steps to replay")
+		symbols   = flag.Int("symbols", 8, "number of symbols to spread the stream over")
+		cancelPct = flag.Int("cancel-pct", 30, "percent of steps that cancel a resting order")
+		crossPct  = flag.Int("cross-pct", 20, "percent of steps that cross the spread")
+		warmup    = flag.Int("warmup", 50_000, "steps replayed untimed before measuring")
+		runs      = flag.Int("runs", 3, "repeat the replay and keep the run with the median p99")
+		out       = flag.String("out", "bench/engine.jsonl", "results file (JSON lines); empty to skip")
+		commit    = flag.String("commit", "", "commit to record the run under (default: git HEAD)")
+		baseline  = flag.String("baseline", "", "commit to compare against from the results file")
+		threshold = flag.Float64("threshold", 10, "allowed regression in percent before failing")
+	)
+	flag.Parse()
+
+	if *commit == "" {
+		*commit = gitHead()
+	}
+	mix := streamMix{CancelPct: *cancelPct, CrossingPct: *crossPct}
+
+	r := medianRun(*runs, func() result { return run(*seed, *orders, *warmup, *symbols, mix) })
+	r.Commit = *commit
+	report(r)
+
+	if *out != "" {
+		if err := os.MkdirAll(filepath.Dir(*out), 0o755); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+		if err := appendResult(*out, r); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(2)
+		}
+	}
+
+	if *baseline != "" {
+		base, err := findBaseline(*out, *baseline, r)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "baseline:", err)
+			os.Exit(2)
+		}
+		if worse := regressions(base, r, *threshold); len(worse) > 0 {
+			fmt.Fprintf(os.Stderr, "regression against %s (threshold %.0f%%):\n", *baseline, *threshold)
+			for _, line := range worse {
+				fmt.Fprintln(os.Stderr, "  "+line)
+			}
+			os.Exit(1)
+		}
+		fmt.Printf("no regression against %s (threshold %.0f%%)\n", *baseline, *threshold)
+	}
+}
+
+// medianRun repeats fn and returns the run with the median p99, which
+// smooths out a single noisy replay without hiding a real tail.
+func medianRun(n int, fn func() result) result {
+	results := make([]result, max(n, 1))
+	for i := range results {
+		results[i] = fn()
+	}
+	slices.SortFunc(results, func(a, b result) int { return cmp.Compare(a.P99Nanos, b.P99Nanos) })
+	return results[len(results)/2]
+}
+
+// run replays the stream once. The warmup prefix fills the books and
+// is excluded from both latency and allocation figures.
+func run(seed uint64, n, warmup, symbols int, mix streamMix) result {
+	ops := generate(seed, warmup+n, symbols, mix)
+	m := engine.NewMatcher()
+
+	for _, o := range ops[:warmup] {
+		apply(m, o)
+	}
+	ops = ops[warmup:]
+
+	latencies := make([]time.Duration, len(ops))
+	trades := 0
+
+	// GC pauses land in the percentiles as they would in production;
+	// a collection now just keeps warmup garbage out of the numbers.
+	runtime.GC()
+	var before, after runtime.MemStats
+	runtime.ReadMemStats(&before)
+	start := time.Now()
+
+	for i, o := range ops {
+		t := time.Now()
+		trades += apply(m, o)
+		latencies[i] = time.Since(t)
+	}
+
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	r := result{
+		RecordedAt:     time.Now().UTC(),
+		GoVersion:      runtime.Version(),
+		Seed:           seed,
+		Orders:         n,
+		Symbols:        symbols,
+		Mix:            mix,
+		OrdersPerSec:   float64(len(ops)) / elapsed.Seconds(),
+		AllocsPerOrder: float64(after.Mallocs-before.Mallocs) / float64(len(ops)),
+		BytesPerOrder:  float64(after.TotalAlloc-before.TotalAlloc) / float64(len(ops)),
+		Trades:         trades,
+	}
+	summarise(&r, latencies)
+	return r
+}
+
+func apply(m *engine.Matcher, o op) int {
+	if o.order == nil {
+		m.Cancel(o.symbol, o.cancel)
+		return 0
+	}
+	return len(m.Match(o.order))
+}
+
+func report(r result) {
+	fmt.Printf("commit %s  seed %d  orders %d  symbols %d  cancel %d%%  cross %d%%\n",
+		r.Commit, r.Seed, r.Orders, r.Symbols, r.Mix.CancelPct, r.Mix.CrossingPct)
+	fmt.Printf("  p50     %v\n", time.Duration(r.P50Nanos))
+	fmt.Printf("  p99     %v\n", time.Duration(r.P99Nanos))
+	fmt.Printf("  p99.9   %v\n", time.Duration(r.P999Nanos))
+	fmt.Printf("  max     %v\n", time.Duration(r.MaxNanos))
+	fmt.Printf("  %.0f orders/s, %d trades\n", r.OrdersPerSec, r.Trades)
+	fmt.Printf("  %.2f allocs/order, %.0f B/order\n", r.AllocsPerOrder, r.BytesPerOrder)
+}
+
+// gitHead returns the short HEAD hash, with a -dirty suffix for
+// uncommitted changes, falling back to the build's VCS stamp.
+func gitHead() string {
+	if out, err := exec.Command("git", "rev-parse", "--short", "HEAD").Output(); err == nil {
+		head := strings.TrimSpace(string(out))
+		if status, err := exec.Command("git", "status", "--porcelain", "--untracked-files=no").Output(); err == nil && len(status) > 0 {
+			head += "-dirty"
+		}
+		return head
+	}
+	if info, ok := debug.ReadBuildInfo(); ok {
+		for _, s := range info.Settings {
+			if s.Key == "vcs.revision" && len(s.Value) >= 7 {
+				return s.Value[:7]
+			}
+		}
+	}
+	return "unknown"
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// result is one harness run, stored as a line of JSON so runs from
+// different commits can be compared later.
+type result struct {
+	Commit     string    `json:"commit"`
+	RecordedAt time.Time `json:"recorded_at"`
+	GoVersion  string    `json:"go_version"`
+
+	Seed    uint64    `json:"seed"`
+	Orders  int       `json:"orders"`
+	Symbols int       `json:"symbols"`
+	Mix     streamMix `json:"mix"`
+
+	P50Nanos       int64   `json:"p50_ns"`
+	P99Nanos       int64   `json:"p99_ns"`
+	P999Nanos      int64   `json:"p999_ns"`
+	MaxNanos       int64   `json:"max_ns"`
+	OrdersPerSec   float64 `json:"orders_per_sec"`
+	AllocsPerOrder float64 `json:"allocs_per_order"`
+	BytesPerOrder  float64 `json:"bytes_per_order"`
+	Trades         int     `json:"trades"`
+}
+
+// sameWorkload reports whether two runs replayed the same stream and
+// can be compared number for number.
+func (r result) sameWorkload(o result) bool {
+	return r.Seed == o.Seed && r.Orders == o.Orders && r.Symbols == o.Symbols && r.Mix == o.Mix
+}
+
+// percentile returns the p-th percentile (0–100) of sorted latencies.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(float64(len(sorted)-1) * p / 100)
+	return sorted[i]
+}
+
+func summarise(r *result, latencies []time.Duration) {
+	slices.Sort(latencies)
+	r.P50Nanos = percentile(latencies, 50).Nanoseconds()
+	r.P99Nanos = percentile(latencies, 99).Nanoseconds()
+	r.P999Nanos = percentile(latencies, 99.9).Nanoseconds()
+	r.MaxNanos = latencies[len(latencies)-1].Nanoseconds()
+}
+
+func appendResult(path string, r result) error {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("appendResult: %w", err)
+	}
+	defer f.Close()
+
+	line, err := json.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("appendResult: %w", err)
+	}
+	_, err = f.Write(append(line, '\n'))
+	return err
+}
+
+// findBaseline returns the most recent stored run for commit that used
+// the same workload as r.
+func findBaseline(path, commit string, r result) (result, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return result{}, fmt.Errorf("findBaseline: %w", err)
+	}
+	defer f.Close()
+
+	var (
+		found result
+		ok    bool
+	)
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var stored result
+		if err := json.Unmarshal(scanner.Bytes(), &stored); err != nil {
+			continue // tolerate hand-edited or truncated lines
+		}
+		if stored.Commit == commit && stored.sameWorkload(r) {
+			found, ok = stored, true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return result{}, fmt.Errorf("findBaseline: %w", err)
+	}
+	if !ok {
+		return result{}, errors.New("no stored run for " + commit + " with the same workload")
+	}
+	return found, nil
+}
+
+// regressions compares r against base and lists every metric that got
+// worse by more than threshold percent.
+func regressions(base, r result, threshold float64) []string {
+	var out []string
+	check := func(name string, was, now float64) {
+		if was <= 0 {
+			return
+		}
+		if change := (now - was) / was * 100; change > threshold {
+			out = append(out, fmt.Sprintf("%s %.0f → %.0f (+%.1f%%)", name, was, now, change))
+		}
+	}
+	check("p50_ns", float64(base.P50Nanos), float64(r.P50Nanos))
+	check("p99_ns", float64(base.P99Nanos), float64(r.P99Nanos))
+	check("p999_ns", float64(base.P999Nanos), float64(r.P999Nanos))
+	check("allocs_per_order", base.AllocsPerOrder, r.AllocsPerOrder)
+	return out
+}
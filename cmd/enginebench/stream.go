@@ -0,0 +1,93 @@
+package main
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+// op is one step of the synthetic stream: a new order, or a cancel of
+// an order placed earlier.
+type op struct {
+	order  *models.Order
+	cancel uuid.UUID
+	symbol string
+}
+
+// streamMix is the share of each kind of step, in percent. What's left
+// after cancels and crossing orders rests passively near the touch.
+type streamMix struct {
+	CancelPct   int `json:"cancel_pct"`
+	CrossingPct int `json:"crossing_pct"`
+}
+
+// generate builds the whole stream up front so the timed loop only
+// measures the matcher. The same seed always yields the same stream.
+func generate(seed uint64, n, symbols int, mix streamMix) []op {
+	rng := rand.New(rand.NewPCG(seed, seed))
+	names := make([]string, symbols)
+	mids := make([]float64, symbols)
+	for i := range names {
+		names[i] = fmt.Sprintf("SYM-%d", i)
+		mids[i] = 100
+	}
+
+	ops := make([]op, 0, n)
+	resting := make([][]uuid.UUID, symbols)
+	base := time.Date(2025, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	for i := 0; i < n; i++ {
+		s := rng.IntN(symbols)
+		roll := rng.IntN(100)
+
+		if roll < mix.CancelPct && len(resting[s]) > 0 {
+			j := rng.IntN(len(resting[s]))
+			id := resting[s][j]
+			resting[s][j] = resting[s][len(resting[s])-1]
+			resting[s] = resting[s][:len(resting[s])-1]
+			ops = append(ops, op{cancel: id, symbol: names[s]})
+			continue
+		}
+
+		// Mid drifts a tick at a time so the touch keeps moving.
+		mids[s] += float64(rng.IntN(3)-1) * 0.01
+
+		side := models.Buy
+		if rng.IntN(2) == 1 {
+			side = models.Sell
+		}
+		ticks := float64(rng.IntN(50)+1) * 0.01
+		crossing := roll < mix.CancelPct+mix.CrossingPct
+
+		// Passive orders sit behind the mid, crossing ones reach through it.
+		price := mids[s] - ticks
+		if side == models.Sell {
+			price = mids[s] + ticks
+		}
+		if crossing {
+			price = 2*mids[s] - price
+		}
+
+		qty := float64(rng.IntN(10) + 1)
+		order := &models.Order{
+			ID:           uuid.New(),
+			UserID:       uuid.New(),
+			Symbol:       names[s],
+			Side:         side,
+			Type:         models.Limit,
+			Price:        price,
+			Quantity:     qty,
+			RemainingQty: qty,
+			Status:       models.StatusOpen,
+			CreatedAt:    base.Add(time.Duration(i) * time.Microsecond),
+		}
+		ops = append(ops, op{order: order, symbol: names[s]})
+		if !crossing {
+			resting[s] = append(resting[s], order.ID)
+		}
+	}
+	return ops
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -13,8 +14,13 @@ import (
 	"github.com/Im-Manav/ome/internal/cache"
 	"github.com/Im-Manav/ome/internal/config"
 	"github.com/Im-Manav/ome/internal/db"
+	"github.com/Im-Manav/ome/internal/grpcapi"
+	"github.com/Im-Manav/ome/internal/health"
 	"github.com/Im-Manav/ome/internal/kafka"
+	"github.com/Im-Manav/ome/internal/metrics"
+	"github.com/Im-Manav/ome/internal/ports"
 	"github.com/Im-Manav/ome/internal/service"
+	"github.com/Im-Manav/ome/internal/tracing"
 	"github.com/Im-Manav/ome/pkg/logger"
 	"github.com/gin-gonic/gin"
 	"go.uber.org/zap"
@@ -32,6 +38,12 @@ func main() {
 	}
 	defer logger.Sync()
 
+	shutdownTracing, err := tracing.Init(context.Background(), "gateway", cfg.TracesExporter)
+	if err != nil {
+		logger.Fatal("tracing init failed", logger.Err(err))
+	}
+	defer shutdownTracing(context.Background())
+
 	database, err := db.NewConnection(cfg)
 	if err != nil {
 		logger.Fatal("postgres connection failed", logger.Err(err))
@@ -41,16 +53,34 @@ func main() {
 	}
 	logger.Info("postgres connected and migrated")
 
-	// Redis
-	redisClient, err := cache.NewClient(cfg)
-	if err != nil {
-		logger.Fatal("redis connection failed", logger.Err(err))
+	// Cache — Redis unless CACHE_BACKEND=memory (single instance, no Redis)
+	var cacheClient interface {
+		ports.Cache
+		Ping(ctx context.Context) error
+		Close() error
 	}
-	defer redisClient.Close()
-	logger.Info("redis connected")
+	switch cfg.CacheBackend {
+	case "redis":
+		redisClient, err := cache.NewClient(cfg)
+		if err != nil {
+			logger.Fatal("redis connection failed", logger.Err(err))
+		}
+		cacheClient = redisClient
+		logger.Info("redis connected")
+	case "memory":
+		cacheClient = cache.NewMemory()
+		logger.Info("using in-memory cache")
+	default:
+		logger.Fatal("unknown cache backend", zap.String("backend", cfg.CacheBackend))
+	}
+	defer cacheClient.Close()
 
 	// Kafka producer
-	producer := kafka.NewProducer(cfg.KafkaBrokers)
+	codecs, err := kafka.ParseTopicCodecs(cfg.KafkaCodecs)
+	if err != nil {
+		logger.Fatal("invalid kafka codec config", logger.Err(err))
+	}
+	producer := kafka.NewProducer(cfg.KafkaBrokers, kafka.SourceGateway, codecs)
 	defer producer.Close()
 	logger.Info("kafka producer ready")
 
@@ -60,10 +90,45 @@ func main() {
 	// Websocket Hub
 	hub := ws.NewHub()
 	go hub.Run()
+	metrics.RegisterWebSocketClients(hub.ClientCount)
 
 	// Services
-	authSvc := service.NewAuthService(repo, redisClient, cfg)
-	orderSvc := service.NewOrderService(repo, repo, producer, redisClient, hub)
+	authSvc := service.NewAuthService(repo, repo, cacheClient, cfg)
+	orderSvc := service.NewOrderService(repo, repo, producer, cacheClient, hub)
+	orderSvc.SetFees(service.FeeSchedule{MakerBps: cfg.FeeMakerBps, TakerBps: cfg.FeeTakerBps})
+	if err := cfg.CheckAPIKeyEncryptionKey(); err != nil {
+		logger.Fatal("api key service init failed", logger.Err(err))
+	}
+	keySvc, err := service.NewAPIKeyService(repo, cacheClient, cfg.APIKeyEncryptionKey)
+	if err != nil {
+		logger.Fatal("api key service init failed", logger.Err(err))
+	}
+	instrumentSvc := service.NewInstrumentService(repo)
+	if err := instrumentSvc.Seed(cfg.Symbols); err != nil {
+		logger.Fatal("instrument seed failed", logger.Err(err))
+	}
+	orderSvc.SetInstruments(instrumentSvc)
+	executions := service.NewExecutionWaiter(cacheClient, cfg.OrderWaitTimeout)
+	if err := executions.Start(context.Background()); err != nil {
+		logger.Fatal("execution subscription failed", logger.Err(err))
+	}
+	orderSvc.SetExecutions(executions)
+	// Orders are saved with an outbox event and published by the relay,
+	// which runs until shutdown, after the last request is served.
+	relayCtx, stopRelay := context.WithCancel(context.Background())
+	outbox := service.NewOutboxRelay(repo, producer, cfg.OutboxPollInterval)
+	go outbox.Start(relayCtx)
+	orderSvc.SetOutbox(outbox)
+	adminSvc := service.NewAdminService(repo, repo, cacheClient)
+	if err := adminSvc.PromoteAdmins(context.Background(), cfg.AdminEmails); err != nil {
+		logger.Fatal("admin promotion failed", logger.Err(err))
+	}
+	if err := adminSvc.SyncFrozen(context.Background()); err != nil {
+		logger.Fatal("frozen account sync failed", logger.Err(err))
+	}
+	if err := authSvc.SyncRevokedSessions(context.Background()); err != nil {
+		logger.Fatal("revoked session sync failed", logger.Err(err))
+	}
 
 	// Gin
 	if cfg.Env == "production" {
@@ -72,11 +137,29 @@ func main() {
 
 	r := gin.New()
 	r.Use(gin.Recovery())
-	r.Use(requestLogger())
-
-	handler := api.NewHandler(orderSvc, authSvc, hub, redisClient)
+	r.Use(api.Tracing("gateway"))
+	r.Use(api.RequestLogger())
+
+	candleSvc := service.NewCandleService(repo)
+
+	handler := api.NewHandler(api.Services{
+		Orders:      orderSvc,
+		Auth:        authSvc,
+		Candles:     candleSvc,
+		APIKeys:     keySvc,
+		Instruments: instrumentSvc,
+		Admin:       adminSvc,
+	}, hub, cacheClient)
 	handler.RegisterRoutes(r)
 
+	// Probes — the gateway only produces to Kafka, so there is no lag to watch
+	checks := health.New()
+	checks.AddReadiness("postgres", health.Database(database))
+	checks.AddReadiness("cache", health.Cache(cacheClient))
+	checks.AddReadiness("kafka", health.KafkaBrokers(cfg.KafkaBrokers))
+	r.GET("/livez", gin.WrapF(checks.LiveHandler))
+	r.GET("/readyz", gin.WrapF(checks.ReadyHandler))
+
 	srv := &http.Server{
 		Addr:         ":" + cfg.GatewayPort,
 		Handler:      r,
@@ -93,6 +176,19 @@ func main() {
 		}
 	}()
 
+	// gRPC trading API, on its own port
+	grpcLis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		logger.Fatal("grpc listen failed", logger.Err(err))
+	}
+	grpcSrv := grpcapi.NewServer(orderSvc, authSvc, cacheClient)
+	go func() {
+		logger.Info("grpc server starting", zap.String("port", cfg.GRPCPort))
+		if err := grpcSrv.Serve(grpcLis); err != nil {
+			logger.Fatal("grpc server failed", logger.Err(err))
+		}
+	}()
+
 	// ── Graceful shutdown ─────────────────────────────────────────────────────
 	// Wait for SIGINT or SIGTERM (sent by K8s on pod termination)
 	quit := make(chan os.Signal, 1)
@@ -108,23 +204,9 @@ func main() {
 	if err := srv.Shutdown(ctx); err != nil {
 		logger.Error("gateway forced shutdown", logger.Err(err))
 	}
+	grpcapi.Shutdown(ctx, grpcSrv)
+	stopRelay()
 
 	logger.Info("gateway stopped cleanly")
 
 }
-
-// requestLogger returns a Gin middleware that logs every request
-// with method, path, status, and latency using zap.
-func requestLogger() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		start := time.Now()
-		c.Next()
-		logger.Info("request",
-			zap.String("method", c.Request.Method),
-			zap.String("path", c.Request.URL.Path),
-			zap.Int("status", c.Writer.Status()),
-			zap.Duration("latency", time.Since(start)),
-			zap.String("ip", c.ClientIP()),
-		)
-	}
-}
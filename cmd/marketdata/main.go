@@ -10,7 +10,11 @@ import (
 	"github.com/Im-Manav/ome/internal/cache"
 	"github.com/Im-Manav/ome/internal/config"
 	"github.com/Im-Manav/ome/internal/db"
+	"github.com/Im-Manav/ome/internal/health"
 	"github.com/Im-Manav/ome/internal/kafka"
+	"github.com/Im-Manav/ome/internal/marketdata"
+	"github.com/Im-Manav/ome/internal/metrics"
+	"github.com/Im-Manav/ome/internal/tracing"
 	"github.com/Im-Manav/ome/pkg/logger"
 	"go.uber.org/zap"
 )
@@ -31,6 +35,12 @@ func main() {
 	}
 	defer logger.Sync()
 
+	shutdownTracing, err := tracing.Init(context.Background(), "marketdata", cfg.TracesExporter)
+	if err != nil {
+		logger.Fatal("tracing init failed", logger.Err(err))
+	}
+	defer shutdownTracing(context.Background())
+
 	database, err := db.NewConnection(cfg)
 	if err != nil {
 		logger.Fatal("postgres connection failed", logger.Err(err))
@@ -48,16 +58,27 @@ func main() {
 
 	// Builder accumulates trades into in-memory candles per symbol
 	// and flushes them to TimescaleDB + Redis pub/sub.
-	builder := NewCandleBuilder(repo, redisClient)
+	builder := marketdata.NewCandleBuilder(repo, redisClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// Tickers keep a rolling 24h window per symbol in Redis. Replay the
+	// stored trades first so they're complete straight after a restart.
+	tickers := marketdata.NewTickerTracker(redisClient)
+	recent, err := repo.GetTradesSince(time.Now().Add(-marketdata.TickerWindow))
+	if err != nil {
+		logger.Fatal("ticker warm-up failed", logger.Err(err))
+	}
+	tickers.Warm(ctx, recent)
+	logger.Info("tickers warmed", zap.Int("trades", len(recent)))
 
 	// Consume the trades topic
 	consumer := kafka.NewTradeConsumer(cfg.KafkaBrokers, kafka.GroupMarketData)
 	consumer.AddHandler(builder.HandleTrade)
+	consumer.AddHandler(tickers.HandleTrade)
 	defer consumer.Close()
 
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
 	go func() {
 		if err := consumer.Start(ctx); err != nil {
 			logger.Error("trade consumer stopped", logger.Err(err))
@@ -67,6 +88,16 @@ func main() {
 	// Periodically flush in-progress candles even if no new trades arrive —
 	// otherwise a quiet market would never close out the current candle.
 	go builder.StartFlushLoop(ctx, candleInterval)
+	go tickers.StartRefreshLoop(ctx, time.Minute)
+
+	checks := health.New()
+	checks.AddReadiness("postgres", health.Database(database))
+	checks.AddReadiness("redis", health.Cache(redisClient))
+	checks.AddReadiness("kafka", health.KafkaBrokers(cfg.KafkaBrokers))
+	checks.AddReadiness("trades_consumer_lag", health.ConsumerLag(consumer.Lag, cfg.HealthMaxConsumerLag))
+
+	metricsSrv := metrics.Serve(":"+cfg.MarketDataPort, checks)
+	defer metricsSrv.Close()
 
 	logger.Info("market data service started")
 
@@ -79,6 +110,4 @@ func main() {
 	cancel()
 	time.Sleep(500 * time.Millisecond)
 	logger.Info("market data service stopped")
-
-	_ = zap.L()
 }
@@ -0,0 +1,265 @@
+// Command ome-standalone runs the gateway, matching engine and market
+// data service in one process. Kafka is replaced by the in-memory event
+// bus, Redis by an in-memory cache and Postgres by embedded SQLite, so
+// the whole exchange comes up with a single command and no dependencies.
+package main
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/ai"
+	"github.com/Im-Manav/ome/internal/api"
+	"github.com/Im-Manav/ome/internal/api/admin"
+	"github.com/Im-Manav/ome/internal/api/ws"
+	"github.com/Im-Manav/ome/internal/cache"
+	"github.com/Im-Manav/ome/internal/config"
+	"github.com/Im-Manav/ome/internal/db"
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/internal/eventbus"
+	"github.com/Im-Manav/ome/internal/grpcapi"
+	"github.com/Im-Manav/ome/internal/health"
+	"github.com/Im-Manav/ome/internal/kafka"
+	"github.com/Im-Manav/ome/internal/marketdata"
+	"github.com/Im-Manav/ome/internal/metrics"
+	"github.com/Im-Manav/ome/internal/service"
+	"github.com/Im-Manav/ome/internal/tracing"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const candleInterval = time.Minute
+
+func main() {
+	cfg, err := config.Load()
+	if err != nil {
+		panic("failed to load config: " + err.Error())
+	}
+
+	if err := logger.Init(cfg.Env); err != nil {
+		panic("failed to init logger: " + err.Error())
+	}
+	defer logger.Sync()
+
+	shutdownTracing, err := tracing.Init(context.Background(), "ome-standalone", cfg.TracesExporter)
+	if err != nil {
+		logger.Fatal("tracing init failed", logger.Err(err))
+	}
+	defer shutdownTracing(context.Background())
+
+	// ── Storage ───────────────────────────────────────────────────────────────
+	database, err := db.NewSQLiteConnection(cfg.StandaloneDBPath)
+	if err != nil {
+		logger.Fatal("sqlite open failed", logger.Err(err))
+	}
+	if err := db.Migrate(database); err != nil {
+		logger.Fatal("migration failed", logger.Err(err))
+	}
+	repo := db.NewSQLiteRepository(database)
+	logger.Info("sqlite ready", zap.String("path", cfg.StandaloneDBPath))
+
+	// ── In-memory infrastructure ──────────────────────────────────────────────
+	bus := eventbus.New(eventbus.DefaultPartitions)
+	memCache := cache.NewMemory()
+	defer memCache.Close()
+
+	hub := ws.NewHub()
+	go hub.Run()
+	metrics.RegisterWebSocketClients(hub.ClientCount)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// ── Matching engine ───────────────────────────────────────────────────────
+	// Same wiring as cmd/engine, except the hub is in-process so the
+	// post-match handlers can broadcast to WebSocket clients directly.
+	enginePub := bus.Publisher(kafka.SourceEngine, nil)
+	matcher := engine.NewMatcher()
+	metrics.RegisterEngine(matcher)
+	engineSvc := service.NewOrderService(repo, repo, enginePub, memCache, hub)
+
+	orders := kafka.NewOrderConsumerFromReader(
+		bus.Reader(kafka.TopicOrders, kafka.GroupEngine),
+		matcher,
+		enginePub,
+	)
+	defer orders.Close()
+	orders.SetOrders(repo)
+	orders.AddHandler(engineSvc.PostMatchHandler)
+	snapshots := service.NewSnapshotPublisher(matcher, memCache, hub)
+	orders.AddHandler(snapshots.PostMatchHandler)
+	orders.AddCancelHandler(snapshots.CancelHandler)
+
+	// Resting orders survive restarts in the SQLite file; put them back
+	// in the books before taking new ones.
+	restored, err := service.RestoreBooks(ctx, repo, matcher)
+	if err != nil {
+		logger.Fatal("order book rebuild failed", logger.Err(err))
+	}
+	for _, symbol := range matcher.Symbols() {
+		if err := snapshots.Publish(ctx, symbol); err != nil {
+			logger.Warn("snapshot publish failed", logger.Err(err), zap.String("symbol", symbol))
+		}
+	}
+	logger.Info("order books rebuilt", zap.Int("orders", restored))
+
+	go func() {
+		if err := orders.Start(ctx); err != nil {
+			logger.Error("order consumer stopped", logger.Err(err))
+		}
+	}()
+
+	// ── Market data ───────────────────────────────────────────────────────────
+	builder := marketdata.NewCandleBuilder(repo, memCache)
+	tickers := marketdata.NewTickerTracker(memCache)
+	recent, err := repo.GetTradesSince(time.Now().Add(-marketdata.TickerWindow))
+	if err != nil {
+		logger.Fatal("ticker warm-up failed", logger.Err(err))
+	}
+	tickers.Warm(ctx, recent)
+
+	trades := kafka.NewTradeConsumerFromReader(bus.Reader(kafka.TopicTrades, kafka.GroupMarketData))
+	defer trades.Close()
+	trades.AddHandler(builder.HandleTrade)
+	trades.AddHandler(tickers.HandleTrade)
+
+	go func() {
+		if err := trades.Start(ctx); err != nil {
+			logger.Error("trade consumer stopped", logger.Err(err))
+		}
+	}()
+	go builder.StartFlushLoop(ctx, candleInterval)
+	go tickers.StartRefreshLoop(ctx, time.Minute)
+
+	// ── Predictor (optional) ──────────────────────────────────────────────────
+	// Off by default — it needs an LLM endpoint, which defeats the point
+	// of a dependency-free binary.
+	if cfg.StandalonePredictor {
+		aiClient := ai.NewClient(cfg.AIBaseURL, cfg.AIModel, cfg.AIAPIKey)
+		predictor := ai.NewPredictor(repo, memCache, aiClient, cfg.Symbols)
+		go predictor.Run(ctx, time.Duration(cfg.PredictionInterval)*time.Second)
+	}
+
+	// ── Gateway ───────────────────────────────────────────────────────────────
+	authSvc := service.NewAuthService(repo, repo, memCache, cfg)
+	gatewayPub := bus.Publisher(kafka.SourceGateway, nil)
+	orderSvc := service.NewOrderService(repo, repo, gatewayPub, memCache, hub)
+	orderSvc.SetFees(service.FeeSchedule{MakerBps: cfg.FeeMakerBps, TakerBps: cfg.FeeTakerBps})
+	if err := cfg.CheckAPIKeyEncryptionKey(); err != nil {
+		logger.Fatal("api key service init failed", logger.Err(err))
+	}
+	keySvc, err := service.NewAPIKeyService(repo, memCache, cfg.APIKeyEncryptionKey)
+	if err != nil {
+		logger.Fatal("api key service init failed", logger.Err(err))
+	}
+	instrumentSvc := service.NewInstrumentService(repo)
+	if err := instrumentSvc.Seed(cfg.Symbols); err != nil {
+		logger.Fatal("instrument seed failed", logger.Err(err))
+	}
+	orderSvc.SetInstruments(instrumentSvc)
+	executions := service.NewExecutionWaiter(memCache, cfg.OrderWaitTimeout)
+	if err := executions.Start(ctx); err != nil {
+		logger.Fatal("execution subscription failed", logger.Err(err))
+	}
+	orderSvc.SetExecutions(executions)
+	outbox := service.NewOutboxRelay(repo, gatewayPub, cfg.OutboxPollInterval)
+	go outbox.Start(ctx)
+	orderSvc.SetOutbox(outbox)
+	adminSvc := service.NewAdminService(repo, repo, memCache)
+	if err := adminSvc.PromoteAdmins(context.Background(), cfg.AdminEmails); err != nil {
+		logger.Fatal("admin promotion failed", logger.Err(err))
+	}
+	if err := adminSvc.SyncFrozen(context.Background()); err != nil {
+		logger.Fatal("frozen account sync failed", logger.Err(err))
+	}
+	if err := authSvc.SyncRevokedSessions(context.Background()); err != nil {
+		logger.Fatal("revoked session sync failed", logger.Err(err))
+	}
+
+	if cfg.Env == "production" {
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	r := gin.New()
+	r.Use(gin.Recovery())
+	r.Use(api.Tracing("ome-standalone"))
+	r.Use(api.RequestLogger())
+	candleSvc := service.NewCandleService(repo)
+	handler := api.NewHandler(api.Services{
+		Orders:      orderSvc,
+		Auth:        authSvc,
+		Candles:     candleSvc,
+		APIKeys:     keySvc,
+		Instruments: instrumentSvc,
+		Admin:       adminSvc,
+	}, hub, memCache)
+	handler.RegisterRoutes(r)
+
+	// Probes — the books are rebuilt before the server starts, so only
+	// the process and the database file are left to check.
+	checks := health.New()
+	checks.AddLiveness("matcher", func(context.Context) error {
+		matcher.Symbols() // times out if a match is wedged holding the lock
+		return nil
+	})
+	checks.AddReadiness("sqlite", health.Database(database))
+	r.GET("/livez", gin.WrapF(checks.LiveHandler))
+	r.GET("/readyz", gin.WrapF(checks.ReadyHandler))
+
+	// The engine admin API shares the gateway's port here, under
+	// /admin/v1, when ENGINE_ADMIN_TOKEN is set.
+	if cfg.EngineAdminToken != "" {
+		admin.NewHandler(matcher, snapshots, cfg.EngineAdminToken).RegisterAdminRoutes(r)
+	}
+
+	srv := &http.Server{
+		Addr:         ":" + cfg.GatewayPort,
+		Handler:      r,
+		ReadTimeout:  10 * time.Second,
+		WriteTimeout: 10 * time.Second,
+		IdleTimeout:  120 * time.Second,
+	}
+
+	go func() {
+		logger.Info("standalone exchange starting", zap.String("port", cfg.GatewayPort))
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Fatal("gateway failed", logger.Err(err))
+		}
+	}()
+
+	// gRPC trading API, on its own port
+	grpcLis, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		logger.Fatal("grpc listen failed", logger.Err(err))
+	}
+	grpcSrv := grpcapi.NewServer(orderSvc, authSvc, memCache)
+	go func() {
+		logger.Info("grpc server starting", zap.String("port", cfg.GRPCPort))
+		if err := grpcSrv.Serve(grpcLis); err != nil {
+			logger.Fatal("grpc server failed", logger.Err(err))
+		}
+	}()
+
+	// ── Graceful shutdown ─────────────────────────────────────────────────────
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	<-quit
+
+	logger.Info("shutdown signal received")
+
+	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer shutdownCancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		logger.Error("gateway forced shutdown", logger.Err(err))
+	}
+	grpcapi.Shutdown(shutdownCtx, grpcSrv)
+
+	cancel()
+	logger.Info("standalone exchange stopped")
+}
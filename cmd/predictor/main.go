@@ -11,14 +11,13 @@ import (
 	"github.com/Im-Manav/ome/internal/cache"
 	"github.com/Im-Manav/ome/internal/config"
 	"github.com/Im-Manav/ome/internal/db"
+	"github.com/Im-Manav/ome/internal/health"
+	"github.com/Im-Manav/ome/internal/metrics"
+	"github.com/Im-Manav/ome/internal/tracing"
 	"github.com/Im-Manav/ome/pkg/logger"
 	"go.uber.org/zap"
 )
 
-// symbols to generate predictions for — matches the symbols
-// the market simulator will trade.
-var symbols = []string{"BTC-USD", "ETH-USD", "AAPL", "TSLA"}
-
 func main() {
 	cfg, err := config.Load()
 	if err != nil {
@@ -30,6 +29,12 @@ func main() {
 	}
 	defer logger.Sync()
 
+	shutdownTracing, err := tracing.Init(context.Background(), "predictor", cfg.TracesExporter)
+	if err != nil {
+		logger.Fatal("tracing init failed", logger.Err(err))
+	}
+	defer shutdownTracing(context.Background())
+
 	database, err := db.NewConnection(cfg)
 	if err != nil {
 		logger.Fatal("postgres connection failed", logger.Err(err))
@@ -43,33 +48,28 @@ func main() {
 	defer redisClient.Close()
 
 	aiClient := ai.NewClient(cfg.AIBaseURL, cfg.AIModel, cfg.AIAPIKey)
+	predictor := ai.NewPredictor(repo, redisClient, aiClient, cfg.Symbols)
 
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
 	interval := time.Duration(cfg.PredictionInterval) * time.Second
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
 
 	logger.Info("predictor started",
-		zap.Strings("symbols", symbols),
+		zap.Strings("symbols", cfg.Symbols),
 		zap.Duration("interval", interval),
 		zap.String("provider", cfg.AIProvider),
 	)
 
 	// Run once immediately on startup, then on each tick
-	runPredictions(ctx, repo, redisClient, aiClient)
-
-	go func() {
-		for {
-			select {
-			case <-ticker.C:
-				runPredictions(ctx, repo, redisClient, aiClient)
-			case <-ctx.Done():
-				return
-			}
-		}
-	}()
+	go predictor.Run(ctx, interval)
+
+	checks := health.New()
+	checks.AddReadiness("postgres", health.Database(database))
+	checks.AddReadiness("redis", health.Cache(redisClient))
+
+	metricsSrv := metrics.Serve(":"+cfg.PredictorPort, checks)
+	defer metricsSrv.Close()
 
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
@@ -80,65 +80,3 @@ func main() {
 	time.Sleep(500 * time.Millisecond)
 	logger.Info("predictor stopped")
 }
-
-// runPredictions generates and broadcasts a prediction for each symbol.
-// Failures for one symbol don't block the others — each is independent.
-func runPredictions(
-	ctx context.Context,
-	repo *db.Repository,
-	redisClient *cache.Client,
-	aiClient *ai.Client,
-) {
-	for _, symbol := range symbols {
-		predictForSymbol(ctx, symbol, repo, redisClient, aiClient)
-	}
-}
-
-func predictForSymbol(
-	ctx context.Context,
-	symbol string,
-	repo *db.Repository,
-	redisClient *cache.Client,
-	aiClient *ai.Client,
-) {
-	candles, err := repo.GetOHLCV(symbol, "1 minute", 20)
-	if err != nil {
-		logger.Error("failed to fetch candles for prediction",
-			logger.Err(err), zap.String("symbol", symbol))
-		return
-	}
-
-	if len(candles) < 3 {
-		// Not enough data yet — common at startup before the
-		// market simulator has been running for a few minutes
-		logger.Info("skipping prediction, insufficient data",
-			zap.String("symbol", symbol), zap.Int("candles", len(candles)))
-		return
-	}
-
-	// Get current order book snapshot for bid/ask context
-	snap, err := redisClient.GetOrderBookSnapshot(ctx, symbol)
-	var bestBid, bestAsk float64
-	if snap != nil {
-		if len(snap.Bids) > 0 {
-			bestBid = snap.Bids[0].Price
-		}
-		if len(snap.Asks) > 0 {
-			bestAsk = snap.Asks[0].Price
-		}
-	}
-
-	prediction, err := aiClient.PredictPrice(ctx, symbol, candles, bestBid, bestAsk)
-	if err != nil {
-		logger.Error("prediction failed",
-			logger.Err(err), zap.String("symbol", symbol))
-		return
-	}
-
-	// Broadcast via Redis pub/sub — the gateway's WebSocket hub
-	// will need a small addition (Step 9/10) to forward this channel
-	channel := "predictions:" + symbol
-	if err := redisClient.Publish(ctx, channel, prediction); err != nil {
-		logger.Error("failed to publish prediction", logger.Err(err))
-	}
-}
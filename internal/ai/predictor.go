@@ -0,0 +1,104 @@
+package ai
+
+import (
+	"context"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/metrics"
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"go.uber.org/zap"
+)
+
+// Predictor periodically asks the LLM for a short-term direction on
+// each symbol and publishes the result for the dashboard.
+type Predictor struct {
+	candles ports.OHLCVRepository
+	cache   ports.Cache
+	client  *Client
+	symbols []string
+}
+
+func NewPredictor(
+	candles ports.OHLCVRepository,
+	cache ports.Cache,
+	client *Client,
+	symbols []string,
+) *Predictor {
+	return &Predictor{
+		candles: candles,
+		cache:   cache,
+		client:  client,
+		symbols: symbols,
+	}
+}
+
+// Run predicts once immediately, then on every tick until ctx is done.
+func (p *Predictor) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	p.RunOnce(ctx)
+	for {
+		select {
+		case <-ticker.C:
+			p.RunOnce(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// RunOnce generates and broadcasts a prediction for each symbol.
+// Failures for one symbol don't block the others — each is independent.
+func (p *Predictor) RunOnce(ctx context.Context) {
+	for _, symbol := range p.symbols {
+		p.predictForSymbol(ctx, symbol)
+	}
+}
+
+func (p *Predictor) predictForSymbol(ctx context.Context, symbol string) {
+	candles, err := p.candles.GetOHLCV(symbol, "1 minute", 20)
+	if err != nil {
+		logger.Error("failed to fetch candles for prediction",
+			logger.Err(err), zap.String("symbol", symbol))
+		return
+	}
+
+	if len(candles) < 3 {
+		// Not enough data yet — common at startup before the
+		// market simulator has been running for a few minutes
+		logger.Info("skipping prediction, insufficient data",
+			zap.String("symbol", symbol), zap.Int("candles", len(candles)))
+		return
+	}
+
+	// Get current order book snapshot for bid/ask context
+	snap, _ := p.cache.GetOrderBookSnapshot(ctx, symbol)
+	var bestBid, bestAsk float64
+	if snap != nil {
+		if len(snap.Bids) > 0 {
+			bestBid = snap.Bids[0].Price
+		}
+		if len(snap.Asks) > 0 {
+			bestAsk = snap.Asks[0].Price
+		}
+	}
+
+	start := time.Now()
+	prediction, err := p.client.PredictPrice(ctx, symbol, candles, bestBid, bestAsk)
+	metrics.PredictionDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.PredictionFailures.WithLabelValues(symbol).Inc()
+		logger.Error("prediction failed",
+			logger.Err(err), zap.String("symbol", symbol))
+		return
+	}
+
+	// Broadcast via pub/sub — the gateway's WebSocket hub
+	// forwards the predictions channel to dashboard clients.
+	channel := "predictions:" + symbol
+	if err := p.cache.Publish(ctx, channel, prediction); err != nil {
+		logger.Error("failed to publish prediction", logger.Err(err))
+	}
+}
@@ -0,0 +1,144 @@
+// Package admin is the engine's operator API. It reads live book state
+// straight from the matcher, so unlike the gateway's GET /orderbook it
+// never depends on a Redis snapshot being fresh.
+package admin
+
+import (
+	"context"
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Snapshotter republishes a symbol's order book snapshot on demand.
+// Implemented by service.SnapshotPublisher.
+type Snapshotter interface {
+	Publish(ctx context.Context, symbol string) error
+}
+
+// Handler holds the admin API dependencies.
+type Handler struct {
+	matcher   *engine.Matcher
+	snapshots Snapshotter
+	token     string
+}
+
+func NewHandler(matcher *engine.Matcher, snapshots Snapshotter, token string) *Handler {
+	return &Handler{
+		matcher:   matcher,
+		snapshots: snapshots,
+		token:     token,
+	}
+}
+
+// RegisterAdminRoutes adds the /admin/v1 routes to an existing router.
+// The caller owns /health and /metrics.
+func (h *Handler) RegisterAdminRoutes(r gin.IRouter) {
+	admin := r.Group("/admin/v1")
+	admin.Use(h.auth())
+	{
+		admin.GET("/symbols", h.ListSymbols)
+		admin.GET("/symbols/:symbol/stats", h.Stats)
+		admin.GET("/symbols/:symbol/book", h.Book)
+		admin.POST("/symbols/:symbol/halt", h.Halt)
+		admin.POST("/symbols/:symbol/resume", h.Resume)
+		admin.POST("/symbols/:symbol/snapshot", h.Snapshot)
+		admin.GET("/orders/:id", h.GetOrder)
+	}
+}
+
+// auth checks the static admin token from ENGINE_ADMIN_TOKEN.
+func (h *Handler) auth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := strings.TrimPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if h.token == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "invalid admin token"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// ─── Read endpoints ───────────────────────────────────────────────────────────
+
+func (h *Handler) ListSymbols(c *gin.Context) {
+	symbols := h.matcher.Symbols()
+	stats := make([]engine.SymbolStats, 0, len(symbols))
+	for _, symbol := range symbols {
+		if s, ok := h.matcher.Stats(symbol); ok {
+			stats = append(stats, s)
+		}
+	}
+	c.JSON(http.StatusOK, gin.H{"symbols": stats})
+}
+
+func (h *Handler) Stats(c *gin.Context) {
+	stats, ok := h.matcher.Stats(c.Param("symbol"))
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown symbol"})
+		return
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+// Book dumps every resting order (L3) for a symbol, best first.
+func (h *Handler) Book(c *gin.Context) {
+	symbol := c.Param("symbol")
+	bids, asks, ok := h.matcher.Book(symbol)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "unknown symbol"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"symbol": symbol,
+		"bids":   bids,
+		"asks":   asks,
+	})
+}
+
+func (h *Handler) GetOrder(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	order, ok := h.matcher.Order(orderID)
+	if !ok {
+		c.JSON(http.StatusNotFound, gin.H{"error": "order not resting in the engine"})
+		return
+	}
+	c.JSON(http.StatusOK, order)
+}
+
+// ─── Commands ─────────────────────────────────────────────────────────────────
+
+func (h *Handler) Halt(c *gin.Context) {
+	symbol := c.Param("symbol")
+	h.matcher.Halt(symbol)
+	logger.Warn("symbol halted by operator", zap.String("symbol", symbol))
+	c.JSON(http.StatusOK, gin.H{"symbol": symbol, "halted": true})
+}
+
+func (h *Handler) Resume(c *gin.Context) {
+	symbol := c.Param("symbol")
+	h.matcher.Resume(symbol)
+	logger.Warn("symbol resumed by operator", zap.String("symbol", symbol))
+	c.JSON(http.StatusOK, gin.H{"symbol": symbol, "halted": false})
+}
+
+func (h *Handler) Snapshot(c *gin.Context) {
+	symbol := c.Param("symbol")
+	if err := h.snapshots.Publish(c.Request.Context(), symbol); err != nil {
+		logger.Error("admin snapshot failed", logger.Err(err), zap.String("symbol", symbol))
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "snapshot failed"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"symbol": symbol, "published": true})
+}
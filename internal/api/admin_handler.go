@@ -0,0 +1,212 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// ─── Admin handlers ───────────────────────────────────────────────────────────
+
+// AdminSearchUsers lists users, newest first.
+//
+//	GET /api/v1/admin/users?email=alice&role=operator&frozen=true&limit=50
+func (h *Handler) AdminSearchUsers(c *gin.Context) {
+	filter := models.UserFilter{Email: c.Query("email")}
+	if raw := c.Query("role"); raw != "" {
+		role, ok := models.ParseRole(raw)
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid role"})
+			return
+		}
+		filter.Role = role
+	}
+	if raw := c.Query("frozen"); raw != "" {
+		frozen, err := strconv.ParseBool(raw)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "frozen must be true or false"})
+			return
+		}
+		filter.Frozen = &frozen
+	}
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		filter.Limit = limit
+	}
+
+	users, err := h.adminSvc.SearchUsers(c.Request.Context(), filter)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"users": users})
+}
+
+// AdminUserOrders lists a user's orders with the same filters as
+// GET /api/v1/orders.
+func (h *Handler) AdminUserOrders(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	filter, err := parseOrderFilter(c)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	page, err := h.orderSvc.GetUserOrders(c.Request.Context(), userID, filter)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// AdminCancelOrder cancels an order whoever owns it.
+func (h *Handler) AdminCancelOrder(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+	c.Set(ContextAuditTarget, "order:"+orderID.String())
+
+	if err := h.orderSvc.ForceCancelOrder(c.Request.Context(), orderID); err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CancelOrderResponse{
+		OrderID: orderID.String(),
+		Status:  models.StatusCancelled.String(),
+	})
+}
+
+// AdminUpsertInstrument lists a new instrument or halts and resumes
+// trading in an existing one.
+func (h *Handler) AdminUpsertInstrument(c *gin.Context) {
+	var req models.UpsertInstrumentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	inst, err := h.instrumentSvc.Upsert(c.Request.Context(), c.Param("symbol"), req)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, inst)
+}
+
+func (h *Handler) AdminSetRole(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.SetRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.adminSvc.SetRole(c.Request.Context(), mustGetUserID(c), userID, req.Role)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *Handler) AdminFreezeUser(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	var req models.FreezeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	user, err := h.adminSvc.Freeze(c.Request.Context(), mustGetUserID(c), userID, req.Reason)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+func (h *Handler) AdminUnfreezeUser(c *gin.Context) {
+	userID, ok := parseUserIDParam(c)
+	if !ok {
+		return
+	}
+
+	user, err := h.adminSvc.Unfreeze(c.Request.Context(), userID)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, user)
+}
+
+// AdminAuditLog returns the most recent audit entries, newest first.
+func (h *Handler) AdminAuditLog(c *gin.Context) {
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		n, err := strconv.Atoi(raw)
+		if err != nil || n < 1 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "limit must be a positive integer"})
+			return
+		}
+		limit = n
+	}
+
+	entries, err := h.adminSvc.AuditLog(c.Request.Context(), limit)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"entries": entries})
+}
+
+// parseUserIDParam reads the :id route parameter as a user ID and names
+// it as the audit target. It writes the 400 itself when the ID is bad.
+func parseUserIDParam(c *gin.Context) (uuid.UUID, bool) {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid user id"})
+		return uuid.Nil, false
+	}
+	c.Set(ContextAuditTarget, "user:"+userID.String())
+	return userID, true
+}
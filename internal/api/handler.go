@@ -1,47 +1,58 @@
 package api
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/Im-Manav/ome/internal/api/ws"
+	"github.com/Im-Manav/ome/internal/metrics"
 	"github.com/Im-Manav/ome/internal/ports"
 	"github.com/Im-Manav/ome/internal/service"
 	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/logger"
 	"github.com/Im-Manav/ome/pkg/models"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
-// OrderService is the interface the handler needs.
-// Defined here so we can mock it in tests without
-// importing the full service package.
-type OrderService interface {
-	PlaceOrder(ctx interface{}, req models.PlaceOrderRequest, userID uuid.UUID) (*models.PlaceOrderResponse, error)
-	CancelOrder(ctx interface{}, orderID uuid.UUID, userID uuid.UUID) error
-	GetOrderBook(ctx interface{}, symbol string) (*models.OrderBookSnapshot, error)
-	GetUserOrders(ctx interface{}, userID uuid.UUID) ([]*models.Order, error)
-	GetRecentTrades(ctx interface{}, symbol string, limit int) ([]models.Trade, error)
+// Services are the handler's service dependencies.
+type Services struct {
+	Orders      *service.OrderService
+	Auth        *service.AuthService
+	Candles     *service.CandleService
+	APIKeys     *service.APIKeyService
+	Instruments *service.InstrumentService
+	Admin       *service.AdminService
 }
 
 // Handler holds all dependencies for HTTP handlers.
 type Handler struct {
-	orderSvc *service.OrderService
-	authSvc  *service.AuthService
-	hub      *ws.Hub
-	cache    ports.Cache
-}
-
-func NewHandler(
-	orderSvc *service.OrderService,
-	authSvc *service.AuthService,
-	hub *ws.Hub,
-	cache ports.Cache,
-) *Handler {
+	orderSvc      *service.OrderService
+	authSvc       *service.AuthService
+	candleSvc     *service.CandleService
+	keySvc        *service.APIKeyService
+	instrumentSvc *service.InstrumentService
+	adminSvc      *service.AdminService
+	hub           *ws.Hub
+	cache         ports.Cache
+}
+
+func NewHandler(svc Services, hub *ws.Hub, cache ports.Cache) *Handler {
 	return &Handler{
-		orderSvc: orderSvc,
-		authSvc:  authSvc,
-		hub:      hub,
-		cache:    cache,
+		orderSvc:      svc.Orders,
+		authSvc:       svc.Auth,
+		candleSvc:     svc.Candles,
+		keySvc:        svc.APIKeys,
+		instrumentSvc: svc.Instruments,
+		adminSvc:      svc.Admin,
+		hub:           hub,
+		cache:         cache,
 	}
 }
 
@@ -50,6 +61,9 @@ func (h *Handler) RegisterRoutes(r *gin.Engine) {
 	// Health check — no auth required
 	r.GET("/health", h.Health)
 
+	// Prometheus scrape endpoint — no auth, keep it off the public ingress
+	r.GET("/metrics", gin.WrapH(metrics.Handler()))
+
 	// WebSocket — no auth (token passed as query param from browser)
 	r.GET("/ws", h.WebSocket)
 
@@ -58,25 +72,83 @@ func (h *Handler) RegisterRoutes(r *gin.Engine) {
 	{
 		auth.POST("/register", h.Register)
 		auth.POST("/login", h.Login)
+		auth.POST("/refresh", h.Refresh)
 		auth.POST("/logout", h.Logout)
+
+		auth.GET("/sessions", Auth(h.authSvc), h.ListSessions)
+		auth.DELETE("/sessions/:id", Auth(h.authSvc), h.RevokeSession)
 	}
 
-	// Protected routes — JWT required
+	// Public market data — no JWT, rate limited per IP, and cacheable
+	// for as long as each kind of data stays meaningfully fresh.
+	public := r.Group("/api/v1/public")
+	public.Use(PublicRateLimit(h.cache))
+	{
+		public.GET("/orderbook/:symbol", CacheFor(time.Second), h.GetOrderBook)
+		public.GET("/trades/:symbol", CacheFor(time.Second), h.GetRecentTrades)
+		public.GET("/candles/:symbol", CacheFor(10*time.Second), h.GetCandles)
+		public.GET("/ticker/:symbol", CacheFor(2*time.Second), h.GetTicker)
+		public.GET("/tickers", CacheFor(2*time.Second), h.GetTickers)
+		public.GET("/instruments", CacheFor(30*time.Second), h.GetInstruments)
+	}
+
+	// Protected routes — JWT or a signed API key request. Keys only
+	// reach the routes their scopes cover.
+	read, trade := RequireScope(models.ScopeRead), RequireScope(models.ScopeTrade)
 	api := r.Group("/api/v1")
-	api.Use(Auth(h.authSvc))
+	api.Use(Authenticate(h.authSvc, h.keySvc))
 	api.Use(RateLimit(h.cache))
 	{
 		// Orders
 		orders := api.Group("/orders")
 		{
-			orders.POST("", h.PlaceOrder)
-			orders.GET("", h.GetUserOrders)
-			orders.DELETE("/:id", h.CancelOrder)
+			idempotent := Idempotency(h.cache)
+			orders.POST("", trade, idempotent, h.PlaceOrder)
+			orders.POST("/batch", trade, idempotent, h.PlaceOrderBatch)
+			orders.DELETE("/batch", trade, h.CancelOrderBatch)
+			orders.GET("", read, h.GetUserOrders)
+			orders.GET("/:id", read, h.GetOrder)
+			orders.DELETE("/:id", trade, h.CancelOrder)
+			orders.GET("/client/:client_order_id", read, h.GetOrderByClientOrderID)
+			orders.DELETE("/client/:client_order_id", trade, h.CancelOrderByClientOrderID)
+		}
+
+		// Market data — the same handlers as /public, kept here for
+		// existing authenticated clients
+		api.GET("/orderbook/:symbol", read, h.GetOrderBook)
+		api.GET("/trades/:symbol", read, h.GetRecentTrades)
+		api.GET("/candles/:symbol", read, h.GetCandles)
+		api.GET("/ticker/:symbol", read, h.GetTicker)
+		api.GET("/tickers", read, h.GetTickers)
+
+		// The caller's own history
+		api.GET("/me/trades", read, h.GetMyTrades)
+
+		// API keys — managed from a login session only
+		keys := api.Group("/apikeys", RequireSession())
+		{
+			keys.POST("", h.CreateAPIKey)
+			keys.GET("", h.ListAPIKeys)
+			keys.DELETE("/:id", h.RevokeAPIKey)
 		}
 
-		// Order book + trades — read-only, still auth-protected
-		api.GET("/orderbook/:symbol", h.GetOrderBook)
-		api.GET("/trades/:symbol", h.GetRecentTrades)
+		// Admin — operators handle support and market operations,
+		// admins also manage accounts. Every request is audited,
+		// including the ones the role guard refuses.
+		admin := api.Group("/admin", Audit(h.adminSvc), RequireRole(models.RoleOperator, models.RoleAdmin))
+		{
+			admin.GET("/users", h.AdminSearchUsers)
+			admin.GET("/users/:id/orders", h.AdminUserOrders)
+			admin.DELETE("/orders/:id", h.AdminCancelOrder)
+			admin.GET("/instruments", h.GetInstruments)
+			admin.PUT("/instruments/:symbol", h.AdminUpsertInstrument)
+
+			adminOnly := admin.Group("", RequireRole(models.RoleAdmin))
+			adminOnly.PUT("/users/:id/role", h.AdminSetRole)
+			adminOnly.POST("/users/:id/freeze", h.AdminFreezeUser)
+			adminOnly.POST("/users/:id/unfreeze", h.AdminUnfreezeUser)
+			adminOnly.GET("/audit", h.AdminAuditLog)
+		}
 	}
 }
 
@@ -105,7 +177,7 @@ func (h *Handler) Register(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authSvc.Register(c.Request.Context(), req)
+	resp, err := h.authSvc.Register(c.Request.Context(), req, clientInfo(c))
 	if err != nil {
 		appErr := apperrors.ToHTTP(err)
 		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
@@ -122,7 +194,13 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.authSvc.Login(c.Request.Context(), req)
+	resp, err := h.authSvc.Login(c.Request.Context(), req, clientInfo(c))
+	if errors.Is(err, apperrors.ErrAccountFrozen) {
+		// Only reachable with the right password, so it leaks nothing
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
 	if err != nil {
 		// Always 401 for auth failures — never reveal which field was wrong
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid credentials"})
@@ -148,8 +226,121 @@ func (h *Handler) Logout(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"message": "logged out successfully"})
 }
 
+// Refresh exchanges a refresh token for a new token pair. The old
+// refresh token is spent whether or not the client receives the reply.
+func (h *Handler) Refresh(c *gin.Context) {
+	var req models.RefreshRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.authSvc.Refresh(c.Request.Context(), req.RefreshToken, clientInfo(c))
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		msg := appErr.Message
+		if appErr.Code < http.StatusInternalServerError {
+			msg = err.Error() // a client needs to know its session is gone
+		}
+		c.JSON(appErr.Code, gin.H{"error": msg})
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// ListSessions lists the caller's active sessions; "current" marks the
+// one this request was made from.
+func (h *Handler) ListSessions(c *gin.Context) {
+	sessions, err := h.authSvc.ListSessions(c.Request.Context(), mustGetUserID(c), c.GetString(ContextSessionID))
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"sessions": sessions})
+}
+
+// RevokeSession ends one session, or every session with
+// DELETE /auth/sessions/all. Access tokens of a revoked session stop
+// working on their next request.
+func (h *Handler) RevokeSession(c *gin.Context) {
+	userID := mustGetUserID(c)
+
+	var err error
+	if c.Param("id") == "all" {
+		err = h.authSvc.RevokeAllSessions(c.Request.Context(), userID)
+	} else {
+		sessionID, parseErr := uuid.Parse(c.Param("id"))
+		if parseErr != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid session id"})
+			return
+		}
+		err = h.authSvc.RevokeSession(c.Request.Context(), userID, sessionID)
+	}
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// ─── API key handlers ─────────────────────────────────────────────────────────
+
+// CreateAPIKey issues a key. The response holds the secret — the only
+// time it is ever shown.
+func (h *Handler) CreateAPIKey(c *gin.Context) {
+	var req models.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	resp, err := h.keySvc.Create(c.Request.Context(), mustGetUserID(c), req)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusCreated, resp)
+}
+
+func (h *Handler) ListAPIKeys(c *gin.Context) {
+	keys, err := h.keySvc.List(c.Request.Context(), mustGetUserID(c))
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"api_keys": keys})
+}
+
+func (h *Handler) RevokeAPIKey(c *gin.Context) {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid api key id"})
+		return
+	}
+
+	if err := h.keySvc.Revoke(c.Request.Context(), mustGetUserID(c), id); err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // ─── Order handlers ───────────────────────────────────────────────────────────
 
+// PlaceOrder accepts an order. With ?wait=true it answers with the
+// engine's result — final status and fills — or 202 with "pending":true
+// if the engine doesn't answer in time.
 func (h *Handler) PlaceOrder(c *gin.Context) {
 	var req models.PlaceOrderRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -157,16 +348,36 @@ func (h *Handler) PlaceOrder(c *gin.Context) {
 		return
 	}
 
+	wait := false
+	if raw := c.Query("wait"); raw != "" {
+		var err error
+		if wait, err = strconv.ParseBool(raw); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "wait must be true or false"})
+			return
+		}
+	}
+
 	userID := mustGetUserID(c)
 
-	resp, err := h.orderSvc.PlaceOrder(c.Request.Context(), req, userID)
+	place := h.orderSvc.PlaceOrder
+	if wait {
+		place = h.orderSvc.PlaceOrderAndWait
+	}
+	resp, err := place(c.Request.Context(), req, userID)
 	if err != nil {
 		appErr := apperrors.ToHTTP(err)
 		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
 		return
 	}
 
-	c.JSON(http.StatusCreated, resp)
+	switch {
+	case resp.Duplicate:
+		c.JSON(http.StatusOK, resp)
+	case resp.Pending:
+		c.JSON(http.StatusAccepted, resp)
+	default:
+		c.JSON(http.StatusCreated, resp)
+	}
 }
 
 func (h *Handler) CancelOrder(c *gin.Context) {
@@ -190,17 +401,120 @@ func (h *Handler) CancelOrder(c *gin.Context) {
 	})
 }
 
+func (h *Handler) CancelOrderByClientOrderID(c *gin.Context) {
+	userID := mustGetUserID(c)
+
+	order, err := h.orderSvc.CancelOrderByClientOrderID(c.Request.Context(), c.Param("client_order_id"), userID)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.CancelOrderResponse{
+		OrderID: order.ID.String(),
+		Status:  models.StatusCancelled.String(),
+	})
+}
+
+// PlaceOrderBatch places up to MaxBatchSize orders in one request. The
+// batch costs one rate-limit unit per order; RateLimit already charged
+// the first.
+func (h *Handler) PlaceOrderBatch(c *gin.Context) {
+	var req models.PlaceOrderBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !chargeRateLimit(c, h.cache, int64(len(req.Orders)-1)) {
+		return
+	}
+
+	userID := mustGetUserID(c)
+
+	results, err := h.orderSvc.PlaceOrderBatch(c.Request.Context(), req.Orders, userID)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.NewBatchResponse(results))
+}
+
+// CancelOrderBatch cancels up to MaxBatchSize orders, weighted like
+// PlaceOrderBatch.
+func (h *Handler) CancelOrderBatch(c *gin.Context) {
+	var req models.CancelOrderBatchRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !chargeRateLimit(c, h.cache, int64(len(req.OrderIDs)-1)) {
+		return
+	}
+
+	userID := mustGetUserID(c)
+
+	results := h.orderSvc.CancelOrderBatch(c.Request.Context(), req.OrderIDs, userID)
+	c.JSON(http.StatusOK, models.NewBatchResponse(results))
+}
+
+// GetUserOrders lists the caller's orders, newest first.
+//
+//	GET /api/v1/orders?status=open,partial&symbol=BTC-USD&side=buy
+//	                  &from=<RFC3339>&to=<RFC3339>&limit=50&cursor=<next_cursor>
 func (h *Handler) GetUserOrders(c *gin.Context) {
 	userID := mustGetUserID(c)
 
-	orders, err := h.orderSvc.GetUserOrders(c.Request.Context(), userID)
+	filter, err := parseOrderFilter(c)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	page, err := h.orderSvc.GetUserOrders(c.Request.Context(), userID, filter)
 	if err != nil {
 		appErr := apperrors.ToHTTP(err)
 		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
 		return
 	}
 
-	c.JSON(http.StatusOK, gin.H{"orders": orders})
+	c.JSON(http.StatusOK, page)
+}
+
+// GetOrder returns one of the caller's orders with its fills and timeline.
+func (h *Handler) GetOrder(c *gin.Context) {
+	orderID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid order id"})
+		return
+	}
+
+	userID := mustGetUserID(c)
+
+	detail, err := h.orderSvc.GetOrderDetail(c.Request.Context(), orderID, userID)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
+}
+
+func (h *Handler) GetOrderByClientOrderID(c *gin.Context) {
+	userID := mustGetUserID(c)
+
+	detail, err := h.orderSvc.GetOrderDetailByClientOrderID(c.Request.Context(), c.Param("client_order_id"), userID)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, detail)
 }
 
 func (h *Handler) GetOrderBook(c *gin.Context) {
@@ -237,6 +551,188 @@ func (h *Handler) GetRecentTrades(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"trades": trades})
 }
 
+// GetCandles returns historical candles, oldest first.
+//
+//	GET /api/v1/candles/BTC-USD?interval=5m&from=<RFC3339>&to=<RFC3339>&limit=300&fill=true
+func (h *Handler) GetCandles(c *gin.Context) {
+	q := service.CandleQuery{
+		Symbol:   c.Param("symbol"),
+		Interval: c.DefaultQuery("interval", "1m"),
+		Fill:     c.Query("fill") == "true",
+	}
+
+	// Candles have no cursor — page backwards by moving "to" instead.
+	pq, err := parsePageQuery(c)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+	q.From, q.To, q.Limit = pq.From, pq.To, pq.Limit
+
+	series, err := h.candleSvc.GetCandles(c.Request.Context(), q)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, series)
+}
+
+// GetTicker returns a symbol's rolling 24h stats with its best bid and ask.
+func (h *Handler) GetTicker(c *gin.Context) {
+	symbol := c.Param("symbol")
+	if symbol == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "symbol required"})
+		return
+	}
+
+	ticker, err := h.orderSvc.GetTicker(c.Request.Context(), symbol)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, ticker)
+}
+
+func (h *Handler) GetTickers(c *gin.Context) {
+	tickers, err := h.orderSvc.GetTickers(c.Request.Context())
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"tickers": tickers})
+}
+
+func (h *Handler) GetInstruments(c *gin.Context) {
+	instruments, err := h.instrumentSvc.List(c.Request.Context())
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"instruments": instruments})
+}
+
+// GetMyTrades lists the caller's trades, newest first. With format=csv
+// it streams every matching trade as a statement instead of one page.
+//
+//	GET /api/v1/me/trades?symbol=BTC-USD&from=<RFC3339>&to=<RFC3339>&limit=50&cursor=<next_cursor>
+//	GET /api/v1/me/trades?from=2025-01-01T00:00:00Z&to=2025-02-01T00:00:00Z&format=csv
+func (h *Handler) GetMyTrades(c *gin.Context) {
+	userID := mustGetUserID(c)
+
+	pq, err := parsePageQuery(c)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+	filter := models.TradeFilter{PageQuery: pq, Symbol: c.Query("symbol")}
+
+	switch c.DefaultQuery("format", "json") {
+	case "json":
+	case "csv":
+		h.streamTradesCSV(c, userID, filter)
+		return
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "format must be json or csv"})
+		return
+	}
+
+	page, err := h.orderSvc.GetUserTrades(c.Request.Context(), userID, filter)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, page)
+}
+
+// streamTradesCSV writes the statement page by page, flushing as it
+// goes, so a year of trades never sits in memory at once.
+func (h *Handler) streamTradesCSV(c *gin.Context, userID uuid.UUID, filter models.TradeFilter) {
+	filter.Limit = models.MaxPageLimit
+
+	// Fetch the first page before writing anything, so a failure can
+	// still be reported with a proper status code.
+	page, err := h.orderSvc.GetUserTrades(c.Request.Context(), userID, filter)
+	if err != nil {
+		appErr := apperrors.ToHTTP(err)
+		c.JSON(appErr.Code, gin.H{"error": appErr.Message})
+		return
+	}
+
+	filename := fmt.Sprintf("trades-%s.csv", time.Now().UTC().Format("20060102"))
+	c.Header("Content-Type", "text/csv; charset=utf-8")
+	c.Header("Content-Disposition", `attachment; filename="`+filename+`"`)
+	c.Status(http.StatusOK)
+
+	w := csv.NewWriter(c.Writer)
+	_ = w.Write([]string{
+		"executed_at", "trade_id", "order_id", "symbol", "role", "liquidity",
+		"price", "quantity", "notional", "fee",
+	})
+	for {
+		for _, t := range page.Trades {
+			_ = w.Write([]string{
+				t.ExecutedAt.UTC().Format(time.RFC3339Nano),
+				t.ID.String(),
+				t.OrderID.String(),
+				csvSafe(t.Symbol),
+				t.Role,
+				t.Liquidity,
+				formatFloat(t.Price),
+				formatFloat(t.Quantity),
+				formatFloat(t.Price * t.Quantity),
+				formatFee(t.Fee),
+			})
+		}
+		w.Flush()
+		c.Writer.Flush()
+		if w.Error() != nil || page.NextCursor == "" {
+			return
+		}
+
+		cursor, _ := models.DecodeCursor(page.NextCursor)
+		filter.After = &cursor
+		if page, err = h.orderSvc.GetUserTrades(c.Request.Context(), userID, filter); err != nil {
+			// Headers are gone; all we can do is stop and leave a
+			// truncated file the client will notice.
+			logger.Error("trade statement aborted", logger.Err(err), zap.String("user_id", userID.String()))
+			return
+		}
+	}
+}
+
+// csvSafe stops a client-chosen value from being read as a formula
+// when the statement is opened in a spreadsheet.
+func csvSafe(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// formatFee leaves an unknown fee blank.
+func formatFee(fee *float64) string {
+	if fee == nil {
+		return ""
+	}
+	return formatFloat(*fee)
+}
+
 // ─── Helpers ──────────────────────────────────────────────────────────────────
 
 // mustGetUserID extracts the userID injected by the Auth middleware.
@@ -246,6 +742,78 @@ func mustGetUserID(c *gin.Context) uuid.UUID {
 	return val.(uuid.UUID)
 }
 
+// parseOrderFilter reads the order history query parameters.
+func parseOrderFilter(c *gin.Context) (models.OrderFilter, error) {
+	var f models.OrderFilter
+
+	if v := c.Query("status"); v != "" {
+		for _, s := range strings.Split(v, ",") {
+			status, ok := models.ParseOrderStatus(strings.TrimSpace(s))
+			if !ok {
+				return f, fmt.Errorf("%w: unknown status %q", apperrors.ErrInvalidQuery, s)
+			}
+			f.Statuses = append(f.Statuses, status)
+		}
+	}
+	if v := c.Query("side"); v != "" {
+		side, ok := models.ParseSide(v)
+		if !ok {
+			return f, fmt.Errorf("%w: unknown side %q", apperrors.ErrInvalidQuery, v)
+		}
+		f.Side = &side
+	}
+	f.Symbol = c.Query("symbol")
+
+	var err error
+	f.PageQuery, err = parsePageQuery(c)
+	return f, err
+}
+
+// parsePageQuery reads the from, to, limit and cursor parameters shared
+// by the paginated listings.
+func parsePageQuery(c *gin.Context) (models.PageQuery, error) {
+	var (
+		p   models.PageQuery
+		err error
+	)
+	if p.From, err = parseTimeParam(c, "from"); err != nil {
+		return p, err
+	}
+	if p.To, err = parseTimeParam(c, "to"); err != nil {
+		return p, err
+	}
+	if v := c.Query("limit"); v != "" {
+		if p.Limit, err = strconv.Atoi(v); err != nil {
+			return p, fmt.Errorf("%w: limit must be a number", apperrors.ErrInvalidQuery)
+		}
+	}
+	if v := c.Query("cursor"); v != "" {
+		cursor, err := models.DecodeCursor(v)
+		if err != nil {
+			return p, apperrors.ErrInvalidCursor
+		}
+		p.After = &cursor
+	}
+	return p, nil
+}
+
+// parseTimeParam reads an optional RFC 3339 timestamp.
+func parseTimeParam(c *gin.Context, name string) (time.Time, error) {
+	v := c.Query(name)
+	if v == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(time.RFC3339, v)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%w: %s must be an RFC 3339 timestamp", apperrors.ErrInvalidQuery, name)
+	}
+	return t, nil
+}
+
+func clientInfo(c *gin.Context) service.Client {
+	return service.Client{UserAgent: c.Request.UserAgent(), IP: c.ClientIP()}
+}
+
 func extractToken(c *gin.Context) string {
 	header := c.GetHeader("Authorization")
 	if len(header) > 7 && header[:7] == "Bearer " {
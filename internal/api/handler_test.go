@@ -0,0 +1,59 @@
+package api
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func queryContext(query string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest("GET", "/api/v1/orders?"+query, nil)
+	return c
+}
+
+func TestParseOrderFilter(t *testing.T) {
+	cursor := models.Cursor{Time: time.Date(2025, 1, 2, 10, 0, 0, 123000, time.UTC), ID: uuid.New()}
+
+	f, err := parseOrderFilter(queryContext(
+		"status=open,PARTIAL&side=sell&symbol=BTC-USD&from=2025-01-01T00:00:00Z&limit=10&cursor=" + cursor.Encode(),
+	))
+	if err != nil {
+		t.Fatalf("parseOrderFilter: %v", err)
+	}
+	if len(f.Statuses) != 2 || f.Statuses[1] != models.StatusPartial {
+		t.Errorf("unexpected statuses %v", f.Statuses)
+	}
+	if f.Side == nil || *f.Side != models.Sell || f.Symbol != "BTC-USD" || f.Limit != 10 {
+		t.Errorf("unexpected filter %+v", f)
+	}
+	if f.From.IsZero() || !f.To.IsZero() {
+		t.Errorf("expected only from to be set, got %v / %v", f.From, f.To)
+	}
+	if f.After == nil || !f.After.Time.Equal(cursor.Time) || f.After.ID != cursor.ID {
+		t.Errorf("cursor did not round-trip: %+v", f.After)
+	}
+
+	for _, bad := range []string{"status=pending", "side=long", "from=yesterday", "limit=ten"} {
+		if _, err := parseOrderFilter(queryContext(bad)); !errors.Is(err, apperrors.ErrInvalidQuery) {
+			t.Errorf("%s: expected ErrInvalidQuery, got %v", bad, err)
+		}
+	}
+	if _, err := parseOrderFilter(queryContext("cursor=not-a-cursor")); !errors.Is(err, apperrors.ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestCSVSafe(t *testing.T) {
+	for in, want := range map[string]string{"BTC-USD": "BTC-USD", "=HYPERLINK(1)": "'=HYPERLINK(1)", "-1": "'-1", "": ""} {
+		if got := csvSafe(in); got != want {
+			t.Errorf("csvSafe(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -1,23 +1,53 @@
 package api
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/Im-Manav/ome/internal/ports"
 	"github.com/Im-Manav/ome/internal/service"
 	"github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"go.uber.org/zap"
 )
 
 const (
 	// RateLimit — max orders per user per minute
-	RateLimitRequests = 60
-	ContextUserID     = "userID"
-	ContextUserEmail  = "userEmail"
-	ContextJTI        = "jti"
+	RateLimitRequests       = 60
+	PublicRateLimitRequests = 120 // per IP per minute, see PublicRateLimit
+	ContextUserID           = "userID"
+	ContextUserEmail        = "userEmail"
+	ContextJTI              = "jti"
+	ContextSessionID        = "sessionID"
+	ContextAPIKey           = "apiKey"
+	ContextRole             = "role"
+	ContextAuditTarget      = "auditTarget"
+
+	// Headers of a request signed with an API key — see service.SignRequest
+	HeaderAPIKey       = "X-OME-APIKEY"
+	HeaderAPITimestamp = "X-OME-TIMESTAMP"
+	HeaderAPISignature = "X-OME-SIGNATURE"
+
+	// HeaderIdempotencyKey makes a POST safe to retry — see Idempotency
+	HeaderIdempotencyKey      = "Idempotency-Key"
+	HeaderIdempotencyReplayed = "Idempotent-Replayed"
+	maxIdempotencyKeyLen      = 255
+	idempotencyKeyTTL         = 24 * time.Hour
+	idempotencyReserveTTL     = time.Minute // outlives any single request
+
+	maxSignedBodyBytes = 1 << 20
+	maxAuditBodyBytes  = 4 << 10
 )
 
 // Auth is the JWT authentication middleware.
@@ -60,6 +90,18 @@ func Auth(authSvc *service.AuthService) gin.HandlerFunc {
 			return
 		}
 
+		// And the session it belongs to — tokens issued before sessions
+		// existed have none and simply run out
+		if claims.SessionID != "" {
+			revoked, err := authSvc.IsSessionRevoked(c.Request.Context(), claims.SessionID)
+			if err != nil || revoked {
+				c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
+					"error": "session has been revoked",
+				})
+				return
+			}
+		}
+
 		userID, err := uuid.Parse(claims.UserID)
 		if err != nil {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
@@ -67,37 +109,364 @@ func Auth(authSvc *service.AuthService) gin.HandlerFunc {
 			})
 			return
 		}
+		if rejectFrozen(c, authSvc, userID) {
+			return
+		}
 
 		c.Set(ContextUserID, userID)
 		c.Set(ContextUserEmail, claims.Email)
+		c.Set(ContextRole, claims.Role)
 		c.Set(ContextJTI, claims.JTI)
+		c.Set(ContextSessionID, claims.SessionID)
+		c.Next()
+	}
+}
+
+// Authenticate accepts either a JWT (see Auth) or a request signed with
+// an API key, and injects the userID the same way for both. API key
+// requests also carry the key, for RequireScope.
+func Authenticate(authSvc *service.AuthService, keySvc *service.APIKeyService) gin.HandlerFunc {
+	jwtAuth := Auth(authSvc)
+	return func(c *gin.Context) {
+		if c.GetHeader(HeaderAPIKey) == "" {
+			jwtAuth(c)
+			return
+		}
+
+		// The body is part of the signature; read it and put it back
+		// for the handler.
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSignedBodyBytes))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": "request body too large",
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		key, err := keySvc.Authenticate(c.Request.Context(), service.SignedRequest{
+			Key:       c.GetHeader(HeaderAPIKey),
+			Timestamp: c.GetHeader(HeaderAPITimestamp),
+			Signature: c.GetHeader(HeaderAPISignature),
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.RequestURI(),
+			Body:      body,
+			ClientIP:  c.ClientIP(),
+		})
+		if err != nil {
+			appErr := errors.ToHTTP(err)
+			msg := appErr.Message
+			if appErr.Code < http.StatusInternalServerError {
+				msg = err.Error() // tell bot authors which check failed
+			}
+			c.AbortWithStatusJSON(appErr.Code, gin.H{"error": msg})
+			return
+		}
+		if rejectFrozen(c, authSvc, key.UserID) {
+			return
+		}
+
+		c.Set(ContextUserID, key.UserID)
+		c.Set(ContextAPIKey, key)
+		c.Next()
+	}
+}
+
+// rejectFrozen aborts with 403 if the user's account is frozen. Like the
+// blocklist check, it fails closed when the cache can't answer.
+func rejectFrozen(c *gin.Context, authSvc *service.AuthService, userID uuid.UUID) bool {
+	frozen, err := authSvc.IsFrozen(c.Request.Context(), userID)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{
+			"error": "could not check account status",
+		})
+		return true
+	}
+	if frozen {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+			"error": "account frozen",
+		})
+		return true
+	}
+	return false
+}
+
+// RequireRole lets through only JWT sessions whose role is one of roles.
+// API key requests carry no role and are always rejected.
+func RequireRole(roles ...models.Role) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role, _ := c.Get(ContextRole)
+		if r, ok := role.(models.Role); !ok || !slices.Contains(roles, r) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "insufficient role",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// Audit records every request that passes through it once the handler
+// has finished, whether it succeeded or was refused. Handlers name what
+// they acted on ("user:<id>") by setting ContextAuditTarget; otherwise
+// the route's :symbol or :id parameter is used.
+func Audit(adminSvc *service.AdminService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// The log keeps the start of the body as the handler reads it;
+		// the handler still gets all of it.
+		detail := &headBuffer{max: maxAuditBodyBytes}
+		if c.Request.Body != nil && c.Request.Method != http.MethodGet {
+			c.Request.Body = struct {
+				io.Reader
+				io.Closer
+			}{io.TeeReader(c.Request.Body, detail), c.Request.Body}
+		}
+
+		c.Next()
+
+		entry := &models.AuditLog{
+			Action: c.Request.Method + " " + c.FullPath(),
+			Target: auditTarget(c),
+			Status: c.Writer.Status(),
+			Detail: detail.String(),
+			IP:     c.ClientIP(),
+		}
+		if id, ok := c.Get(ContextUserID); ok {
+			entry.ActorID = id.(uuid.UUID)
+		}
+		entry.ActorEmail = c.GetString(ContextUserEmail)
+
+		if err := adminSvc.Record(c.Request.Context(), entry); err != nil {
+			logger.Error("audit log write failed", logger.Err(err), zap.String("action", entry.Action))
+		}
+		logger.Info("admin action",
+			zap.String("actor", entry.ActorEmail),
+			zap.String("action", entry.Action),
+			zap.String("target", entry.Target),
+			zap.Int("status", entry.Status),
+		)
+	}
+}
+
+func auditTarget(c *gin.Context) string {
+	if target := c.GetString(ContextAuditTarget); target != "" {
+		return target
+	}
+	if symbol := c.Param("symbol"); symbol != "" {
+		return "instrument:" + symbol
+	}
+	return c.Param("id")
+}
+
+// headBuffer keeps the first max bytes written to it and drops the rest.
+type headBuffer struct {
+	bytes.Buffer
+	max int
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); room > 0 {
+		b.Buffer.Write(p[:min(len(p), room)])
+	}
+	return len(p), nil
+}
+
+// RequireScope rejects API key requests whose key lacks scope.
+// JWT sessions have every scope.
+func RequireScope(scope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if key, ok := c.Get(ContextAPIKey); ok && !key.(*models.APIKey).HasScope(scope) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "api key lacks the " + scope + " scope",
+			})
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSession rejects API key requests outright, for routes only a
+// logged-in user should reach — a leaked key mustn't mint more keys.
+func RequireSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get(ContextAPIKey); ok {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+				"error": "this route needs a login session, not an api key",
+			})
+			return
+		}
 		c.Next()
 	}
 }
 
 // RateLimit enforces a per-user request rate limit using Redis.
 // Uses a fixed window counter — simple and effective for order APIs.
+// Every request counts once here; batch handlers charge the rest of
+// their weight with chargeRateLimit once they know the batch size.
 func RateLimit(cache ports.Cache) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		userID, exists := c.Get(ContextUserID)
-		if !exists {
+		if !chargeRateLimit(c, cache, 1) {
+			return
+		}
+		c.Next()
+	}
+}
+
+// chargeRateLimit counts weight requests against the caller's window.
+// It aborts with 429 and returns false once the window is exhausted.
+func chargeRateLimit(c *gin.Context, cache ports.Cache, weight int64) bool {
+	userID, exists := c.Get(ContextUserID)
+	if !exists || weight <= 0 {
+		return true
+	}
+
+	count, err := cache.IncrByWithExpiry(
+		c.Request.Context(),
+		userID.(uuid.UUID).String(),
+		weight,
+		60*time.Second, // 60 second window
+	)
+	if err != nil {
+		// If Redis is down, fail open — don't block legitimate orders
+		return true
+	}
+
+	if count > RateLimitRequests {
+		appErr := errors.ToHTTP(errors.ErrRateLimitExceeded)
+		c.AbortWithStatusJSON(appErr.Code, gin.H{
+			"error": appErr.Message,
+		})
+		return false
+	}
+	return true
+}
+
+// Idempotency replays the stored response when a request is retried with
+// the same Idempotency-Key header, instead of running it again. Keys are
+// per user and kept for a day. Reusing a key for a different request is
+// refused, as is a retry while the first request is still running.
+// Server errors and 429s aren't stored, so those can be retried for real.
+func Idempotency(cache ports.Cache) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(HeaderIdempotencyKey)
+		if key == "" {
+			c.Next()
+			return
+		}
+		if len(key) > maxIdempotencyKeyLen {
+			c.AbortWithStatusJSON(http.StatusBadRequest, gin.H{
+				"error": fmt.Sprintf("%s must be at most %d characters", HeaderIdempotencyKey, maxIdempotencyKeyLen),
+			})
+			return
+		}
+
+		// The body is part of the request hash, so it is read in full;
+		// one too large to hash is refused rather than cut short.
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxSignedBodyBytes))
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusRequestEntityTooLarge, gin.H{
+				"error": "request body too large",
+			})
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		key = mustGetUserID(c).String() + ":" + key
+		hash := requestHash(c.Request.Method, c.Request.URL.RequestURI(), body)
+
+		reserved, err := cache.ReserveIdempotencyKey(ctx, key, idempotencyReserveTTL)
+		if err != nil {
+			// Cache down — run the request rather than refuse it
+			logger.Error("idempotency key reserve failed", logger.Err(err))
 			c.Next()
 			return
 		}
+		if !reserved {
+			replayIdempotent(c, cache, key, hash)
+			return
+		}
+
+		rec := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = rec
+		c.Next()
+
+		status := c.Writer.Status()
+		if status >= http.StatusInternalServerError || status == http.StatusTooManyRequests {
+			if err := cache.ReleaseIdempotencyKey(ctx, key); err != nil {
+				logger.Error("idempotency key release failed", logger.Err(err))
+			}
+			return
+		}
+		resp := models.IdempotentResponse{RequestHash: hash, Status: status, Body: rec.body.Bytes()}
+		if err := cache.SetIdempotentResponse(ctx, key, resp, idempotencyKeyTTL); err != nil {
+			logger.Error("idempotent response store failed", logger.Err(err))
+		}
+	}
+}
+
+// replayIdempotent answers a request whose key was already taken.
+func replayIdempotent(c *gin.Context, cache ports.Cache, key, hash string) {
+	stored, err := cache.GetIdempotentResponse(c.Request.Context(), key)
+	switch {
+	case err != nil:
+		appErr := errors.ToHTTP(err)
+		c.AbortWithStatusJSON(appErr.Code, gin.H{"error": appErr.Message})
+	case stored == nil:
+		appErr := errors.ToHTTP(errors.ErrIdempotencyConflict)
+		c.AbortWithStatusJSON(appErr.Code, gin.H{"error": appErr.Message})
+	case stored.RequestHash != hash:
+		appErr := errors.ToHTTP(errors.ErrIdempotencyMismatch)
+		c.AbortWithStatusJSON(appErr.Code, gin.H{"error": appErr.Message})
+	default:
+		c.Header(HeaderIdempotencyReplayed, "true")
+		c.Data(stored.Status, "application/json; charset=utf-8", stored.Body)
+		c.Abort()
+	}
+}
+
+func requestHash(method, uri string, body []byte) string {
+	h := sha256.New()
+	h.Write([]byte(method + "\n" + uri + "\n"))
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// responseRecorder keeps a copy of the response body as it is written.
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(b []byte) (int, error) {
+	w.body.Write(b)
+	return w.ResponseWriter.Write(b)
+}
 
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+// PublicRateLimit is RateLimit for unauthenticated routes, keyed by
+// client IP. Behind a load balancer, set the engine's trusted proxies so
+// ClientIP reads X-Forwarded-For rather than the balancer's address.
+func PublicRateLimit(cache ports.Cache) gin.HandlerFunc {
+	return func(c *gin.Context) {
 		count, err := cache.IncrWithExpiry(
 			c.Request.Context(),
-			userID.(uuid.UUID).String(),
-			60*time.Second, // 60 second window
+			"ip:"+c.ClientIP(),
+			60*time.Second,
 		)
 		if err != nil {
-			// If Redis is down, fail open — don't block legitimate orders
+			// Fail open, same as RateLimit
 			c.Next()
 			return
 		}
 
-		if count > RateLimitRequests {
+		if count > PublicRateLimitRequests {
 			appErr := errors.ToHTTP(errors.ErrRateLimitExceeded)
+			c.Header("Retry-After", "60")
 			c.AbortWithStatusJSON(appErr.Code, gin.H{
 				"error": appErr.Message,
 			})
@@ -106,3 +475,58 @@ func RateLimit(cache ports.Cache) gin.HandlerFunc {
 		c.Next()
 	}
 }
+
+// CacheFor lets browsers and CDNs reuse a public response for maxAge.
+// Only successful responses are marked cacheable.
+func CacheFor(maxAge time.Duration) gin.HandlerFunc {
+	value := fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds()))
+	return func(c *gin.Context) {
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, value: value}
+		c.Next()
+	}
+}
+
+// cacheControlWriter sets Cache-Control just before the header is
+// written, once the handler has chosen its status.
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	value string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	if code == http.StatusOK {
+		w.Header().Set("Cache-Control", w.value)
+	} else {
+		w.Header().Set("Cache-Control", "no-store")
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Tracing starts a server span per request; the span's context rides
+// on c.Request.Context() into the services and the Kafka headers.
+// Health checks, probes and metric scrapes are left out of traces.
+func Tracing(service string) gin.HandlerFunc {
+	return otelgin.Middleware(service, otelgin.WithFilter(func(r *http.Request) bool {
+		switch r.URL.Path {
+		case "/health", "/livez", "/readyz", "/metrics":
+			return false
+		}
+		return true
+	}))
+}
+
+// RequestLogger logs every request with method, path, status,
+// and latency using zap.
+func RequestLogger() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		logger.Info("request",
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("ip", c.ClientIP()),
+		)
+	}
+}
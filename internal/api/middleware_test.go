@@ -0,0 +1,157 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/cache"
+	"github.com/Im-Manav/ome/internal/service"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+func TestPublicRateLimitAndCacheHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	r := gin.New()
+	r.GET("/ok", PublicRateLimit(mem), CacheFor(2*time.Second), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{})
+	})
+	r.GET("/bad", CacheFor(2*time.Second), func(c *gin.Context) {
+		c.JSON(http.StatusBadRequest, gin.H{})
+	})
+
+	get := func(path, ip string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", path, nil)
+		req.RemoteAddr = ip + ":1234"
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	if w := get("/ok", "10.0.0.1"); w.Header().Get("Cache-Control") != "public, max-age=2" {
+		t.Errorf("expected a cacheable 200, got %q", w.Header().Get("Cache-Control"))
+	}
+	if w := get("/bad", "10.0.0.1"); w.Header().Get("Cache-Control") != "no-store" {
+		t.Errorf("expected errors not to be cached, got %q", w.Header().Get("Cache-Control"))
+	}
+
+	for i := 1; i < PublicRateLimitRequests; i++ {
+		get("/ok", "10.0.0.1")
+	}
+	if w := get("/ok", "10.0.0.1"); w.Code != http.StatusTooManyRequests {
+		t.Errorf("expected 429 past the limit, got %d", w.Code)
+	}
+	if w := get("/ok", "10.0.0.2"); w.Code != http.StatusOK {
+		t.Errorf("expected another IP to be unaffected, got %d", w.Code)
+	}
+}
+
+func TestRateLimitWeightedByBatchSize(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	userID := uuid.New()
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(ContextUserID, userID) }, RateLimit(mem))
+	r.POST("/batch", func(c *gin.Context) {
+		if !chargeRateLimit(c, mem, 39) { // a 40-item batch
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{})
+	})
+
+	post := func() int {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest("POST", "/batch", nil))
+		return w.Code
+	}
+	if code := post(); code != http.StatusOK {
+		t.Fatalf("first batch: got %d", code)
+	}
+	if code := post(); code != http.StatusTooManyRequests {
+		t.Errorf("second batch is over %d requests, got %d", RateLimitRequests, code)
+	}
+}
+
+func TestIdempotencyReplaysResponse(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	calls, userID := 0, uuid.New()
+	r := gin.New()
+	r.Use(func(c *gin.Context) { c.Set(ContextUserID, userID) })
+	r.POST("/orders", Idempotency(mem), func(c *gin.Context) {
+		calls++
+		c.JSON(http.StatusCreated, gin.H{"call": calls})
+	})
+
+	post := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("POST", "/orders", strings.NewReader(body))
+		req.Header.Set(HeaderIdempotencyKey, key)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	first := post("k1", `{"quantity":1}`)
+	retry := post("k1", `{"quantity":1}`)
+	if calls != 1 || retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("expected the retry to replay %d %s, got %d %s after %d calls",
+			first.Code, first.Body, retry.Code, retry.Body, calls)
+	}
+	if retry.Header().Get(HeaderIdempotencyReplayed) != "true" {
+		t.Error("expected the replay to be marked")
+	}
+	if w := post("k1", `{"quantity":2}`); w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("reusing the key for another body: got %d, want 422", w.Code)
+	}
+	if post("k2", `{"quantity":1}`); calls != 2 {
+		t.Errorf("expected a new key to run the handler, got %d calls", calls)
+	}
+	if w := post("k3", strings.Repeat("x", maxSignedBodyBytes+1)); w.Code != http.StatusRequestEntityTooLarge || calls != 2 {
+		t.Errorf("oversized body: got %d after %d calls, want 413 without running the handler", w.Code, calls)
+	}
+}
+
+// auditRecorder keeps audit entries in memory.
+type auditRecorder struct{ entries []models.AuditLog }
+
+func (r *auditRecorder) CreateAuditLog(entry *models.AuditLog) error {
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+func (r *auditRecorder) ListAuditLogs(int) ([]models.AuditLog, error) { return r.entries, nil }
+
+func TestAuditTruncatesOnlyTheLoggedBody(t *testing.T) {
+	logger.InitForTest()
+	gin.SetMode(gin.TestMode)
+	audit := &auditRecorder{}
+	body := strings.Repeat("x", 2*maxAuditBodyBytes)
+
+	var read int
+	r := gin.New()
+	r.POST("/admin", Audit(service.NewAdminService(nil, audit, nil)), func(c *gin.Context) {
+		b, _ := io.ReadAll(c.Request.Body)
+		read = len(b)
+		c.Status(http.StatusNoContent)
+	})
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/admin", strings.NewReader(body)))
+
+	if read != len(body) {
+		t.Errorf("expected the handler to read all %d bytes, got %d", len(body), read)
+	}
+	if len(audit.entries) != 1 || len(audit.entries[0].Detail) != maxAuditBodyBytes {
+		t.Errorf("expected one entry with the first %d bytes, got %+v", maxAuditBodyBytes, audit.entries)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/Im-Manav/ome/internal/metrics"
 	"github.com/Im-Manav/ome/pkg/logger"
 	"github.com/Im-Manav/ome/pkg/models"
 	"github.com/gorilla/websocket"
@@ -78,6 +79,8 @@ func (h *Hub) Run() {
 				select {
 				case client.send <- message:
 				default:
+					// Client can't keep up — disconnect it rather than block the hub
+					metrics.WebSocketDropped.WithLabelValues("slow_client").Inc()
 					close(client.send)
 					delete(h.clients, client)
 				}
@@ -115,12 +118,13 @@ func (h *Hub) broadcastJSON(v any) {
 	select {
 	case h.broadcast <- data:
 	default:
+		metrics.WebSocketDropped.WithLabelValues("broadcast_full").Inc()
 		logger.Warn("ws broadcast channel full - dropping message")
 	}
 }
 
 // ClientCount returns the number of connected clients.
-// Used by Prometheus metrics.
+// Exported as ome_websocket_clients via metrics.RegisterWebSocketClients.
 func (h *Hub) ClientCount() int {
 	h.mu.RLock()
 	defer h.mu.RUnlock()
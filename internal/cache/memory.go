@@ -0,0 +1,455 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/metrics"
+	"github.com/Im-Manav/ome/pkg/models"
+)
+
+// janitorInterval is how often expired keys are swept. Reads check
+// expiry themselves, so this only bounds memory held by dead keys.
+const janitorInterval = time.Minute
+
+// Memory is an in-process implementation of ports.Cache.
+//
+// It uses the same key and channel helpers as Client, so the two are
+// interchangeable. Semantics match Redis where the services depend on them:
+//   - keys expire after their TTL (checked on read, swept periodically)
+//   - IncrWithExpiry refreshes the expiry on every increment
+//   - every subscriber on a channel receives every publish
+//   - a subscriber whose buffer is full misses the message, same as Client.Subscribe
+//
+// State lives in one process — use it for a single gateway, the
+// standalone binary and tests, not behind a load balancer.
+type Memory struct {
+	mu      sync.Mutex
+	kv      map[string]memoryEntry
+	tickers map[string]string // stands in for the keyTickers hash
+	subs    map[string]map[chan string]struct{}
+
+	stop chan struct{}
+	once sync.Once
+}
+
+type memoryEntry struct {
+	value     string
+	expiresAt time.Time // zero means no expiry
+}
+
+func (e memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+func NewMemory() *Memory {
+	m := &Memory{
+		kv:      make(map[string]memoryEntry),
+		tickers: make(map[string]string),
+		subs:    make(map[string]map[chan string]struct{}),
+		stop:    make(chan struct{}),
+	}
+	go m.janitor(janitorInterval)
+	return m
+}
+
+// Ping always succeeds — there is nothing to reach.
+func (m *Memory) Ping(context.Context) error {
+	return nil
+}
+
+// Close stops the janitor and closes every open subscription.
+func (m *Memory) Close() error {
+	m.once.Do(func() {
+		close(m.stop)
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		for channel, subs := range m.subs {
+			for ch := range subs {
+				close(ch)
+			}
+			delete(m.subs, channel)
+		}
+	})
+	return nil
+}
+
+func (m *Memory) janitor(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			m.sweep()
+		case <-m.stop:
+			return
+		}
+	}
+}
+
+func (m *Memory) sweep() {
+	now := time.Now()
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key, e := range m.kv {
+		if e.expired(now) {
+			delete(m.kv, key)
+		}
+	}
+}
+
+// get returns the value for key if it hasn't expired. Caller holds m.mu.
+func (m *Memory) get(key string) (string, bool) {
+	e, ok := m.kv[key]
+	if !ok {
+		return "", false
+	}
+	if e.expired(time.Now()) {
+		delete(m.kv, key)
+		return "", false
+	}
+	return e.value, true
+}
+
+// set stores value under key. A ttl <= 0 means no expiry. Caller holds m.mu.
+func (m *Memory) set(key, value string, ttl time.Duration) {
+	e := memoryEntry{value: value}
+	if ttl > 0 {
+		e.expiresAt = time.Now().Add(ttl)
+	}
+	m.kv[key] = e
+}
+
+// ─── Order book snapshot ──────────────────────────────────────────────────────
+
+func (m *Memory) SetOrderBookSnapshot(
+	ctx context.Context,
+	symbol string,
+	snap models.OrderBookSnapshot,
+	ttl time.Duration,
+) error {
+	data, err := json.Marshal(snap)
+	if err != nil {
+		return fmt.Errorf("SetOrderBookSnapshot marshal: %w", err)
+	}
+	m.mu.Lock()
+	m.set(keyOrderBookSnapshot(symbol), string(data), ttl)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) GetOrderBookSnapshot(
+	ctx context.Context,
+	symbol string,
+) (*models.OrderBookSnapshot, error) {
+	m.mu.Lock()
+	data, ok := m.get(keyOrderBookSnapshot(symbol))
+	m.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	var snap models.OrderBookSnapshot
+	if err := json.Unmarshal([]byte(data), &snap); err != nil {
+		return nil, fmt.Errorf("GetOrderBookSnapshot unmarshal: %w", err)
+	}
+	return &snap, nil
+}
+
+// ─── Tickers ──────────────────────────────────────────────────────────────────
+
+func (m *Memory) SetTicker(ctx context.Context, ticker models.Ticker) error {
+	data, err := json.Marshal(ticker)
+	if err != nil {
+		return fmt.Errorf("SetTicker marshal: %w", err)
+	}
+	m.mu.Lock()
+	m.tickers[ticker.Symbol] = string(data)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
+	m.mu.Lock()
+	data, ok := m.tickers[symbol]
+	m.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	var ticker models.Ticker
+	if err := json.Unmarshal([]byte(data), &ticker); err != nil {
+		return nil, fmt.Errorf("GetTicker unmarshal: %w", err)
+	}
+	return &ticker, nil
+}
+
+func (m *Memory) GetTickers(ctx context.Context) ([]models.Ticker, error) {
+	m.mu.Lock()
+	all := make(map[string]string, len(m.tickers))
+	for symbol, data := range m.tickers {
+		all[symbol] = data
+	}
+	m.mu.Unlock()
+	return decodeTickers(all)
+}
+
+// ─── Rate limiting ────────────────────────────────────────────────────────────
+
+func (m *Memory) IncrWithExpiry(
+	ctx context.Context,
+	key string,
+	expiry time.Duration,
+) (int64, error) {
+	return m.IncrByWithExpiry(ctx, key, 1, expiry)
+}
+
+func (m *Memory) IncrByWithExpiry(
+	ctx context.Context,
+	key string,
+	n int64,
+	expiry time.Duration,
+) (int64, error) {
+	key = keyRateLimit(key)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var count int64
+	if val, ok := m.get(key); ok {
+		count, _ = strconv.ParseInt(val, 10, 64)
+	}
+	count += n
+	m.set(key, strconv.FormatInt(count, 10), expiry)
+	return count, nil
+}
+
+// ─── Pub/Sub ──────────────────────────────────────────────────────────────────
+
+func (m *Memory) Publish(ctx context.Context, channel string, payload any) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("Publish marshal: %w", err)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for ch := range m.subs[channel] {
+		select {
+		case ch <- string(data):
+		default:
+			// Subscriber is too slow — drop the message rather than
+			// block the publisher, same as Client.Subscribe.
+			metrics.PubSubDropped.WithLabelValues(channel).Inc()
+		}
+	}
+	return nil
+}
+
+// PublishTrade publishes a trade event to the symbol-specific trades channel.
+func (m *Memory) PublishTrade(ctx context.Context, event models.TradeEvent) error {
+	return m.Publish(ctx, channelTrades(event.Symbol), event)
+}
+
+// PublishOrderBookUpdate publishes an order book snapshot to subscribers.
+func (m *Memory) PublishOrderBookUpdate(
+	ctx context.Context,
+	snap models.OrderBookSnapshot,
+) error {
+	return m.Publish(ctx, channelOrderBook(snap.Symbol), snap)
+}
+
+func (m *Memory) PublishExecution(ctx context.Context, exec models.Execution) error {
+	return m.Publish(ctx, channelExecutions, exec)
+}
+
+func (m *Memory) SubscribeExecutions(ctx context.Context) (<-chan models.Execution, error) {
+	raw, err := m.Subscribe(ctx, channelExecutions)
+	if err != nil {
+		return nil, err
+	}
+	return decode[models.Execution](raw), nil
+}
+
+func (m *Memory) SubscribeTrades(ctx context.Context, symbol string) (<-chan models.TradeEvent, error) {
+	raw, err := m.Subscribe(ctx, channelTrades(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return decode[models.TradeEvent](raw), nil
+}
+
+func (m *Memory) SubscribeOrderBook(ctx context.Context, symbol string) (<-chan models.OrderBookSnapshot, error) {
+	raw, err := m.Subscribe(ctx, channelOrderBook(symbol))
+	if err != nil {
+		return nil, err
+	}
+	return decode[models.OrderBookSnapshot](raw), nil
+}
+
+// Subscribe returns a channel of raw JSON payloads published to channel
+// from now on. It is closed when ctx is cancelled or the cache is closed.
+func (m *Memory) Subscribe(ctx context.Context, channel string) (<-chan string, error) {
+	out := make(chan string, 64)
+
+	m.mu.Lock()
+	select {
+	case <-m.stop:
+		m.mu.Unlock()
+		return nil, fmt.Errorf("Subscribe %s: cache closed", channel)
+	default:
+	}
+	if m.subs[channel] == nil {
+		m.subs[channel] = make(map[chan string]struct{})
+	}
+	m.subs[channel][out] = struct{}{}
+	m.mu.Unlock()
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-m.stop:
+			return // Close already closed out
+		}
+
+		m.mu.Lock()
+		defer m.mu.Unlock()
+		if _, ok := m.subs[channel][out]; !ok {
+			return
+		}
+		delete(m.subs[channel], out)
+		if len(m.subs[channel]) == 0 {
+			delete(m.subs, channel)
+		}
+		close(out)
+	}()
+
+	return out, nil
+}
+
+// ─── Frozen accounts ──────────────────────────────────────────────────────────
+
+func (m *Memory) SetUserFrozen(ctx context.Context, userID string, frozen bool) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if frozen {
+		m.set(keyFrozenUser(userID), "1", 0)
+	} else {
+		delete(m.kv, keyFrozenUser(userID))
+	}
+	return nil
+}
+
+func (m *Memory) IsUserFrozen(ctx context.Context, userID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.get(keyFrozenUser(userID))
+	return ok, nil
+}
+
+// ─── Nonces ───────────────────────────────────────────────────────────────────
+
+func (m *Memory) SetNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	key := keyNonce(nonce)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.get(key); ok {
+		return false, nil
+	}
+	m.set(key, "1", ttl)
+	return true, nil
+}
+
+// ─── Revoked sessions ─────────────────────────────────────────────────────────
+
+func (m *Memory) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	m.mu.Lock()
+	m.set(keyRevokedSession(sessionID), "1", ttl)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.get(keyRevokedSession(sessionID))
+	return ok, nil
+}
+
+// ─── Idempotency keys ─────────────────────────────────────────────────────────
+
+func (m *Memory) ReserveIdempotencyKey(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.get(keyIdempotency(key)); ok {
+		return false, nil
+	}
+	m.set(keyIdempotency(key), "", ttl)
+	return true, nil
+}
+
+func (m *Memory) SetIdempotentResponse(
+	ctx context.Context,
+	key string,
+	resp models.IdempotentResponse,
+	ttl time.Duration,
+) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("SetIdempotentResponse marshal: %w", err)
+	}
+	m.mu.Lock()
+	m.set(keyIdempotency(key), string(data), ttl)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) GetIdempotentResponse(ctx context.Context, key string) (*models.IdempotentResponse, error) {
+	m.mu.Lock()
+	data, ok := m.get(keyIdempotency(key))
+	m.mu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+	return decodeIdempotentResponse(data)
+}
+
+func (m *Memory) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	m.mu.Lock()
+	delete(m.kv, keyIdempotency(key))
+	m.mu.Unlock()
+	return nil
+}
+
+// ─── JWT blocklist ────────────────────────────────────────────────────────────
+
+func (m *Memory) SetWithExpiry(
+	ctx context.Context,
+	key, value string,
+	expiry time.Duration,
+) error {
+	m.mu.Lock()
+	m.set(keyJWTBlocklist(key), value, expiry)
+	m.mu.Unlock()
+	return nil
+}
+
+func (m *Memory) Get(ctx context.Context, key string) (string, error) {
+	m.mu.Lock()
+	val, _ := m.get(keyJWTBlocklist(key))
+	m.mu.Unlock()
+	return val, nil
+}
+
+func (m *Memory) Delete(ctx context.Context, key string) error {
+	m.mu.Lock()
+	delete(m.kv, keyJWTBlocklist(key))
+	m.mu.Unlock()
+	return nil
+}
@@ -0,0 +1,119 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/models"
+)
+
+func TestMemoryKeysExpire(t *testing.T) {
+	m := NewMemory()
+	defer m.Close()
+	ctx := context.Background()
+
+	_ = m.SetWithExpiry(ctx, "jti", "blocked", 20*time.Millisecond)
+	if val, _ := m.Get(ctx, "jti"); val != "blocked" {
+		t.Fatalf("expected blocked, got %q", val)
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if val, _ := m.Get(ctx, "jti"); val != "" {
+		t.Errorf("expected key to have expired, got %q", val)
+	}
+
+	_ = m.SetOrderBookSnapshot(ctx, "BTC-USD", models.OrderBookSnapshot{Symbol: "BTC-USD"}, 20*time.Millisecond)
+	time.Sleep(30 * time.Millisecond)
+	m.sweep()
+	if len(m.kv) != 0 {
+		t.Errorf("expected sweep to remove expired keys, %d left", len(m.kv))
+	}
+}
+
+func TestMemoryIncrRefreshesExpiry(t *testing.T) {
+	m := NewMemory()
+	defer m.Close()
+	ctx := context.Background()
+
+	for i := int64(1); i <= 3; i++ {
+		n, _ := m.IncrWithExpiry(ctx, "user", 40*time.Millisecond)
+		if n != i {
+			t.Fatalf("expected %d, got %d", i, n)
+		}
+		time.Sleep(25 * time.Millisecond) // each increment pushes expiry out again
+	}
+
+	time.Sleep(50 * time.Millisecond)
+	if n, _ := m.IncrWithExpiry(ctx, "user", time.Second); n != 1 {
+		t.Errorf("expected counter to restart after expiry, got %d", n)
+	}
+}
+
+func TestMemoryFanOut(t *testing.T) {
+	m := NewMemory()
+	defer m.Close()
+	ctx := context.Background()
+
+	a, _ := m.Subscribe(ctx, "trades:BTC-USD")
+	b, _ := m.Subscribe(ctx, "trades:BTC-USD")
+	other, _ := m.Subscribe(ctx, "trades:ETH-USD")
+
+	_ = m.PublishTrade(ctx, models.TradeEvent{Trade: models.Trade{Symbol: "BTC-USD"}})
+
+	for _, ch := range []<-chan string{a, b} {
+		select {
+		case <-ch:
+		case <-time.After(time.Second):
+			t.Fatal("subscriber missed the message")
+		}
+	}
+	select {
+	case msg := <-other:
+		t.Errorf("unexpected message on another channel: %s", msg)
+	default:
+	}
+}
+
+func TestMemorySlowSubscriberDrops(t *testing.T) {
+	m := NewMemory()
+	defer m.Close()
+	ctx := context.Background()
+
+	ch, _ := m.Subscribe(ctx, "orderbook:BTC-USD")
+	for i := 0; i < 100; i++ {
+		if err := m.Publish(ctx, "orderbook:BTC-USD", i); err != nil {
+			t.Fatalf("Publish blocked or failed: %v", err)
+		}
+	}
+	if len(ch) != cap(ch) {
+		t.Errorf("expected buffer full at %d, got %d", cap(ch), len(ch))
+	}
+	if first := <-ch; first != "0" {
+		t.Errorf("expected the oldest messages kept, got %s first", first)
+	}
+}
+
+func TestMemoryUnsubscribeOnCancel(t *testing.T) {
+	m := NewMemory()
+	defer m.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch, _ := m.Subscribe(ctx, "trades:BTC-USD")
+	cancel()
+
+	select {
+	case _, ok := <-ch:
+		if ok {
+			t.Fatal("expected channel to be closed, got a message")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("channel not closed after cancel")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if len(m.subs) != 0 {
+		t.Errorf("expected subscription removed, %d channels left", len(m.subs))
+	}
+}
@@ -4,10 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
 
 	"github.com/Im-Manav/ome/internal/config"
+	"github.com/Im-Manav/ome/internal/metrics"
 	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -37,6 +40,11 @@ func NewClient(cfg *config.Config) (*Client, error) {
 		return nil, fmt.Errorf("redis ping failed: %w", err)
 	}
 
+	// One span per command, parented to whatever trace ctx carries
+	if err := redisotel.InstrumentTracing(rdb); err != nil {
+		return nil, fmt.Errorf("redis tracing: %w", err)
+	}
+
 	return &Client{rdb: rdb}, nil
 }
 
@@ -44,6 +52,11 @@ func (c *Client) Close() error {
 	return c.rdb.Close()
 }
 
+// Ping round-trips to Redis — used by the readiness probe.
+func (c *Client) Ping(ctx context.Context) error {
+	return c.rdb.Ping(ctx).Err()
+}
+
 // ─── Key helpers ─────────────────────────────────────────────────────────────
 // All Redis keys go through these helpers — never hardcode key strings
 // anywhere else in the codebase. One place to change, one place to read.
@@ -60,6 +73,26 @@ func keyJWTBlocklist(tokenID string) string {
 	return fmt.Sprintf("jwt:blocklist:%s", tokenID)
 }
 
+// keyTickers is one hash of symbol → ticker JSON, so GET /tickers is
+// a single HGETALL rather than a key scan.
+const keyTickers = "tickers"
+
+func keyNonce(nonce string) string {
+	return fmt.Sprintf("nonce:%s", nonce)
+}
+
+func keyFrozenUser(userID string) string {
+	return fmt.Sprintf("user:frozen:%s", userID)
+}
+
+func keyRevokedSession(sessionID string) string {
+	return fmt.Sprintf("session:revoked:%s", sessionID)
+}
+
+func keyIdempotency(key string) string {
+	return fmt.Sprintf("idempotency:%s", key)
+}
+
 func channelTrades(symbol string) string {
 	return fmt.Sprintf("trades:%s", symbol)
 }
@@ -68,6 +101,8 @@ func channelOrderBook(symbol string) string {
 	return fmt.Sprintf("orderbook:%s", symbol)
 }
 
+const channelExecutions = "executions"
+
 // ─── Order book snapshot ──────────────────────────────────────────────────────
 // The matching engine writes a fresh snapshot after every match.
 // The GET /orderbook/:symbol handler reads from here instead of
@@ -105,6 +140,58 @@ func (c *Client) GetOrderBookSnapshot(
 	return &snap, nil
 }
 
+// ─── Tickers ──────────────────────────────────────────────────────────────────
+// The market data service writes a symbol's 24h ticker after every trade
+// and as its window rolls; the gateway's ticker endpoints read from here.
+
+func (c *Client) SetTicker(ctx context.Context, ticker models.Ticker) error {
+	data, err := json.Marshal(ticker)
+	if err != nil {
+		return fmt.Errorf("SetTicker marshal: %w", err)
+	}
+	return c.rdb.HSet(ctx, keyTickers, ticker.Symbol, data).Err()
+}
+
+// GetTicker returns nil, nil if the symbol has no ticker yet.
+func (c *Client) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
+	data, err := c.rdb.HGet(ctx, keyTickers, symbol).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetTicker: %w", err)
+	}
+
+	var ticker models.Ticker
+	if err := json.Unmarshal(data, &ticker); err != nil {
+		return nil, fmt.Errorf("GetTicker unmarshal: %w", err)
+	}
+	return &ticker, nil
+}
+
+// GetTickers returns every symbol's ticker, sorted by symbol.
+func (c *Client) GetTickers(ctx context.Context) ([]models.Ticker, error) {
+	all, err := c.rdb.HGetAll(ctx, keyTickers).Result()
+	if err != nil {
+		return nil, fmt.Errorf("GetTickers: %w", err)
+	}
+	return decodeTickers(all)
+}
+
+// decodeTickers unmarshals a symbol → JSON map into tickers sorted by symbol.
+func decodeTickers(all map[string]string) ([]models.Ticker, error) {
+	tickers := make([]models.Ticker, 0, len(all))
+	for symbol, data := range all {
+		var ticker models.Ticker
+		if err := json.Unmarshal([]byte(data), &ticker); err != nil {
+			return nil, fmt.Errorf("GetTickers unmarshal %s: %w", symbol, err)
+		}
+		tickers = append(tickers, ticker)
+	}
+	sort.Slice(tickers, func(i, j int) bool { return tickers[i].Symbol < tickers[j].Symbol })
+	return tickers, nil
+}
+
 // ─── Rate limiting ────────────────────────────────────────────────────────────
 // Fixed window counter — increment a key, set expiry on first increment.
 // The API gateway calls this before processing any order request.
@@ -115,14 +202,23 @@ func (c *Client) IncrWithExpiry(
 	ctx context.Context,
 	key string,
 	expiry time.Duration,
+) (int64, error) {
+	return c.IncrByWithExpiry(ctx, key, 1, expiry)
+}
+
+func (c *Client) IncrByWithExpiry(
+	ctx context.Context,
+	key string,
+	n int64,
+	expiry time.Duration,
 ) (int64, error) {
 	// Pipeline both commands - one round trip instead of two
 	pipe := c.rdb.Pipeline()
-	incr := pipe.Incr(ctx, keyRateLimit(key))
+	incr := pipe.IncrBy(ctx, keyRateLimit(key), n)
 	pipe.Expire(ctx, keyRateLimit(key), expiry)
 
 	if _, err := pipe.Exec(ctx); err != nil {
-		return 0, fmt.Errorf("IncrWithExpiry: %w", err)
+		return 0, fmt.Errorf("IncrByWithExpiry: %w", err)
 	}
 	return incr.Val(), nil
 }
@@ -152,6 +248,36 @@ func (c *Client) PublishOrderBookUpdate(
 	return c.Publish(ctx, channelOrderBook(snap.Symbol), snap)
 }
 
+// PublishExecution publishes the engine's result for one order.
+func (c *Client) PublishExecution(ctx context.Context, exec models.Execution) error {
+	return c.Publish(ctx, channelExecutions, exec)
+}
+
+func (c *Client) SubscribeExecutions(ctx context.Context) (<-chan models.Execution, error) {
+	raw, err := c.Subscribe(ctx, channelExecutions)
+	if err != nil {
+		return nil, err
+	}
+	return decode[models.Execution](raw), nil
+}
+
+// decode unmarshals a subscription's payloads, skipping any that don't
+// decode. The output closes with the input.
+func decode[T any](raw <-chan string) <-chan T {
+	out := make(chan T, 64)
+	go func() {
+		defer close(out)
+		for payload := range raw {
+			var v T
+			if err := json.Unmarshal([]byte(payload), &v); err != nil {
+				continue
+			}
+			out <- v
+		}
+	}()
+	return out
+}
+
 // Subscribe returns a channel that streams raw JSON strings from a Redis
 // pub/sub channel. The caller is responsible for unmarshalling.
 // The goroutine exits cleanly when ctx is cancelled.
@@ -183,7 +309,8 @@ func (c *Client) Subscribe(
 				case out <- msg.Payload:
 				default:
 					// Subscriber is too slow — drop the message rather than
-					// block the Redis reader. In production a Prometheus counter needs to be increment here
+					// block the Redis reader.
+					metrics.PubSubDropped.WithLabelValues(channel).Inc()
 				}
 			case <-ctx.Done():
 				return
@@ -204,22 +331,10 @@ func (c *Client) SubscribeTrades(
 	if err != nil {
 		return nil, err
 	}
-
-	out := make(chan models.TradeEvent, 64)
-
-	go func() {
-		defer close(out)
-		for payload := range raw {
-			var event models.TradeEvent
-			if err := json.Unmarshal([]byte(payload), &event); err != nil {
-				continue
-			}
-			out <- event
-		}
-	}()
-	return out, nil
+	return decode[models.TradeEvent](raw), nil
 }
 
+// SubscribeOrderBook subscribes to the order book updates for a symbol.
 func (c *Client) SubscribeOrderBook(
 	ctx context.Context,
 	symbol string,
@@ -229,20 +344,105 @@ func (c *Client) SubscribeOrderBook(
 		return nil, err
 	}
 
-	out := make(chan models.OrderBookSnapshot, 64)
+	return decode[models.OrderBookSnapshot](raw), nil
+}
 
-	go func() {
-		defer close(out)
-		for payload := range raw {
-			var snap models.OrderBookSnapshot
-			if err := json.Unmarshal([]byte(payload), &snap); err != nil {
-				continue
-			}
-			out <- snap
-		}
-	}()
+// ─── Nonces ───────────────────────────────────────────────────────────────────
+// SET NX is atomic, so two gateways racing on the same replayed request
+// can't both accept it.
 
-	return out, nil
+func (c *Client) SetNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error) {
+	ok, err := c.rdb.SetNX(ctx, keyNonce(nonce), 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("SetNonce: %w", err)
+	}
+	return ok, nil
+}
+
+// ─── Frozen accounts ──────────────────────────────────────────────────────────
+// The users table is the source of truth; these markers, with no expiry,
+// let the auth middleware check without a database query per request.
+
+func (c *Client) SetUserFrozen(ctx context.Context, userID string, frozen bool) error {
+	if !frozen {
+		return c.rdb.Del(ctx, keyFrozenUser(userID)).Err()
+	}
+	return c.rdb.Set(ctx, keyFrozenUser(userID), 1, 0).Err()
+}
+
+func (c *Client) IsUserFrozen(ctx context.Context, userID string) (bool, error) {
+	n, err := c.rdb.Exists(ctx, keyFrozenUser(userID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("IsUserFrozen: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ─── Revoked sessions ─────────────────────────────────────────────────────────
+
+func (c *Client) RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error {
+	return c.rdb.Set(ctx, keyRevokedSession(sessionID), 1, ttl).Err()
+}
+
+func (c *Client) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	n, err := c.rdb.Exists(ctx, keyRevokedSession(sessionID)).Result()
+	if err != nil {
+		return false, fmt.Errorf("IsSessionRevoked: %w", err)
+	}
+	return n > 0, nil
+}
+
+// ─── Idempotency keys ─────────────────────────────────────────────────────────
+// A reserved key holds an empty value until the response is stored.
+// SET NX makes the reservation atomic across gateways.
+
+func (c *Client) ReserveIdempotencyKey(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ok, err := c.rdb.SetNX(ctx, keyIdempotency(key), "", ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("ReserveIdempotencyKey: %w", err)
+	}
+	return ok, nil
+}
+
+func (c *Client) SetIdempotentResponse(
+	ctx context.Context,
+	key string,
+	resp models.IdempotentResponse,
+	ttl time.Duration,
+) error {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		return fmt.Errorf("SetIdempotentResponse marshal: %w", err)
+	}
+	return c.rdb.Set(ctx, keyIdempotency(key), data, ttl).Err()
+}
+
+func (c *Client) GetIdempotentResponse(ctx context.Context, key string) (*models.IdempotentResponse, error) {
+	data, err := c.rdb.Get(ctx, keyIdempotency(key)).Result()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("GetIdempotentResponse: %w", err)
+	}
+	return decodeIdempotentResponse(data)
+}
+
+// decodeIdempotentResponse returns nil for a key that is reserved but
+// has no response yet.
+func decodeIdempotentResponse(data string) (*models.IdempotentResponse, error) {
+	if data == "" {
+		return nil, nil
+	}
+	var resp models.IdempotentResponse
+	if err := json.Unmarshal([]byte(data), &resp); err != nil {
+		return nil, fmt.Errorf("GetIdempotentResponse unmarshal: %w", err)
+	}
+	return &resp, nil
+}
+
+func (c *Client) ReleaseIdempotencyKey(ctx context.Context, key string) error {
+	return c.rdb.Del(ctx, keyIdempotency(key)).Err()
 }
 
 // ─── JWT blocklist ────────────────────────────────────────────────────────────
@@ -5,6 +5,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -12,8 +13,10 @@ import (
 type Config struct {
 	Env            string
 	GatewayPort    string
+	GRPCPort       string // the gateway's gRPC trading API
 	EnginePort     string
 	MarketDataPort string
+	PredictorPort  string
 
 	DBHost     string
 	DBPort     string
@@ -26,11 +29,62 @@ type Config struct {
 	RedisPort     string
 	RedisPassword string
 
+	// CacheBackend is "redis" (default) or "memory". Memory keeps rate
+	// limits and the JWT blocklist in-process — single gateway only.
+	CacheBackend string
+
 	KafkaBrokers []string
 	KafkaGroupID string
+	KafkaCodecs  map[string]string // topic -> "json" | "protobuf"
+
+	// HealthMaxConsumerLag is how far behind a consumer may fall, in
+	// messages, before its service reports not ready.
+	HealthMaxConsumerLag int64
+
+	JWTSecret string
+
+	// AccessTokenTTL is the lifetime of a JWT; clients renew it with
+	// their refresh token. RefreshTokenTTL is how long a login session
+	// lasts before the user has to sign in again.
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+
+	// APIKeyEncryptionKey encrypts API key secrets at rest. Services
+	// that store API keys refuse to start without one of their own;
+	// rotating it invalidates every issued key.
+	APIKeyEncryptionKey string
+
+	// AdminEmails are promoted to the admin role on startup, if their
+	// accounts exist — how the first admin gets in.
+	AdminEmails []string
+
+	// OrderWaitTimeout is how long POST /orders?wait=true waits for the
+	// engine's result before answering 202 with the order pending.
+	OrderWaitTimeout time.Duration
+
+	// OutboxPollInterval is how often the outbox relay looks for events
+	// it wasn't told about, such as those left by a crashed gateway.
+	OutboxPollInterval time.Duration
+
+	// Trading fees in basis points of notional, reported on fills.
+	FeeMakerBps float64
+	FeeTakerBps float64
+
+	// TracesExporter is "none" (default), "otlp" or "stdout". The OTLP
+	// endpoint comes from the standard OTEL_EXPORTER_OTLP_ENDPOINT.
+	TracesExporter string
 
-	JWTSecret      string
-	JWTExpiryHours int
+	// EngineAdminToken guards the engine admin API on EnginePort.
+	// The admin server is not started when it is empty.
+	EngineAdminToken string
+
+	// Symbols is the instrument list for services that need one up front
+	// (e.g. the predictor) rather than discovering symbols from orders.
+	Symbols []string
+
+	// Standalone (cmd/ome-standalone) only
+	StandaloneDBPath    string
+	StandalonePredictor bool
 
 	AIProvider         string
 	AIBaseURL          string
@@ -43,16 +97,22 @@ func Load() (*Config, error) {
 	// In production the env vars are injected by K8s — .env is for local dev only
 	_ = godotenv.Load()
 
-	jwtExpiry, err := strconv.Atoi(getEnv("JWT_EXPIRY_HOURS", "24"))
-	if err != nil {
-		jwtExpiry = 24
+	accessMinutes, err := strconv.Atoi(getEnv("ACCESS_TOKEN_TTL_MINUTES", "15"))
+	if err != nil || accessMinutes < 1 {
+		accessMinutes = 15
+	}
+	refreshHours, err := strconv.Atoi(getEnv("REFRESH_TOKEN_TTL_HOURS", "720"))
+	if err != nil || refreshHours < 1 {
+		refreshHours = 720
 	}
 
 	cfg := &Config{
 		Env:            getEnv("ENV", "development"),
 		GatewayPort:    getEnv("GATEWAY_PORT", "8080"),
+		GRPCPort:       getEnv("GRPC_PORT", "9090"),
 		EnginePort:     getEnv("ENGINE_PORT", "8081"),
 		MarketDataPort: getEnv("MARKET_DATA_PORT", "8082"),
+		PredictorPort:  getEnv("PREDICTOR_PORT", "8083"),
 
 		DBHost:     getEnv("DB_HOST", "localhost"),
 		DBPort:     getEnv("DB_PORT", "5432"),
@@ -64,19 +124,57 @@ func Load() (*Config, error) {
 		RedisHost:     getEnv("REDIS_HOST", "localhost"),
 		RedisPort:     getEnv("REDIS_PORT", "6379"),
 		RedisPassword: getEnv("REDIS_PASSWORD", ""),
+		CacheBackend:  getEnv("CACHE_BACKEND", "redis"),
 
 		KafkaBrokers: strings.Split(getEnv("KAFKA_BROKERS", "localhost:9092"), ","),
 		KafkaGroupID: getEnv("KAFKA_GROUP_ID", "ome-engine"),
+		KafkaCodecs:  parseKeyValues(getEnv("KAFKA_CODECS", "")),
+
+		JWTSecret:       getEnv("JWT_SECRET", "change_me"),
+		AccessTokenTTL:  time.Duration(accessMinutes) * time.Minute,
+		RefreshTokenTTL: time.Duration(refreshHours) * time.Hour,
+
+		TracesExporter:   getEnv("OTEL_TRACES_EXPORTER", "none"),
+		EngineAdminToken: getEnv("ENGINE_ADMIN_TOKEN", ""),
+	}
+
+	cfg.APIKeyEncryptionKey = getEnv("API_KEY_ENCRYPTION_KEY", "")
+
+	waitMs, err := strconv.Atoi(getEnv("ORDER_WAIT_TIMEOUT_MS", "5000"))
+	if err != nil || waitMs < 1 {
+		waitMs = 5000
+	}
+	cfg.OrderWaitTimeout = time.Duration(waitMs) * time.Millisecond
 
-		JWTSecret:      getEnv("JWT_SECRET", "change_me"),
-		JWTExpiryHours: jwtExpiry,
+	pollMs, err := strconv.Atoi(getEnv("OUTBOX_POLL_INTERVAL_MS", "500"))
+	if err != nil || pollMs < 1 {
+		pollMs = 500
 	}
+	cfg.OutboxPollInterval = time.Duration(pollMs) * time.Millisecond
+
+	if emails := getEnv("ADMIN_EMAILS", ""); emails != "" {
+		cfg.AdminEmails = strings.Split(emails, ",")
+	}
+
+	cfg.Symbols = strings.Split(getEnv("SYMBOLS", "BTC-USD,ETH-USD,AAPL,TSLA"), ",")
+
+	cfg.StandaloneDBPath = getEnv("STANDALONE_DB_PATH", "ome.db")
+	cfg.StandalonePredictor = getEnv("STANDALONE_PREDICTOR", "false") == "true"
 
 	cfg.AIProvider = getEnv("AI_PROVIDER", "ollama")
 	cfg.AIBaseURL = getEnv("AI_BASE_URL", "http://localhost:11434")
 	cfg.AIModel = getEnv("AI_MODEL", "llama3.2")
 	cfg.AIAPIKey = getEnv("AI_API_KEY", "")
 
+	cfg.FeeMakerBps = getEnvFloat("FEE_MAKER_BPS", 0)
+	cfg.FeeTakerBps = getEnvFloat("FEE_TAKER_BPS", 10)
+
+	maxLag, err := strconv.ParseInt(getEnv("HEALTH_MAX_CONSUMER_LAG", "1000"), 10, 64)
+	if err != nil {
+		maxLag = 1000
+	}
+	cfg.HealthMaxConsumerLag = maxLag
+
 	predInterval, err := strconv.Atoi(getEnv("PREDICTION_INTERVAL_SECONDS", "60"))
 	if err != nil {
 		predInterval = 60
@@ -96,6 +194,20 @@ func (c *Config) validate() error {
 	return nil
 }
 
+// CheckAPIKeyEncryptionKey reports why APIKeyEncryptionKey can't be
+// used, for the services that store API keys. It must be set, and not
+// to the JWT secret: a leaked signing key shouldn't also decrypt every
+// API key secret.
+func (c *Config) CheckAPIKeyEncryptionKey() error {
+	switch c.APIKeyEncryptionKey {
+	case "", "change_me":
+		return fmt.Errorf("API_KEY_ENCRYPTION_KEY must be set")
+	case c.JWTSecret:
+		return fmt.Errorf("API_KEY_ENCRYPTION_KEY must differ from JWT_SECRET")
+	}
+	return nil
+}
+
 func (c *Config) DBConnectionString() string {
 	return fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s TimeZone=UTC",
 		c.DBHost, c.DBPort, c.DBUser, c.DBPassword, c.DBName, c.DBSSLMode)
@@ -111,3 +223,25 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+// getEnvFloat reads a float, falling back on a missing or invalid value.
+func getEnvFloat(key string, fallback float64) float64 {
+	v, err := strconv.ParseFloat(getEnv(key, ""), 64)
+	if err != nil {
+		return fallback
+	}
+	return v
+}
+
+// parseKeyValues parses "a=x,b=y" into a map. Blank entries are skipped.
+func parseKeyValues(s string) map[string]string {
+	out := make(map[string]string)
+	for _, pair := range strings.Split(s, ",") {
+		key, val, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok || key == "" {
+			continue
+		}
+		out[strings.TrimSpace(key)] = strings.TrimSpace(val)
+	}
+	return out
+}
@@ -0,0 +1,74 @@
+package db
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/models"
+)
+
+// ParseInterval turns a Postgres-style interval ("1 minute", "5 minutes",
+// "1 hour", "1 day") into a duration. It accepts the same strings callers
+// already pass to GetOHLCV for time_bucket, so both backends share one API.
+func ParseInterval(interval string) (time.Duration, error) {
+	fields := strings.Fields(interval)
+	if len(fields) != 2 {
+		return 0, fmt.Errorf("invalid interval %q", interval)
+	}
+
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n <= 0 {
+		return 0, fmt.Errorf("invalid interval %q", interval)
+	}
+
+	var unit time.Duration
+	switch strings.TrimSuffix(strings.ToLower(fields[1]), "s") {
+	case "second":
+		unit = time.Second
+	case "minute":
+		unit = time.Minute
+	case "hour":
+		unit = time.Hour
+	case "day":
+		unit = 24 * time.Hour
+	case "week":
+		unit = 7 * 24 * time.Hour
+	default:
+		return 0, fmt.Errorf("invalid interval %q", interval)
+	}
+	return time.Duration(n) * unit, nil
+}
+
+// candleFolder merges stored bars, newest first, into candles of a fixed
+// width — the portable equivalent of time_bucket + first/last.
+type candleFolder struct {
+	width   time.Duration
+	limit   int // <= 0 for no limit
+	candles []models.OHLCV
+}
+
+// add folds the next (older) bar in. It returns false once limit candles
+// are complete and the bar belongs to a candle that won't be returned.
+func (f *candleFolder) add(bar models.OHLCV) bool {
+	start := bar.Time.UTC().Truncate(f.width)
+
+	n := len(f.candles)
+	if n == 0 || !f.candles[n-1].Time.Equal(start) {
+		if f.limit > 0 && n == f.limit {
+			return false
+		}
+		bar.Time = start
+		f.candles = append(f.candles, bar)
+		return true
+	}
+
+	// Same bucket, older bar: it sets the open, close stays with the newest.
+	c := &f.candles[n-1]
+	c.Open = bar.Open
+	c.High = max(c.High, bar.High)
+	c.Low = min(c.Low, bar.Low)
+	c.Volume += bar.Volume
+	return true
+}
@@ -38,10 +38,43 @@ func Migrate(db *gorm.DB) error {
 		&models.Order{},
 		&models.Trade{},
 		&models.OHLCV{},
+		&models.APIKey{},
+		&models.Session{},
+		&models.Instrument{},
+		&models.AuditLog{},
+		&models.OutboxEvent{},
 	); err != nil {
 		return fmt.Errorf("automigrate failed: %w", err)
 	}
 
+	// Steps 2-3 are TimescaleDB-only — skipped on the embedded SQLite store.
+	if db.Dialector.Name() == "postgres" {
+		migrateTimescale(db)
+	}
+
+	// Step 4: indexes for hot query paths
+	db.Exec(`CREATE INDEX IF NOT EXISTS idx_orders_symbol_status ON orders (symbol, status)`)
+	db.Exec(`CREATE INDEX IF NOT EXISTS idx_orders_users_created ON orders (user_id, created_at DESC)`)
+	db.Exec(`CREATE INDEX IF NOT EXISTS idx_trades_symbol_executed ON trades (symbol, executed_at DESC)`)
+	db.Exec(`CREATE INDEX IF NOT EXISTS idx_ohlcv_symbol_time ON ohlcvs (symbol, time DESC)`)
+
+	// Step 5: client order IDs are unique per user. Partial, so orders
+	// without one don't collide — and unlike the indexes above, placing
+	// orders depends on it, so a failure here is fatal.
+	err := db.Exec(`
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_user_client_order_id
+		ON orders (user_id, client_order_id)
+		WHERE client_order_id IS NOT NULL AND client_order_id <> ''
+	`).Error
+	if err != nil {
+		return fmt.Errorf("create client order id index: %w", err)
+	}
+
+	return nil
+}
+
+// migrateTimescale enables TimescaleDB and turns ohlcvs into a hypertable.
+func migrateTimescale(db *gorm.DB) {
 	// Step 2: enable TimescaleDB extension (idempotent)
 	if err := db.Exec("CREATE EXTENSION IF NOT EXSISTS timescaledb CASCADE").Error; err != nil {
 		// Non-fatal — TimescaleDB may not be installed in dev without the extension
@@ -57,12 +90,4 @@ func Migrate(db *gorm.DB) error {
 			migrate_data => TRUE
 		)
 	`)
-
-	// Step 4: indexes for hot query paths
-	db.Exec(`CREATE INDEX IF NOT EXISTS idx_orders_symbol_status ON orders (symbol, status)`)
-	db.Exec(`CREATE INDEX IF NOT EXISTS idx_orders_users_created ON orders (user_id, created_at DESC)`)
-	db.Exec(`CREATE INDEX IF NOT EXISTS idx_trades_symbol_executed ON trades (symbol, executed_at DESC)`)
-	db.Exec(`CREATE INDEX IF NOT EXISTS idx_ohlcv_symbol_time ON ohlcvs (symbol, time DESC)`)
-
-	return nil
 }
@@ -0,0 +1,39 @@
+package db
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Im-Manav/ome/internal/db/repotest"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestPostgresRepository needs a TimescaleDB instance, e.g. the one from
+// docker compose. Tables are truncated before every case.
+//
+//	OME_TEST_POSTGRES_DSN="host=localhost user=ome password=ome dbname=ome_test sslmode=disable" go test ./internal/db/
+func TestPostgresRepository(t *testing.T) {
+	dsn := os.Getenv("OME_TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("OME_TEST_POSTGRES_DSN not set")
+	}
+
+	database, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	if err := Migrate(database); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+
+	repotest.Run(t, func(t *testing.T) repotest.Repository {
+		if err := database.Exec(`TRUNCATE users, orders, trades, ohlcvs`).Error; err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		return NewRepository(database)
+	})
+}
@@ -2,18 +2,25 @@ package db
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Im-Manav/ome/pkg/models"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
-// Repository implements all four repository ports:
+// Repository implements the repository ports:
 //   - ports.OrderRepository
 //   - ports.TradeRepository
 //   - ports.OHLCVRepository
 //   - ports.UserRepository
+//   - ports.APIKeyRepository
+//   - ports.SessionRepository
+//   - ports.InstrumentRepository
+//   - ports.AuditRepository
+//   - ports.OutboxRepository
 type Repository struct {
 	db *gorm.DB
 }
@@ -30,8 +37,19 @@ func (r *Repository) SaveOrder(order *models.Order) error {
 	return nil
 }
 
+// UpdateOrder writes the columns matching changes: status and fill
+// progress. The rest of the row belongs to the gateway that created the
+// order, and an engine's decoded copy may not carry all of it.
 func (r *Repository) UpdateOrder(order *models.Order) error {
-	if err := r.db.Save(order).Error; err != nil {
+	err := r.db.Model(&models.Order{}).
+		Where("id = ?", order.ID).
+		Updates(map[string]any{
+			"status":        order.Status,
+			"filled_qty":    order.FilledQty,
+			"remaining_qty": order.RemainingQty,
+			"updated_at":    time.Now().UTC(),
+		}).Error
+	if err != nil {
 		return fmt.Errorf("UpdateOrder: %w", err)
 	}
 	return nil
@@ -45,6 +63,20 @@ func (r *Repository) GetOrderByID(id uuid.UUID) (*models.Order, error) {
 	return &order, nil
 }
 
+func (r *Repository) GetOrderByClientOrderID(userID uuid.UUID, clientOrderID string) (*models.Order, error) {
+	var orders []models.Order
+	err := r.db.Where("user_id = ? AND client_order_id = ?", userID, clientOrderID).
+		Limit(1).
+		Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("GetOrderByClientOrderID: %w", err)
+	}
+	if len(orders) == 0 {
+		return nil, nil
+	}
+	return &orders[0], nil
+}
+
 func (r *Repository) GetOpenOrdersBySymbol(symbol string) ([]*models.Order, error) {
 	var orders []*models.Order
 	err := r.db.Where("symbol = ? AND status IN ?", symbol, []models.OrderStatus{
@@ -57,6 +89,57 @@ func (r *Repository) GetOpenOrdersBySymbol(symbol string) ([]*models.Order, erro
 	return orders, nil
 }
 
+// ListOrdersByUserID returns up to filter.Limit of a user's orders,
+// newest first, ordered by (created_at, id) so cursor pages are stable.
+func (r *Repository) ListOrdersByUserID(userID uuid.UUID, filter models.OrderFilter) ([]*models.Order, error) {
+	q := r.db.Where("user_id = ?", userID)
+	if len(filter.Statuses) > 0 {
+		q = q.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Symbol != "" {
+		q = q.Where("symbol = ?", filter.Symbol)
+	}
+	if filter.Side != nil {
+		q = q.Where("side = ?", *filter.Side)
+	}
+
+	var orders []*models.Order
+	err := page(q, "created_at", filter.PageQuery).Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("ListOrdersByUserID: %w", err)
+	}
+	return orders, nil
+}
+
+// page applies a PageQuery to a listing ordered newest first by
+// (column, id). The id breaks ties so cursor pages are stable.
+func page(q *gorm.DB, column string, p models.PageQuery) *gorm.DB {
+	if !p.From.IsZero() {
+		q = q.Where(column+" >= ?", p.From)
+	}
+	if !p.To.IsZero() {
+		q = q.Where(column+" < ?", p.To)
+	}
+	if c := p.After; c != nil {
+		q = q.Where(column+" < ? OR ("+column+" = ? AND id < ?)", c.Time, c.Time, c.ID)
+	}
+	return q.Order(column + " DESC, id DESC").Limit(p.Limit)
+}
+
+// GetOpenOrders returns every resting order across all symbols, oldest
+// first — the engine replays them to rebuild its books on startup.
+func (r *Repository) GetOpenOrders() ([]*models.Order, error) {
+	var orders []*models.Order
+	err := r.db.Where("status IN ?", []models.OrderStatus{
+		models.StatusOpen,
+		models.StatusPartial,
+	}).Order("created_at ASC").Find(&orders).Error
+	if err != nil {
+		return nil, fmt.Errorf("GetOpenOrders: %w", err)
+	}
+	return orders, nil
+}
+
 func (r *Repository) GetOrdersByUserID(userID uuid.UUID) ([]*models.Order, error) {
 	var orders []*models.Order
 	err := r.db.Where("user_id = ?", userID).
@@ -70,7 +153,60 @@ func (r *Repository) GetOrdersByUserID(userID uuid.UUID) ([]*models.Order, error
 }
 
 func (r *Repository) CancelOrder(id uuid.UUID) error {
-	result := r.db.Model(&models.Order{}).
+	if err := cancelLiveOrder(r.db, id); err != nil {
+		return fmt.Errorf("CancelOrder: %w", err)
+	}
+	return nil
+}
+
+// ReplaceOrder cancels a live order and saves its replacement in one
+// transaction, so an amend can't leave the user with neither.
+func (r *Repository) ReplaceOrder(oldID uuid.UUID, replacement *models.Order) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := cancelLiveOrder(tx, oldID); err != nil {
+			return err
+		}
+		return tx.Create(replacement).Error
+	})
+	if err != nil {
+		return fmt.Errorf("ReplaceOrder: %w", err)
+	}
+	return nil
+}
+
+// FillOrders adds each fill to its order, in one transaction. Each
+// update is a single conditional statement on the stored quantities,
+// so it needs no read first and leaves orders that were cancelled or
+// otherwise closed meanwhile as they are.
+func (r *Repository) FillOrders(fills []models.OrderFill) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, f := range fills {
+			err := tx.Model(&models.Order{}).
+				Where("id = ? AND status IN ?", f.OrderID, []models.OrderStatus{
+					models.StatusOpen,
+					models.StatusPartial,
+				}).
+				Updates(map[string]any{
+					"filled_qty":    gorm.Expr("filled_qty + ?", f.Quantity),
+					"remaining_qty": gorm.Expr("CASE WHEN remaining_qty - ? <= 0 THEN 0 ELSE remaining_qty - ? END", f.Quantity, f.Quantity),
+					"status":        gorm.Expr("CASE WHEN remaining_qty - ? <= 0 THEN ? ELSE ? END", f.Quantity, models.StatusFilled, models.StatusPartial),
+					"updated_at":    time.Now().UTC(),
+				}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("FillOrders: %w", err)
+	}
+	return nil
+}
+
+// cancelLiveOrder cancels an open or partially filled order.
+func cancelLiveOrder(db *gorm.DB, id uuid.UUID) error {
+	result := db.Model(&models.Order{}).
 		Where("id = ? AND status IN ?", id, []models.OrderStatus{
 			models.StatusOpen,
 			models.StatusPartial,
@@ -80,10 +216,10 @@ func (r *Repository) CancelOrder(id uuid.UUID) error {
 			"updated_at": time.Now().UTC(),
 		})
 	if result.Error != nil {
-		return fmt.Errorf("CancelOrder: %w", result.Error)
+		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return fmt.Errorf("CancelOrder: order not found or already terminal")
+		return fmt.Errorf("order not found or already terminal")
 	}
 	return nil
 }
@@ -131,6 +267,47 @@ func (r *Repository) GetTradesByUserID(userID uuid.UUID, limit int) ([]models.Tr
 	return trades, nil
 }
 
+// ListTradesByUserID returns up to filter.Limit trades the user was on
+// either side of, newest first, ordered by (executed_at, id).
+func (r *Repository) ListTradesByUserID(userID uuid.UUID, filter models.TradeFilter) ([]models.Trade, error) {
+	q := r.db.Where("(buy_user_id = ? OR sell_user_id = ?)", userID, userID)
+	if filter.Symbol != "" {
+		q = q.Where("symbol = ?", filter.Symbol)
+	}
+
+	var trades []models.Trade
+	if err := page(q, "executed_at", filter.PageQuery).Find(&trades).Error; err != nil {
+		return nil, fmt.Errorf("ListTradesByUserID: %w", err)
+	}
+	return trades, nil
+}
+
+// GetTradesByOrderID returns every fill of an order, oldest first.
+func (r *Repository) GetTradesByOrderID(orderID uuid.UUID) ([]models.Trade, error) {
+	var trades []models.Trade
+	err := r.db.Where("buy_order_id = ? OR sell_order_id = ?", orderID, orderID).
+		Order("executed_at ASC").
+		Find(&trades).Error
+	if err != nil {
+		return nil, fmt.Errorf("GetTradesByOrderID: %w", err)
+	}
+	return trades, nil
+}
+
+// GetTradesSince returns every symbol's trades executed at or after
+// since, oldest first. The ticker replays these to rebuild its
+// 24h windows on startup.
+func (r *Repository) GetTradesSince(since time.Time) ([]models.Trade, error) {
+	var trades []models.Trade
+	err := r.db.Where("executed_at >= ?", since).
+		Order("executed_at ASC").
+		Find(&trades).Error
+	if err != nil {
+		return nil, fmt.Errorf("GetTradesSince: %w", err)
+	}
+	return trades, nil
+}
+
 // ─── OHLCV Repository ────────────────────────────────────────────────────────
 
 func (r *Repository) UpsertOHLCV(bar *models.OHLCV) error {
@@ -155,26 +332,38 @@ func (r *Repository) UpsertOHLCV(bar *models.OHLCV) error {
 }
 
 func (r *Repository) GetOHLCV(symbol, interval string, limit int) ([]models.OHLCV, error) {
+	return r.GetOHLCVRange(symbol, interval, time.Time{}, time.Time{}, limit)
+}
+
+// GetOHLCVRange returns up to limit candles of the given interval whose
+// stored bars fall in [from, to), newest first. Zero times are unbounded.
+func (r *Repository) GetOHLCVRange(symbol, interval string, from, to time.Time, limit int) ([]models.OHLCV, error) {
 	// TimescaleDB time_bucket groups trades into candles of any interval
 	// e.g. interval = '1 minute', '5 minutes', '1 hour'
-	var bars []models.OHLCV
-	err := r.db.Raw(`
-		SELECT
-			time_bucket(?, time) AS time,
+	q := r.db.Table("ohlcvs").
+		Select(`time_bucket(?::interval, time) AS time,
 			symbol,
 			first(open, time) AS open,
 			max(high) AS high,
 			min(low) AS low,
 			last(close, time) AS close,
-			sum(volume) AS volume
-		FROM ohlcvs
-		WHERE symbol = ?
-		GROUP BY time_bucket(?, time), symbol
-		ORDER BY time DESC
-		LIMIT ?
-	`, interval, symbol, interval, limit).Scan(&bars).Error
+			sum(volume) AS volume`, interval).
+		Where("symbol = ?", symbol)
+	if !from.IsZero() {
+		q = q.Where("time >= ?", from)
+	}
+	if !to.IsZero() {
+		q = q.Where("time < ?", to)
+	}
+
+	// GORM writes LIMIT 0 for zero and drops the clause for negatives.
+	if limit <= 0 {
+		limit = -1
+	}
+	var bars []models.OHLCV
+	err := q.Group("1, symbol").Order("time DESC").Limit(limit).Scan(&bars).Error
 	if err != nil {
-		return nil, fmt.Errorf("GetOHLCV:%w", err)
+		return nil, fmt.Errorf("GetOHLCVRange: %w", err)
 	}
 	return bars, nil
 }
@@ -203,3 +392,335 @@ func (r *Repository) GetUserByID(id uuid.UUID) (*models.User, error) {
 	}
 	return &user, nil
 }
+
+// SearchUsers lists users newest first, for the admin API. A zero
+// Limit returns every match.
+func (r *Repository) SearchUsers(filter models.UserFilter) ([]*models.User, error) {
+	q := r.db.Model(&models.User{})
+	if filter.Email != "" {
+		q = q.Where("LOWER(email) LIKE ?", "%"+strings.ToLower(filter.Email)+"%")
+	}
+	if filter.Role != "" {
+		q = q.Where("role = ?", filter.Role)
+	}
+	if filter.Frozen != nil {
+		if *filter.Frozen {
+			q = q.Where("frozen_at IS NOT NULL")
+		} else {
+			q = q.Where("frozen_at IS NULL")
+		}
+	}
+
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+
+	var users []*models.User
+	if err := q.Order("created_at DESC").Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("SearchUsers: %w", err)
+	}
+	return users, nil
+}
+
+func (r *Repository) SetUserRole(id uuid.UUID, role models.Role) error {
+	return r.updateUser("SetUserRole", id, map[string]any{"role": role})
+}
+
+// SetUserFrozen freezes a user, or unfreezes them when frozenAt is nil.
+func (r *Repository) SetUserFrozen(id uuid.UUID, frozenAt *time.Time, reason string) error {
+	return r.updateUser("SetUserFrozen", id, map[string]any{
+		"frozen_at":     frozenAt,
+		"frozen_reason": reason,
+	})
+}
+
+func (r *Repository) updateUser(op string, id uuid.UUID, fields map[string]any) error {
+	fields["updated_at"] = time.Now().UTC()
+	result := r.db.Model(&models.User{}).Where("id = ?", id).Updates(fields)
+	if result.Error != nil {
+		return fmt.Errorf("%s: %w", op, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%s: user not found", op)
+	}
+	return nil
+}
+
+// ─── Instrument Repository ────────────────────────────────────────────────────
+
+func (r *Repository) GetInstrument(symbol string) (*models.Instrument, error) {
+	var insts []models.Instrument
+	if err := r.db.Where("symbol = ?", symbol).Limit(1).Find(&insts).Error; err != nil {
+		return nil, fmt.Errorf("GetInstrument: %w", err)
+	}
+	if len(insts) == 0 {
+		return nil, nil
+	}
+	return &insts[0], nil
+}
+
+func (r *Repository) ListInstruments() ([]models.Instrument, error) {
+	var insts []models.Instrument
+	if err := r.db.Order("symbol ASC").Find(&insts).Error; err != nil {
+		return nil, fmt.Errorf("ListInstruments: %w", err)
+	}
+	return insts, nil
+}
+
+func (r *Repository) UpsertInstrument(inst *models.Instrument) error {
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "symbol"}},
+		DoUpdates: clause.AssignmentColumns([]string{"base", "quote", "status", "updated_at"}),
+	}).Create(inst).Error
+	if err != nil {
+		return fmt.Errorf("UpsertInstrument: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) SeedInstruments(insts []models.Instrument) error {
+	if len(insts) == 0 {
+		return nil
+	}
+	err := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(&insts).Error
+	if err != nil {
+		return fmt.Errorf("SeedInstruments: %w", err)
+	}
+	return nil
+}
+
+// ─── Audit Repository ─────────────────────────────────────────────────────────
+
+func (r *Repository) CreateAuditLog(entry *models.AuditLog) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		return fmt.Errorf("CreateAuditLog: %w", err)
+	}
+	return nil
+}
+
+// ListAuditLogs returns the most recent entries, newest first.
+func (r *Repository) ListAuditLogs(limit int) ([]models.AuditLog, error) {
+	var entries []models.AuditLog
+	if err := r.db.Order("created_at DESC").Limit(limit).Find(&entries).Error; err != nil {
+		return nil, fmt.Errorf("ListAuditLogs: %w", err)
+	}
+	return entries, nil
+}
+
+// ─── API Key Repository ───────────────────────────────────────────────────────
+
+func (r *Repository) CreateAPIKey(key *models.APIKey) error {
+	if err := r.db.Create(key).Error; err != nil {
+		return fmt.Errorf("CreateAPIKey: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetAPIKeyByID(id uuid.UUID) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.First(&key, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("GetAPIKeyByID: %w", err)
+	}
+	return &key, nil
+}
+
+func (r *Repository) GetAPIKeyByKey(key string) (*models.APIKey, error) {
+	var k models.APIKey
+	if err := r.db.First(&k, "key = ?", key).Error; err != nil {
+		return nil, fmt.Errorf("GetAPIKeyByKey: %w", err)
+	}
+	return &k, nil
+}
+
+// ListAPIKeysByUserID returns a user's keys newest first, revoked
+// ones included so the user can see what was revoked and when.
+func (r *Repository) ListAPIKeysByUserID(userID uuid.UUID) ([]*models.APIKey, error) {
+	var keys []*models.APIKey
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Find(&keys).Error
+	if err != nil {
+		return nil, fmt.Errorf("ListAPIKeysByUserID: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *Repository) RevokeAPIKey(id uuid.UUID) error {
+	result := r.db.Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now().UTC())
+	if result.Error != nil {
+		return fmt.Errorf("RevokeAPIKey: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("RevokeAPIKey: key not found or already revoked")
+	}
+	return nil
+}
+
+// ─── Session Repository ───────────────────────────────────────────────────────
+
+func (r *Repository) CreateSession(session *models.Session) error {
+	if err := r.db.Create(session).Error; err != nil {
+		return fmt.Errorf("CreateSession: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) GetSession(id uuid.UUID) (*models.Session, error) {
+	var session models.Session
+	if err := r.db.First(&session, "id = ?", id).Error; err != nil {
+		return nil, fmt.Errorf("GetSession: %w", err)
+	}
+	return &session, nil
+}
+
+func (r *Repository) RotateSession(id uuid.UUID, oldHash, newHash, ip string, usedAt time.Time) (bool, error) {
+	result := r.db.Model(&models.Session{}).
+		Where("id = ? AND refresh_hash = ? AND revoked_at IS NULL", id, oldHash).
+		Updates(map[string]any{
+			"refresh_hash": newHash,
+			"generation":   gorm.Expr("generation + 1"),
+			"ip":           ip,
+			"last_used_at": usedAt,
+		})
+	if result.Error != nil {
+		return false, fmt.Errorf("RotateSession: %w", result.Error)
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *Repository) ListActiveSessions(userID uuid.UUID, now time.Time) ([]*models.Session, error) {
+	var sessions []*models.Session
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_used_at DESC").
+		Find(&sessions).Error
+	if err != nil {
+		return nil, fmt.Errorf("ListActiveSessions: %w", err)
+	}
+	return sessions, nil
+}
+
+func (r *Repository) RevokeSession(id uuid.UUID, reason string) error {
+	err := r.db.Model(&models.Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]any{"revoked_at": time.Now().UTC(), "revoked_reason": reason}).Error
+	if err != nil {
+		return fmt.Errorf("RevokeSession: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) RevokeUserSessions(userID uuid.UUID, reason string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&models.Session{}).
+			Where("user_id = ? AND revoked_at IS NULL", userID).
+			Pluck("id", &ids).Error; err != nil {
+			return err
+		}
+		if len(ids) == 0 {
+			return nil
+		}
+		return tx.Model(&models.Session{}).
+			Where("id IN ?", ids).
+			Updates(map[string]any{"revoked_at": time.Now().UTC(), "revoked_reason": reason}).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("RevokeUserSessions: %w", err)
+	}
+	return ids, nil
+}
+
+func (r *Repository) ListSessionsRevokedSince(since time.Time) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	if err := r.db.Model(&models.Session{}).
+		Where("revoked_at > ?", since).
+		Pluck("id", &ids).Error; err != nil {
+		return nil, fmt.Errorf("ListSessionsRevokedSince: %w", err)
+	}
+	return ids, nil
+}
+
+// ─── Outbox Repository ────────────────────────────────────────────────────────
+
+func (r *Repository) SaveOrderWithEvent(order *models.Order, event *models.OutboxEvent) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(order).Error; err != nil {
+			return err
+		}
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return fmt.Errorf("SaveOrderWithEvent: %w", err)
+	}
+	return nil
+}
+
+// CancelOrderWithEvent is CancelOrder that also saves the cancel's
+// event.
+func (r *Repository) CancelOrderWithEvent(id uuid.UUID, event *models.OutboxEvent) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := cancelLiveOrder(tx, id); err != nil {
+			return err
+		}
+		return tx.Create(event).Error
+	})
+	if err != nil {
+		return fmt.Errorf("CancelOrderWithEvent: %w", err)
+	}
+	return nil
+}
+
+// ReplaceOrderWithEvents is ReplaceOrder that also saves the events,
+// in the order given.
+func (r *Repository) ReplaceOrderWithEvents(oldID uuid.UUID, replacement *models.Order, events []*models.OutboxEvent) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := cancelLiveOrder(tx, oldID); err != nil {
+			return err
+		}
+		if err := tx.Create(replacement).Error; err != nil {
+			return err
+		}
+		for _, event := range events {
+			if err := tx.Create(event).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("ReplaceOrderWithEvents: %w", err)
+	}
+	return nil
+}
+
+// UnsentEvents reads without locking, so a relay holds no transaction
+// while it publishes. Relays on several gateways may pick up the same
+// rows; the engine drops the repeats.
+func (r *Repository) UnsentEvents(limit int) ([]models.OutboxEvent, error) {
+	var events []models.OutboxEvent
+	err := r.db.Where("sent_at IS NULL").Order("id ASC").Limit(limit).Find(&events).Error
+	if err != nil {
+		return nil, fmt.Errorf("UnsentEvents: %w", err)
+	}
+	return events, nil
+}
+
+func (r *Repository) MarkEventsSent(ids []int64) error {
+	err := r.db.Model(&models.OutboxEvent{}).
+		Where("id IN ? AND sent_at IS NULL", ids).
+		Update("sent_at", time.Now().UTC()).Error
+	if err != nil {
+		return fmt.Errorf("MarkEventsSent: %w", err)
+	}
+	return nil
+}
+
+func (r *Repository) DeleteSentEvents(before time.Time) (int64, error) {
+	result := r.db.Where("sent_at < ?", before).Delete(&models.OutboxEvent{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("DeleteSentEvents: %w", result.Error)
+	}
+	return result.RowsAffected, nil
+}
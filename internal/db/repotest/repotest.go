@@ -0,0 +1,820 @@
+// Package repotest is a conformance suite for the repository ports.
+// Every backend runs the same cases, so Postgres and SQLite can't drift
+// apart in ordering, filtering or candle bucketing.
+package repotest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+// Repository is everything a backend has to implement.
+type Repository interface {
+	ports.OrderRepository
+	ports.TradeRepository
+	ports.OHLCVRepository
+	ports.UserRepository
+	ports.APIKeyRepository
+	ports.InstrumentRepository
+	ports.AuditRepository
+	ports.SessionRepository
+	ports.OutboxRepository
+}
+
+// Run runs the suite. newRepo must return an empty repository each call.
+func Run(t *testing.T, newRepo func(t *testing.T) Repository) {
+	cases := []struct {
+		name string
+		fn   func(t *testing.T, repo Repository)
+	}{
+		{"OrderRoundTrip", testOrderRoundTrip},
+		{"OpenOrdersBySymbol", testOpenOrdersBySymbol},
+		{"OrdersByUserID", testOrdersByUserID},
+		{"ListOrdersByUserID", testListOrdersByUserID},
+		{"CancelOrder", testCancelOrder},
+		{"FillOrders", testFillOrders},
+		{"ClientOrderIDs", testClientOrderIDs},
+		{"TradesBySymbol", testTradesBySymbol},
+		{"TradesByUserID", testTradesByUserID},
+		{"TradesByOrderID", testTradesByOrderID},
+		{"TradesSince", testTradesSince},
+		{"UpsertOHLCVMerges", testUpsertOHLCVMerges},
+		{"OHLCVBucketing", testOHLCVBucketing},
+		{"Users", testUsers},
+		{"UserAdmin", testUserAdmin},
+		{"APIKeys", testAPIKeys},
+		{"Instruments", testInstruments},
+		{"AuditLog", testAuditLog},
+		{"Sessions", testSessions},
+		{"Outbox", testOutbox},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			c.fn(t, newRepo(t))
+		})
+	}
+}
+
+// base is a fixed, minute-aligned time. Databases store timestamps at
+// microsecond precision or coarser, so tests never use time.Now directly.
+var base = time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+
+func newOrder(userID uuid.UUID, symbol string, status models.OrderStatus, createdAt time.Time) *models.Order {
+	return &models.Order{
+		ID:           uuid.New(),
+		UserID:       userID,
+		Symbol:       symbol,
+		Side:         models.Buy,
+		Type:         models.Limit,
+		Price:        100,
+		Quantity:     2,
+		RemainingQty: 2,
+		Status:       status,
+		CreatedAt:    createdAt,
+		UpdatedAt:    createdAt,
+	}
+}
+
+func newTrade(symbol string, buyer, seller uuid.UUID, executedAt time.Time) models.Trade {
+	return models.Trade{
+		ID:          uuid.New(),
+		Symbol:      symbol,
+		BuyOrderID:  uuid.New(),
+		SellOrderID: uuid.New(),
+		BuyUserID:   buyer,
+		SellUserID:  seller,
+		Price:       100,
+		Quantity:    1,
+		ExecutedAt:  executedAt,
+	}
+}
+
+func mustSave(t *testing.T, repo Repository, orders ...*models.Order) {
+	t.Helper()
+	for _, o := range orders {
+		if err := repo.SaveOrder(o); err != nil {
+			t.Fatalf("SaveOrder: %v", err)
+		}
+	}
+}
+
+// ─── Orders ───────────────────────────────────────────────────────────────────
+
+func testOrderRoundTrip(t *testing.T, repo Repository) {
+	order := newOrder(uuid.New(), "BTC-USD", models.StatusOpen, base)
+	order.ClientOrderID = "bot-1"
+	mustSave(t, repo, order)
+
+	// As the engine sees it: a decoded copy that may lack gateway-owned
+	// columns, which must survive the update.
+	update := *order
+	update.ClientOrderID = ""
+	update.FilledQty, update.RemainingQty, update.Status = 2, 0, models.StatusFilled
+	if err := repo.UpdateOrder(&update); err != nil {
+		t.Fatalf("UpdateOrder: %v", err)
+	}
+
+	got, err := repo.GetOrderByID(order.ID)
+	if err != nil {
+		t.Fatalf("GetOrderByID: %v", err)
+	}
+	if got.Status != models.StatusFilled || got.FilledQty != 2 || got.RemainingQty != 0 {
+		t.Errorf("update not persisted: %+v", got)
+	}
+	if !got.CreatedAt.Equal(order.CreatedAt) {
+		t.Errorf("created_at changed: %v != %v", got.CreatedAt, order.CreatedAt)
+	}
+	if got.ClientOrderID != order.ClientOrderID {
+		t.Errorf("client_order_id overwritten: %q", got.ClientOrderID)
+	}
+
+	if _, err := repo.GetOrderByID(uuid.New()); err == nil {
+		t.Error("expected an error for a missing order")
+	}
+}
+
+func testOpenOrdersBySymbol(t *testing.T, repo Repository) {
+	user := uuid.New()
+	second := newOrder(user, "BTC-USD", models.StatusPartial, base.Add(time.Second))
+	first := newOrder(user, "BTC-USD", models.StatusOpen, base)
+	mustSave(t, repo,
+		second,
+		first,
+		newOrder(user, "BTC-USD", models.StatusFilled, base),
+		newOrder(user, "BTC-USD", models.StatusCancelled, base),
+		newOrder(user, "ETH-USD", models.StatusOpen, base),
+	)
+
+	got, err := repo.GetOpenOrdersBySymbol("BTC-USD")
+	if err != nil {
+		t.Fatalf("GetOpenOrdersBySymbol: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 open orders, got %d", len(got))
+	}
+	if got[0].ID != first.ID || got[1].ID != second.ID {
+		t.Error("expected open orders oldest first")
+	}
+
+	all, err := repo.GetOpenOrders()
+	if err != nil {
+		t.Fatalf("GetOpenOrders: %v", err)
+	}
+	if len(all) != 3 || all[len(all)-1].ID != second.ID {
+		t.Errorf("expected 3 open orders across symbols, oldest first, got %d", len(all))
+	}
+}
+
+func testOrdersByUserID(t *testing.T, repo Repository) {
+	user := uuid.New()
+	older := newOrder(user, "BTC-USD", models.StatusOpen, base)
+	newer := newOrder(user, "ETH-USD", models.StatusFilled, base.Add(time.Minute))
+	mustSave(t, repo, older, newer, newOrder(uuid.New(), "BTC-USD", models.StatusOpen, base))
+
+	got, err := repo.GetOrdersByUserID(user)
+	if err != nil {
+		t.Fatalf("GetOrdersByUserID: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 orders, got %d", len(got))
+	}
+	if got[0].ID != newer.ID || got[1].ID != older.ID {
+		t.Error("expected orders newest first")
+	}
+}
+
+func testListOrdersByUserID(t *testing.T, repo Repository) {
+	user := uuid.New()
+
+	// Five orders share a timestamp so pages have to split a tie on id.
+	var all []*models.Order
+	for i := 0; i < 5; i++ {
+		all = append(all, newOrder(user, "BTC-USD", models.StatusOpen, base))
+	}
+	sell := newOrder(user, "ETH-USD", models.StatusFilled, base.Add(time.Minute))
+	sell.Side = models.Sell
+	all = append(all, sell, newOrder(user, "BTC-USD", models.StatusCancelled, base.Add(-time.Minute)))
+	mustSave(t, repo, all...)
+	mustSave(t, repo, newOrder(uuid.New(), "BTC-USD", models.StatusOpen, base))
+
+	// Walk every page of two and check nothing is skipped or repeated.
+	seen := map[uuid.UUID]bool{}
+	var last *models.Order
+	filter := models.OrderFilter{PageQuery: models.PageQuery{Limit: 2}}
+	for {
+		page, err := repo.ListOrdersByUserID(user, filter)
+		if err != nil {
+			t.Fatalf("ListOrdersByUserID: %v", err)
+		}
+		if len(page) == 0 {
+			break
+		}
+		for _, o := range page {
+			if seen[o.ID] {
+				t.Fatalf("order %s returned twice", o.ID)
+			}
+			seen[o.ID] = true
+			if last != nil && o.CreatedAt.After(last.CreatedAt) {
+				t.Fatal("expected orders newest first")
+			}
+			last = o
+		}
+		filter.After = &models.Cursor{Time: last.CreatedAt, ID: last.ID}
+	}
+	if len(seen) != len(all) {
+		t.Fatalf("expected %d orders across pages, got %d", len(all), len(seen))
+	}
+
+	side := models.Sell
+	filtered := []struct {
+		name   string
+		filter models.OrderFilter
+		want   int
+	}{
+		{"status", models.OrderFilter{Statuses: []models.OrderStatus{models.StatusOpen, models.StatusFilled}}, 6},
+		{"symbol", models.OrderFilter{Symbol: "ETH-USD"}, 1},
+		{"side", models.OrderFilter{Side: &side}, 1},
+		{"range", models.OrderFilter{PageQuery: models.PageQuery{From: base, To: base.Add(time.Minute)}}, 5},
+	}
+	for _, f := range filtered {
+		f.filter.Limit = 100
+		got, err := repo.ListOrdersByUserID(user, f.filter)
+		if err != nil {
+			t.Fatalf("%s: %v", f.name, err)
+		}
+		if len(got) != f.want {
+			t.Errorf("%s: expected %d orders, got %d", f.name, f.want, len(got))
+		}
+	}
+}
+
+func testCancelOrder(t *testing.T, repo Repository) {
+	open := newOrder(uuid.New(), "BTC-USD", models.StatusOpen, base)
+	filled := newOrder(uuid.New(), "BTC-USD", models.StatusFilled, base)
+	mustSave(t, repo, open, filled)
+
+	if err := repo.CancelOrder(open.ID); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	got, _ := repo.GetOrderByID(open.ID)
+	if got.Status != models.StatusCancelled {
+		t.Errorf("expected cancelled, got %s", got.Status)
+	}
+
+	if err := repo.CancelOrder(open.ID); err == nil {
+		t.Error("expected an error cancelling an already cancelled order")
+	}
+	if err := repo.CancelOrder(filled.ID); err == nil {
+		t.Error("expected an error cancelling a filled order")
+	}
+}
+
+func testFillOrders(t *testing.T, repo Repository) {
+	partial := newOrder(uuid.New(), "BTC-USD", models.StatusOpen, base)
+	full := newOrder(uuid.New(), "BTC-USD", models.StatusOpen, base)
+	cancelled := newOrder(uuid.New(), "BTC-USD", models.StatusCancelled, base)
+	mustSave(t, repo, partial, full, cancelled)
+
+	err := repo.FillOrders([]models.OrderFill{
+		{OrderID: partial.ID, Quantity: 0.5},
+		{OrderID: full.ID, Quantity: 2},
+		{OrderID: cancelled.ID, Quantity: 1},
+	})
+	if err != nil {
+		t.Fatalf("FillOrders: %v", err)
+	}
+	// A second fill adds to the stored quantities.
+	if err := repo.FillOrders([]models.OrderFill{{OrderID: partial.ID, Quantity: 0.5}}); err != nil {
+		t.Fatalf("FillOrders: %v", err)
+	}
+
+	for _, tc := range []struct {
+		order             *models.Order
+		status            models.OrderStatus
+		filled, remaining float64
+	}{
+		{partial, models.StatusPartial, 1, 1},
+		{full, models.StatusFilled, 2, 0},
+		{cancelled, models.StatusCancelled, 0, 2},
+	} {
+		got, err := repo.GetOrderByID(tc.order.ID)
+		if err != nil {
+			t.Fatalf("GetOrderByID: %v", err)
+		}
+		if got.Status != tc.status || got.FilledQty != tc.filled || got.RemainingQty != tc.remaining {
+			t.Errorf("expected %s %g/%g, got %s %g/%g",
+				tc.status, tc.filled, tc.remaining, got.Status, got.FilledQty, got.RemainingQty)
+		}
+	}
+}
+
+func testClientOrderIDs(t *testing.T, repo Repository) {
+	alice, bob := uuid.New(), uuid.New()
+	first := newOrder(alice, "BTC-USD", models.StatusOpen, base)
+	first.ClientOrderID = "mm-1"
+	theirs := newOrder(bob, "BTC-USD", models.StatusOpen, base)
+	theirs.ClientOrderID = "mm-1"
+	// Orders without a client order ID never collide.
+	mustSave(t, repo, first, theirs,
+		newOrder(alice, "BTC-USD", models.StatusOpen, base),
+		newOrder(alice, "BTC-USD", models.StatusOpen, base))
+
+	got, err := repo.GetOrderByClientOrderID(alice, "mm-1")
+	if err != nil || got == nil || got.ID != first.ID {
+		t.Fatalf("GetOrderByClientOrderID: got %+v (%v), want %s", got, err, first.ID)
+	}
+	if got, err := repo.GetOrderByClientOrderID(alice, "mm-2"); got != nil || err != nil {
+		t.Errorf("unused id: got %+v (%v), want nil, nil", got, err)
+	}
+
+	again := newOrder(alice, "ETH-USD", models.StatusOpen, base)
+	again.ClientOrderID = "mm-1"
+	if err := repo.SaveOrder(again); err == nil {
+		t.Error("expected the unique index to refuse a reused client order id")
+	}
+}
+
+// ─── Trades ───────────────────────────────────────────────────────────────────
+
+func testTradesBySymbol(t *testing.T, repo Repository) {
+	a, b := uuid.New(), uuid.New()
+	var trades []models.Trade
+	for i := 0; i < 3; i++ {
+		trades = append(trades, newTrade("BTC-USD", a, b, base.Add(time.Duration(i)*time.Second)))
+	}
+	if err := repo.SaveTrades(trades); err != nil {
+		t.Fatalf("SaveTrades: %v", err)
+	}
+	other := newTrade("ETH-USD", a, b, base)
+	if err := repo.SaveTrade(&other); err != nil {
+		t.Fatalf("SaveTrade: %v", err)
+	}
+
+	got, err := repo.GetTradesBySymbol("BTC-USD", 2)
+	if err != nil {
+		t.Fatalf("GetTradesBySymbol: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected limit of 2 trades, got %d", len(got))
+	}
+	if got[0].ID != trades[2].ID || got[1].ID != trades[1].ID {
+		t.Error("expected trades newest first")
+	}
+}
+
+func testTradesByUserID(t *testing.T, repo Repository) {
+	user := uuid.New()
+	asBuyer := newTrade("BTC-USD", user, uuid.New(), base)
+	asSeller := newTrade("BTC-USD", uuid.New(), user, base.Add(time.Second))
+	unrelated := newTrade("BTC-USD", uuid.New(), uuid.New(), base)
+	if err := repo.SaveTrades([]models.Trade{asBuyer, asSeller, unrelated}); err != nil {
+		t.Fatalf("SaveTrades: %v", err)
+	}
+
+	got, err := repo.GetTradesByUserID(user, 10)
+	if err != nil {
+		t.Fatalf("GetTradesByUserID: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != asSeller.ID || got[1].ID != asBuyer.ID {
+		t.Errorf("expected both sides of the user's trades newest first, got %d trades", len(got))
+	}
+
+	// Same two trades through the paginated query, one per page.
+	eth := newTrade("ETH-USD", user, uuid.New(), base.Add(-time.Second))
+	if err := repo.SaveTrades([]models.Trade{eth}); err != nil {
+		t.Fatalf("SaveTrades: %v", err)
+	}
+	filter := models.TradeFilter{Symbol: "BTC-USD", PageQuery: models.PageQuery{Limit: 1}}
+	first, err := repo.ListTradesByUserID(user, filter)
+	if err != nil {
+		t.Fatalf("ListTradesByUserID: %v", err)
+	}
+	if len(first) != 1 || first[0].ID != asSeller.ID {
+		t.Fatalf("expected the newest BTC-USD trade first, got %d trades", len(first))
+	}
+	filter.After = &models.Cursor{Time: first[0].ExecutedAt, ID: first[0].ID}
+	filter.Limit = 10
+	rest, err := repo.ListTradesByUserID(user, filter)
+	if err != nil {
+		t.Fatalf("ListTradesByUserID: %v", err)
+	}
+	if len(rest) != 1 || rest[0].ID != asBuyer.ID {
+		t.Errorf("expected only the older BTC-USD trade on the next page, got %d trades", len(rest))
+	}
+}
+
+func testTradesByOrderID(t *testing.T, repo Repository) {
+	// One sell order filled twice, by two different buy orders.
+	first := newTrade("BTC-USD", uuid.New(), uuid.New(), base)
+	sell := models.Sell
+	first.TakerSide = &sell
+	second := newTrade("BTC-USD", uuid.New(), uuid.New(), base.Add(time.Second))
+	second.SellOrderID = first.SellOrderID
+	if err := repo.SaveTrades([]models.Trade{second, first, newTrade("BTC-USD", uuid.New(), uuid.New(), base)}); err != nil {
+		t.Fatalf("SaveTrades: %v", err)
+	}
+
+	got, err := repo.GetTradesByOrderID(first.SellOrderID)
+	if err != nil {
+		t.Fatalf("GetTradesByOrderID: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != first.ID || got[1].ID != second.ID {
+		t.Fatalf("expected the order's fills oldest first, got %d trades", len(got))
+	}
+	if got[0].TakerSide == nil || *got[0].TakerSide != models.Sell {
+		t.Errorf("expected taker side to round-trip, got %v", got[0].TakerSide)
+	}
+	// A trade saved without one reads back unknown, not as a buy.
+	if got[1].TakerSide != nil {
+		t.Errorf("expected no taker side, got %s", *got[1].TakerSide)
+	}
+
+	if got, _ := repo.GetTradesByOrderID(second.BuyOrderID); len(got) != 1 || got[0].ID != second.ID {
+		t.Errorf("expected the buy order's single fill, got %d trades", len(got))
+	}
+}
+
+// ─── OHLCV ────────────────────────────────────────────────────────────────────
+
+func upsert(t *testing.T, repo Repository, bars ...models.OHLCV) {
+	t.Helper()
+	for i := range bars {
+		if err := repo.UpsertOHLCV(&bars[i]); err != nil {
+			t.Fatalf("UpsertOHLCV: %v", err)
+		}
+	}
+}
+
+func testTradesSince(t *testing.T, repo Repository) {
+	a, b := uuid.New(), uuid.New()
+	old := newTrade("BTC-USD", a, b, base.Add(-time.Hour))
+	later := newTrade("ETH-USD", a, b, base.Add(time.Minute))
+	first := newTrade("BTC-USD", a, b, base)
+	if err := repo.SaveTrades([]models.Trade{old, later, first}); err != nil {
+		t.Fatalf("SaveTrades: %v", err)
+	}
+
+	got, err := repo.GetTradesSince(base)
+	if err != nil {
+		t.Fatalf("GetTradesSince: %v", err)
+	}
+	if len(got) != 2 || got[0].ID != first.ID || got[1].ID != later.ID {
+		t.Errorf("expected the two trades from base on, oldest first, got %d trades", len(got))
+	}
+}
+
+func testUpsertOHLCVMerges(t *testing.T, repo Repository) {
+	upsert(t, repo,
+		models.OHLCV{Time: base, Symbol: "BTC-USD", Open: 100, High: 105, Low: 99, Close: 101, Volume: 2},
+		models.OHLCV{Time: base, Symbol: "BTC-USD", Open: 101, High: 110, Low: 100, Close: 108, Volume: 3},
+	)
+
+	got, err := repo.GetOHLCV("BTC-USD", "1 minute", 10)
+	if err != nil {
+		t.Fatalf("GetOHLCV: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 merged bar, got %d", len(got))
+	}
+	want := models.OHLCV{Time: base, Symbol: "BTC-USD", Open: 100, High: 110, Low: 99, Close: 108, Volume: 5}
+	assertBar(t, got[0], want)
+}
+
+func testOHLCVBucketing(t *testing.T, repo Repository) {
+	// Seven one-minute bars from 10:00 to 10:06, close = 100 + minute.
+	for i := 0; i < 7; i++ {
+		p := float64(100 + i)
+		upsert(t, repo, models.OHLCV{
+			Time: base.Add(time.Duration(i) * time.Minute), Symbol: "BTC-USD",
+			Open: p, High: p + 1, Low: p - 1, Close: p, Volume: 1,
+		})
+	}
+	upsert(t, repo, models.OHLCV{Time: base, Symbol: "ETH-USD", Open: 1, High: 1, Low: 1, Close: 1, Volume: 1})
+
+	got, err := repo.GetOHLCV("BTC-USD", "5 minutes", 10)
+	if err != nil {
+		t.Fatalf("GetOHLCV: %v", err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 five-minute candles, got %d", len(got))
+	}
+	assertBar(t, got[0], models.OHLCV{
+		Time: base.Add(5 * time.Minute), Symbol: "BTC-USD",
+		Open: 105, High: 107, Low: 104, Close: 106, Volume: 2,
+	})
+	assertBar(t, got[1], models.OHLCV{
+		Time: base, Symbol: "BTC-USD",
+		Open: 100, High: 105, Low: 99, Close: 104, Volume: 5,
+	})
+
+	got, err = repo.GetOHLCV("BTC-USD", "1 minute", 3)
+	if err != nil {
+		t.Fatalf("GetOHLCV: %v", err)
+	}
+	if len(got) != 3 || !got[0].Time.Equal(base.Add(6*time.Minute)) {
+		t.Errorf("expected the 3 newest one-minute bars, got %d", len(got))
+	}
+	for _, limit := range []int{0, -1} {
+		if got, err := repo.GetOHLCV("BTC-USD", "1 minute", limit); err != nil || len(got) != 7 {
+			t.Errorf("limit %d: expected all 7 bars, got %d (%v)", limit, len(got), err)
+		}
+	}
+
+	// Only bars in [10:02, 10:05) count, in whichever bucket they land.
+	got, err = repo.GetOHLCVRange("BTC-USD", "5 minutes", base.Add(2*time.Minute), base.Add(5*time.Minute), 10)
+	if err != nil {
+		t.Fatalf("GetOHLCVRange: %v", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("expected 1 candle in range, got %d", len(got))
+	}
+	assertBar(t, got[0], models.OHLCV{
+		Time: base, Symbol: "BTC-USD",
+		Open: 102, High: 105, Low: 101, Close: 104, Volume: 3,
+	})
+}
+
+func assertBar(t *testing.T, got, want models.OHLCV) {
+	t.Helper()
+	if !got.Time.Equal(want.Time) {
+		t.Errorf("time: got %v, want %v", got.Time, want.Time)
+	}
+	got.Time = want.Time
+	if got != want {
+		t.Errorf("bar mismatch:\n got  %+v\n want %+v", got, want)
+	}
+}
+
+// ─── Users ────────────────────────────────────────────────────────────────────
+
+func testUsers(t *testing.T, repo Repository) {
+	user := &models.User{
+		ID:           uuid.New(),
+		Email:        "trader@example.com",
+		PasswordHash: "hash",
+		CreatedAt:    base,
+		UpdatedAt:    base,
+	}
+	if err := repo.CreateUser(user); err != nil {
+		t.Fatalf("CreateUser: %v", err)
+	}
+
+	byEmail, err := repo.GetUserByEmail(user.Email)
+	if err != nil || byEmail.ID != user.ID {
+		t.Fatalf("GetUserByEmail: %v", err)
+	}
+	byID, err := repo.GetUserByID(user.ID)
+	if err != nil || byID.Email != user.Email {
+		t.Fatalf("GetUserByID: %v", err)
+	}
+
+	dup := *user
+	dup.ID = uuid.New()
+	if err := repo.CreateUser(&dup); err == nil {
+		t.Error("expected duplicate email to be rejected")
+	}
+	if _, err := repo.GetUserByEmail("nobody@example.com"); err == nil {
+		t.Error("expected an error for a missing user")
+	}
+}
+
+func testUserAdmin(t *testing.T, repo Repository) {
+	alice := &models.User{ID: uuid.New(), Email: "Alice@example.com", PasswordHash: "h", Role: models.RoleUser, CreatedAt: base}
+	bob := &models.User{ID: uuid.New(), Email: "bob@example.com", PasswordHash: "h", Role: models.RoleUser, CreatedAt: base.Add(time.Second)}
+	for _, u := range []*models.User{alice, bob} {
+		if err := repo.CreateUser(u); err != nil {
+			t.Fatalf("CreateUser: %v", err)
+		}
+	}
+
+	if err := repo.SetUserRole(bob.ID, models.RoleOperator); err != nil {
+		t.Fatalf("SetUserRole: %v", err)
+	}
+	frozenAt := base.Add(time.Minute)
+	if err := repo.SetUserFrozen(alice.ID, &frozenAt, "chargeback"); err != nil {
+		t.Fatalf("SetUserFrozen: %v", err)
+	}
+	if err := repo.SetUserRole(uuid.New(), models.RoleAdmin); err == nil {
+		t.Error("expected an error for a missing user")
+	}
+
+	frozen := true
+	cases := []struct {
+		name   string
+		filter models.UserFilter
+		want   []uuid.UUID
+	}{
+		{"all, newest first", models.UserFilter{Limit: 10}, []uuid.UUID{bob.ID, alice.ID}},
+		{"email substring, any case", models.UserFilter{Email: "ALICE", Limit: 10}, []uuid.UUID{alice.ID}},
+		{"role", models.UserFilter{Role: models.RoleOperator, Limit: 10}, []uuid.UUID{bob.ID}},
+		{"frozen", models.UserFilter{Frozen: &frozen, Limit: 10}, []uuid.UUID{alice.ID}},
+	}
+	for _, tc := range cases {
+		got, err := repo.SearchUsers(tc.filter)
+		if err != nil {
+			t.Fatalf("%s: SearchUsers: %v", tc.name, err)
+		}
+		if len(got) != len(tc.want) {
+			t.Errorf("%s: expected %d users, got %d", tc.name, len(tc.want), len(got))
+			continue
+		}
+		for i := range got {
+			if got[i].ID != tc.want[i] {
+				t.Errorf("%s: user %d is %s", tc.name, i, got[i].Email)
+			}
+		}
+	}
+
+	if err := repo.SetUserFrozen(alice.ID, nil, ""); err != nil {
+		t.Fatalf("SetUserFrozen: %v", err)
+	}
+	if u, _ := repo.GetUserByID(alice.ID); u.FrozenAt != nil {
+		t.Error("expected unfreezing to clear frozen_at")
+	}
+}
+
+func testAPIKeys(t *testing.T, repo Repository) {
+	user := uuid.New()
+	older := &models.APIKey{ID: uuid.New(), UserID: user, Key: "ome_a", Scopes: "read", SecretEncrypted: []byte{1}, CreatedAt: base}
+	newer := &models.APIKey{ID: uuid.New(), UserID: user, Key: "ome_b", Scopes: "read,trade", SecretEncrypted: []byte{2}, CreatedAt: base.Add(time.Second)}
+	for _, k := range []*models.APIKey{older, newer} {
+		if err := repo.CreateAPIKey(k); err != nil {
+			t.Fatalf("CreateAPIKey: %v", err)
+		}
+	}
+
+	got, err := repo.GetAPIKeyByKey("ome_b")
+	if err != nil || got.ID != newer.ID || string(got.SecretEncrypted) != "\x02" {
+		t.Fatalf("GetAPIKeyByKey: %v", err)
+	}
+	keys, err := repo.ListAPIKeysByUserID(user)
+	if err != nil || len(keys) != 2 || keys[0].ID != newer.ID {
+		t.Fatalf("expected both keys newest first, got %d (%v)", len(keys), err)
+	}
+
+	if err := repo.RevokeAPIKey(older.ID); err != nil {
+		t.Fatalf("RevokeAPIKey: %v", err)
+	}
+	if err := repo.RevokeAPIKey(older.ID); err == nil {
+		t.Error("expected revoking twice to fail")
+	}
+	if got, err := repo.GetAPIKeyByID(older.ID); err != nil || got.RevokedAt == nil {
+		t.Errorf("expected revoked_at to be set, got %v (%v)", got, err)
+	}
+}
+
+func testInstruments(t *testing.T, repo Repository) {
+	if err := repo.SeedInstruments([]models.Instrument{
+		models.NewInstrument("BTC-USD"), models.NewInstrument("AAPL"),
+	}); err != nil {
+		t.Fatalf("SeedInstruments: %v", err)
+	}
+
+	halted := models.NewInstrument("BTC-USD")
+	halted.Status = models.InstrumentHalted
+	if err := repo.UpsertInstrument(&halted); err != nil {
+		t.Fatalf("UpsertInstrument: %v", err)
+	}
+	// Reseeding on the next startup must not undo the halt.
+	if err := repo.SeedInstruments([]models.Instrument{models.NewInstrument("BTC-USD")}); err != nil {
+		t.Fatalf("SeedInstruments: %v", err)
+	}
+
+	got, err := repo.GetInstrument("BTC-USD")
+	if err != nil || got == nil || got.Status != models.InstrumentHalted || got.Quote != "USD" {
+		t.Fatalf("GetInstrument: %+v (%v)", got, err)
+	}
+	if missing, err := repo.GetInstrument("DOGE-USD"); missing != nil || err != nil {
+		t.Errorf("expected nil, nil for an unknown symbol, got %v, %v", missing, err)
+	}
+	all, err := repo.ListInstruments()
+	if err != nil || len(all) != 2 || all[0].Symbol != "AAPL" {
+		t.Errorf("expected 2 instruments by symbol, got %+v (%v)", all, err)
+	}
+}
+
+func testAuditLog(t *testing.T, repo Repository) {
+	entry := &models.AuditLog{ID: uuid.New(), ActorID: uuid.New(), Action: "PUT /api/v1/admin/instruments/:symbol", Target: "instrument:BTC-USD", Status: 200, CreatedAt: base}
+	if err := repo.CreateAuditLog(entry); err != nil {
+		t.Fatalf("CreateAuditLog: %v", err)
+	}
+	if logs, err := repo.ListAuditLogs(10); err != nil || len(logs) != 1 || logs[0].Target != entry.Target {
+		t.Errorf("ListAuditLogs: %+v (%v)", logs, err)
+	}
+}
+
+func testSessions(t *testing.T, repo Repository) {
+	user := uuid.New()
+	laptop := &models.Session{ID: uuid.New(), UserID: user, RefreshHash: "h1", CreatedAt: base, LastUsedAt: base, ExpiresAt: base.Add(time.Hour)}
+	phone := &models.Session{ID: uuid.New(), UserID: user, RefreshHash: "p1", CreatedAt: base, LastUsedAt: base.Add(time.Minute), ExpiresAt: base.Add(time.Hour)}
+	expired := &models.Session{ID: uuid.New(), UserID: user, RefreshHash: "e1", CreatedAt: base, LastUsedAt: base, ExpiresAt: base.Add(time.Second)}
+	for _, s := range []*models.Session{laptop, phone, expired} {
+		if err := repo.CreateSession(s); err != nil {
+			t.Fatalf("CreateSession: %v", err)
+		}
+	}
+
+	now := base.Add(10 * time.Minute)
+	sessions, err := repo.ListActiveSessions(user, now)
+	if err != nil || len(sessions) != 2 || sessions[0].ID != phone.ID {
+		t.Fatalf("expected phone then laptop, got %d (%v)", len(sessions), err)
+	}
+
+	if ok, err := repo.RotateSession(laptop.ID, "h1", "h2", "10.0.0.1", now); err != nil || !ok {
+		t.Fatalf("RotateSession: %v, %v", ok, err)
+	}
+	if ok, _ := repo.RotateSession(laptop.ID, "h1", "h3", "10.0.0.1", now); ok {
+		t.Error("expected rotating from a stale hash to fail")
+	}
+	if got, err := repo.GetSession(laptop.ID); err != nil || got.RefreshHash != "h2" || got.Generation != 1 || got.IP != "10.0.0.1" {
+		t.Fatalf("GetSession: %+v (%v)", got, err)
+	}
+
+	if err := repo.RevokeSession(laptop.ID, "logout"); err != nil {
+		t.Fatalf("RevokeSession: %v", err)
+	}
+	if ok, _ := repo.RotateSession(laptop.ID, "h2", "h3", "", now); ok {
+		t.Error("expected a revoked session not to rotate")
+	}
+
+	ids, err := repo.RevokeUserSessions(user, "revoke all")
+	if err != nil || len(ids) != 2 {
+		t.Fatalf("RevokeUserSessions: %v (%v)", ids, err)
+	}
+	if sessions, _ := repo.ListActiveSessions(user, now); len(sessions) != 0 {
+		t.Errorf("expected no active sessions, got %d", len(sessions))
+	}
+	if ids, err := repo.ListSessionsRevokedSince(time.Now().Add(-time.Minute)); err != nil || len(ids) != 3 {
+		t.Errorf("ListSessionsRevokedSince: %v (%v)", ids, err)
+	}
+}
+
+// ─── Outbox ───────────────────────────────────────────────────────────────────
+
+func testOutbox(t *testing.T, repo Repository) {
+	first := newOrder(uuid.New(), "BTC-USD", models.StatusOpen, base)
+	second := newOrder(uuid.New(), "ETH-USD", models.StatusOpen, base)
+	event := func(o *models.Order) *models.OutboxEvent {
+		return &models.OutboxEvent{Type: models.OutboxOrderPlaced, Key: o.Symbol, Payload: []byte(o.ID.String()), CreatedAt: base}
+	}
+	for _, o := range []*models.Order{first, second} {
+		if err := repo.SaveOrderWithEvent(o, event(o)); err != nil {
+			t.Fatalf("SaveOrderWithEvent: %v", err)
+		}
+	}
+	// The order insert fails, so its event is rolled back with it.
+	if err := repo.SaveOrderWithEvent(first, event(first)); err == nil {
+		t.Fatal("expected saving the same order twice to fail")
+	}
+
+	events, err := repo.UnsentEvents(10)
+	if err != nil || len(events) != 2 {
+		t.Fatalf("UnsentEvents: %d (%v), want 2", len(events), err)
+	}
+	if string(events[0].Payload) != first.ID.String() || string(events[1].Payload) != second.ID.String() {
+		t.Errorf("expected both events in insert order, got %s, %s", events[0].Payload, events[1].Payload)
+	}
+	if more, _ := repo.UnsentEvents(1); len(more) != 1 || more[0].ID != events[0].ID {
+		t.Errorf("expected the limit to keep the oldest event, got %v", more)
+	}
+
+	if err := repo.MarkEventsSent([]int64{events[0].ID, events[1].ID}); err != nil {
+		t.Fatalf("MarkEventsSent: %v", err)
+	}
+	if events, err := repo.UnsentEvents(10); err != nil || len(events) != 0 {
+		t.Errorf("expected sent events not returned again, got %d (%v)", len(events), err)
+	}
+
+	if deleted, err := repo.DeleteSentEvents(time.Now().Add(time.Hour)); err != nil || deleted != 2 {
+		t.Errorf("DeleteSentEvents: %d (%v), want 2", deleted, err)
+	}
+
+	// A replace saves its events in the order given; a cancel of an
+	// order that is no longer live saves nothing.
+	replacement := newOrder(first.UserID, "BTC-USD", models.StatusOpen, base)
+	cancelEvent := &models.OutboxEvent{Type: models.OutboxOrderCancelRequested, Key: first.Symbol, Payload: []byte(first.ID.String()), CreatedAt: base}
+	if err := repo.ReplaceOrderWithEvents(first.ID, replacement, []*models.OutboxEvent{cancelEvent, event(replacement)}); err != nil {
+		t.Fatalf("ReplaceOrderWithEvents: %v", err)
+	}
+	if err := repo.CancelOrderWithEvent(first.ID, &models.OutboxEvent{Type: models.OutboxOrderCancelRequested, Key: first.Symbol, Payload: []byte(first.ID.String()), CreatedAt: base}); err == nil {
+		t.Error("expected cancelling a cancelled order to fail")
+	}
+	if err := repo.CancelOrderWithEvent(second.ID, &models.OutboxEvent{Type: models.OutboxOrderCancelRequested, Key: second.Symbol, Payload: []byte(second.ID.String()), CreatedAt: base}); err != nil {
+		t.Fatalf("CancelOrderWithEvent: %v", err)
+	}
+	if got, _ := repo.GetOrderByID(second.ID); got == nil || got.Status != models.StatusCancelled {
+		t.Errorf("expected the order cancelled, got %+v", got)
+	}
+	events, err = repo.UnsentEvents(10)
+	if err != nil || len(events) != 3 {
+		t.Fatalf("UnsentEvents: %d (%v), want 3", len(events), err)
+	}
+	if events[0].Type != models.OutboxOrderCancelRequested || events[1].Type != models.OutboxOrderPlaced ||
+		events[2].Type != models.OutboxOrderCancelRequested {
+		t.Errorf("expected cancel, placed, cancel, got %s, %s, %s", events[0].Type, events[1].Type, events[2].Type)
+	}
+}
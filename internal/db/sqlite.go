@@ -0,0 +1,110 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// NewSQLiteConnection opens an embedded SQLite database at path
+// (":memory:" for a throwaway one). Pure Go — no cgo, no server.
+func NewSQLiteConnection(path string) (*gorm.DB, error) {
+	dsn := path + "?_pragma=busy_timeout(5000)&_pragma=journal_mode(WAL)&_pragma=foreign_keys(1)"
+	db, err := gorm.Open(sqlite.Open(dsn), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open sqlite: %w", err)
+	}
+
+	// SQLite allows a single writer. One connection turns concurrent
+	// writes into a queue instead of "database is locked" errors.
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	return db, nil
+}
+
+// SQLiteRepository is Repository on an embedded SQLite database.
+// Orders, trades and users are plain GORM and work unchanged; the
+// candle queries are rewritten without TimescaleDB functions.
+// Implements the same repository ports.
+type SQLiteRepository struct {
+	*Repository
+}
+
+func NewSQLiteRepository(db *gorm.DB) *SQLiteRepository {
+	return &SQLiteRepository{Repository: NewRepository(db)}
+}
+
+// UpsertOHLCV merges a bar into the stored one for the same (time, symbol).
+// SQLite's two-argument max/min stand in for GREATEST/LEAST.
+func (r *SQLiteRepository) UpsertOHLCV(bar *models.OHLCV) error {
+	err := r.db.Exec(`
+		INSERT INTO ohlcvs (time, symbol, open, high, low, close, volume)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (time, symbol) DO UPDATE SET
+			high   = max(ohlcvs.high, excluded.high),
+			low    = min(ohlcvs.low, excluded.low),
+			close  = excluded.close,
+			volume = ohlcvs.volume + excluded.volume
+	`,
+		bar.Time, bar.Symbol,
+		bar.Open, bar.High, bar.Low, bar.Close, bar.Volume,
+	).Error
+	if err != nil {
+		return fmt.Errorf("UpsertOHLCV: %w", err)
+	}
+	return nil
+}
+
+// GetOHLCV returns candles of the given interval, newest first.
+func (r *SQLiteRepository) GetOHLCV(symbol, interval string, limit int) ([]models.OHLCV, error) {
+	return r.GetOHLCVRange(symbol, interval, time.Time{}, time.Time{}, limit)
+}
+
+// GetOHLCVRange returns up to limit candles whose stored bars fall in
+// [from, to), newest first. Stored bars are folded in Go rather than
+// with time_bucket, so any interval ParseInterval understands works
+// without TimescaleDB.
+func (r *SQLiteRepository) GetOHLCVRange(symbol, interval string, from, to time.Time, limit int) ([]models.OHLCV, error) {
+	width, err := ParseInterval(interval)
+	if err != nil {
+		return nil, fmt.Errorf("GetOHLCVRange: %w", err)
+	}
+
+	q := r.db.Model(&models.OHLCV{}).Where("symbol = ?", symbol)
+	if !from.IsZero() {
+		q = q.Where("time >= ?", from)
+	}
+	if !to.IsZero() {
+		q = q.Where("time < ?", to)
+	}
+	rows, err := q.Order("time DESC").Rows()
+	if err != nil {
+		return nil, fmt.Errorf("GetOHLCVRange: %w", err)
+	}
+	defer rows.Close()
+
+	folder := candleFolder{width: width, limit: limit}
+	for rows.Next() {
+		var bar models.OHLCV
+		if err := r.db.ScanRows(rows, &bar); err != nil {
+			return nil, fmt.Errorf("GetOHLCVRange scan: %w", err)
+		}
+		if !folder.add(bar) {
+			break
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("GetOHLCVRange: %w", err)
+	}
+	return folder.candles, nil
+}
@@ -0,0 +1,25 @@
+package db
+
+import (
+	"testing"
+
+	"github.com/Im-Manav/ome/internal/db/repotest"
+)
+
+func TestSQLiteRepository(t *testing.T) {
+	repotest.Run(t, func(t *testing.T) repotest.Repository {
+		database, err := NewSQLiteConnection(":memory:")
+		if err != nil {
+			t.Fatalf("NewSQLiteConnection: %v", err)
+		}
+		if err := Migrate(database); err != nil {
+			t.Fatalf("Migrate: %v", err)
+		}
+		t.Cleanup(func() {
+			if sqlDB, err := database.DB(); err == nil {
+				sqlDB.Close()
+			}
+		})
+		return NewSQLiteRepository(database)
+	})
+}
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"sort"
+	"sync"
 	"time"
 
 	"github.com/Im-Manav/ome/pkg/models"
@@ -9,23 +11,49 @@ import (
 
 // Matcher implements the price-time priority matching algorithm.
 // It holds one OrderBook per symbol and is the only writer to them.
-// IMPORTANT: Match() is not thread-safe by design — the engine runs
-// one goroutine per symbol, so there is never concurrent access.
+//
+// Orders are matched one at a time on the consumer goroutine. The mutex
+// is there so the admin API can read books and flip halts from another
+// goroutine without seeing an order half-way through a match.
 type Matcher struct {
-	books map[string]*OrderBook // symbol -> order book
+	mu     sync.Mutex
+	books  map[string]*OrderBook    // symbol -> order book
+	status map[string]*symbolStatus // symbol -> engine-side bookkeeping
+}
+
+// symbolStatus is what the engine tracks per symbol besides the book.
+type symbolStatus struct {
+	halted    bool
+	sequence  uint64 // orders processed for this symbol, including rejects
+	lastTrade *models.Trade
+}
+
+// SymbolStats is a point-in-time view of one symbol for operators.
+type SymbolStats struct {
+	Symbol        string        `json:"symbol"`
+	Halted        bool          `json:"halted"`
+	RestingOrders int           `json:"resting_orders"`
+	RestingBids   int           `json:"resting_bids"`
+	RestingAsks   int           `json:"resting_asks"`
+	BidLevels     int           `json:"bid_levels"`
+	AskLevels     int           `json:"ask_levels"`
+	LastSequence  uint64        `json:"last_sequence"`
+	LastTrade     *models.Trade `json:"last_trade,omitempty"`
 }
 
 func NewMatcher() *Matcher {
 	return &Matcher{
-		books: make(map[string]*OrderBook),
+		books:  make(map[string]*OrderBook),
+		status: make(map[string]*symbolStatus),
 	}
 }
 
 // getOrCreateBook returns the order book for a symbol,
-// creating one if it doesn't exist yet.
+// creating one if it doesn't exist yet. Caller holds m.mu.
 func (m *Matcher) getOrCreateBook(symbol string) *OrderBook {
 	if _, ok := m.books[symbol]; !ok {
 		m.books[symbol] = NewOrderBook(symbol)
+		m.status[symbol] = &symbolStatus{}
 	}
 	return m.books[symbol]
 }
@@ -42,8 +70,28 @@ func (m *Matcher) getOrCreateBook(symbol string) *OrderBook {
 //  4. Fully filled resting orders are removed from the book
 //  5. Partially filled resting orders stay in the book with updated qty
 //  6. Whatever remains of the incoming order rests in the book
+//
+// Orders for a halted symbol are rejected without touching the book.
 func (m *Matcher) Match(order *models.Order) []models.Trade {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	book := m.getOrCreateBook(order.Symbol)
+	status := m.status[order.Symbol]
+	status.sequence++
+
+	if status.halted {
+		order.Status = models.StatusRejected
+		return nil
+	}
+
+	// An order restored from the database on startup may still be
+	// waiting in Kafka. When it arrives, drop the restored copy and
+	// match it like any new order rather than resting it twice.
+	if _, ok := book.Get(order.ID); ok {
+		book.Cancel(order.ID)
+	}
+
 	var trades []models.Trade
 
 	switch order.Side {
@@ -62,6 +110,11 @@ func (m *Matcher) Match(order *models.Order) []models.Trade {
 			book.Add(order)
 		}
 	}
+
+	if n := len(trades); n > 0 {
+		last := trades[n-1]
+		status.lastTrade = &last
+	}
 	return trades
 }
 
@@ -83,6 +136,8 @@ func (m *Matcher) matchBuy(buy *models.Order, book *OrderBook) []models.Trade {
 		}
 
 		trade := executeTrade(buy, ask)
+		taker := models.Buy
+		trade.TakerSide = &taker
 		trades = append(trades, trade)
 
 		if ask.RemainingQty > 0 {
@@ -109,6 +164,8 @@ func (m *Matcher) matchSell(sell *models.Order, book *OrderBook) []models.Trade
 		}
 
 		trade := executeTrade(bid, sell)
+		taker := models.Sell
+		trade.TakerSide = &taker
 		trades = append(trades, trade)
 
 		if bid.RemainingQty > 0 {
@@ -158,9 +215,145 @@ func applyFill(order *models.Order, qty float64) {
 }
 
 func (m *Matcher) BookFor(symbol string) *OrderBook {
+	m.mu.Lock()
+	defer m.mu.Unlock()
 	return m.getOrCreateBook(symbol)
 }
 
+// Depth returns the aggregated top levels of a symbol's book.
+// Unlike BookFor(symbol).Depth it is safe to call while orders are matching.
+func (m *Matcher) Depth(symbol string, levels int) (bids, asks []models.OrderBookLevel) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.getOrCreateBook(symbol).Depth(levels)
+}
+
+// Restore rests orders in their books without matching them, to
+// rebuild the engine's state from the database on startup. Orders
+// with nothing left to fill, market orders and orders already in a
+// book are skipped. Returns how many orders were restored.
+func (m *Matcher) Restore(orders []*models.Order) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	restored := 0
+	for _, order := range orders {
+		if order.RemainingQty <= 0 || order.Type == models.Market {
+			continue
+		}
+		book := m.getOrCreateBook(order.Symbol)
+		if _, ok := book.Get(order.ID); ok {
+			continue
+		}
+		book.Add(order)
+		restored++
+	}
+	return restored
+}
+
+// Cancel removes a resting order from a symbol's book.
+// Returns false if the order isn't resting (already filled or cancelled).
+func (m *Matcher) Cancel(symbol string, orderID uuid.UUID) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	book, ok := m.books[symbol]
+	if !ok {
+		return false
+	}
+	found, _ := book.Cancel(orderID)
+	return found
+}
+
+// ─── Operator controls ────────────────────────────────────────────────────────
+
+// Halt stops matching for a symbol. Resting orders stay in the book;
+// new orders are rejected until Resume.
+func (m *Matcher) Halt(symbol string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.getOrCreateBook(symbol)
+	m.status[symbol].halted = true
+}
+
+func (m *Matcher) Resume(symbol string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.getOrCreateBook(symbol)
+	m.status[symbol].halted = false
+}
+
+// Symbols returns every symbol the engine has seen, sorted.
+func (m *Matcher) Symbols() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	symbols := make([]string, 0, len(m.books))
+	for symbol := range m.books {
+		symbols = append(symbols, symbol)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+// Stats reports a symbol's book size and engine bookkeeping. It reads
+// the book's counters, so it is cheap enough for every metrics scrape.
+// ok is false for a symbol the engine has never seen.
+func (m *Matcher) Stats(symbol string) (stats SymbolStats, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	book, ok := m.books[symbol]
+	if !ok {
+		return SymbolStats{}, false
+	}
+	status := m.status[symbol]
+	bids, asks, bidLevels, askLevels := book.Counts()
+
+	stats = SymbolStats{
+		Symbol:        symbol,
+		Halted:        status.halted,
+		RestingOrders: bids + asks,
+		RestingBids:   bids,
+		RestingAsks:   asks,
+		BidLevels:     bidLevels,
+		AskLevels:     askLevels,
+		LastSequence:  status.sequence,
+	}
+	if status.lastTrade != nil {
+		last := *status.lastTrade
+		stats.LastTrade = &last
+	}
+	return stats, true
+}
+
+// Book returns copies of every resting order in a symbol's book (L3),
+// each side in priority order. ok is false for an unknown symbol.
+func (m *Matcher) Book(symbol string) (bids, asks []models.Order, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	book, ok := m.books[symbol]
+	if !ok {
+		return nil, nil, false
+	}
+	bids, asks = book.Orders()
+	return bids, asks, true
+}
+
+// Order returns a copy of a resting order's live state.
+// ok is false once the order is filled, cancelled or was never resting.
+func (m *Matcher) Order(id uuid.UUID) (order models.Order, ok bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, book := range m.books {
+		if o, ok := book.Get(id); ok {
+			return o, true
+		}
+	}
+	return models.Order{}, false
+}
+
 func min(a, b float64) float64 {
 	if a < b {
 		return a
@@ -0,0 +1,175 @@
+package engine
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/models"
+)
+
+// Benchmarks build their books before b.ResetTimer, so only the
+// operation under test is measured. Compare runs with benchstat:
+//
+//	go test -run '^$' -bench . -benchmem -count 10 ./internal/engine/ > new.txt
+
+// restingBook fills a book with depth orders per side spread over
+// levels price levels, bids below 100 and asks above.
+func restingBook(m *Matcher, symbol string, depth, levels int) {
+	for i := 0; i < depth; i++ {
+		offset := float64(i%levels + 1)
+		bid := newOrder(models.Buy, models.Limit, 100-offset, 1)
+		bid.Symbol = symbol
+		m.Match(bid)
+
+		ask := newOrder(models.Sell, models.Limit, 100+offset, 1)
+		ask.Symbol = symbol
+		m.Match(ask)
+	}
+}
+
+// orders pre-builds n orders so allocation of the order itself isn't measured.
+func orders(n int, side models.Side, orderType models.OrderType, price, qty float64) []*models.Order {
+	out := make([]*models.Order, n)
+	base := time.Now().UTC()
+	for i := range out {
+		out[i] = newOrder(side, orderType, price, qty)
+		out[i].CreatedAt = base.Add(time.Duration(i))
+	}
+	return out
+}
+
+// BenchmarkRestNoMatch adds non-crossing limit orders to a deep book.
+func BenchmarkRestNoMatch(b *testing.B) {
+	for _, depth := range []int{100, 10_000, 100_000} {
+		b.Run(fmt.Sprintf("depth=%d", depth), func(b *testing.B) {
+			m := NewMatcher()
+			restingBook(m, "BTC-USD", depth, 100)
+			incoming := orders(b.N, models.Buy, models.Limit, 50, 1)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.Match(incoming[i])
+			}
+		})
+	}
+}
+
+// BenchmarkMatchOneLevel fills against the top of a deep book, one
+// trade per order, replenishing the level so depth stays constant.
+func BenchmarkMatchOneLevel(b *testing.B) {
+	for _, depth := range []int{100, 10_000, 100_000} {
+		b.Run(fmt.Sprintf("depth=%d", depth), func(b *testing.B) {
+			m := NewMatcher()
+			restingBook(m, "BTC-USD", depth, 100)
+			makers := orders(b.N, models.Sell, models.Limit, 100.5, 1)
+			takers := orders(b.N, models.Buy, models.Limit, 100.5, 1)
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.Match(makers[i])
+				m.Match(takers[i])
+			}
+		})
+	}
+}
+
+// BenchmarkSweep sends one limit order priced through the whole ask
+// side, so it walks every level.
+func BenchmarkSweep(b *testing.B) {
+	for _, levels := range []int{10, 100, 1000} {
+		b.Run(fmt.Sprintf("levels=%d", levels), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				m := NewMatcher()
+				restingBook(m, "BTC-USD", levels, levels)
+				sweep := newOrder(models.Buy, models.Limit, 100+float64(levels), float64(levels))
+				b.StartTimer()
+
+				if trades := m.Match(sweep); len(trades) != levels {
+					b.Fatalf("expected %d trades, got %d", levels, len(trades))
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkCancelHeavy rests an order and cancels it straight away —
+// the pattern of a quoting market maker. Cancelled orders are removed
+// lazily, so this also measures what the dead heap entries cost the
+// next crossing order.
+func BenchmarkCancelHeavy(b *testing.B) {
+	m := NewMatcher()
+	restingBook(m, "BTC-USD", 1000, 100)
+	quotes := orders(b.N, models.Sell, models.Limit, 100.5, 1)
+	takers := orders(b.N/100+1, models.Buy, models.Limit, 100.5, 1)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		m.Match(quotes[i])
+		m.Cancel("BTC-USD", quotes[i].ID)
+		if i%100 == 99 {
+			m.Match(takers[i/100])
+		}
+	}
+}
+
+// BenchmarkManySymbols round-robins crossing orders across symbols.
+func BenchmarkManySymbols(b *testing.B) {
+	for _, n := range []int{1, 100, 10_000} {
+		b.Run(fmt.Sprintf("symbols=%d", n), func(b *testing.B) {
+			m := NewMatcher()
+			symbols := make([]string, n)
+			for i := range symbols {
+				symbols[i] = fmt.Sprintf("SYM-%d", i)
+				restingBook(m, symbols[i], 10, 10)
+			}
+			makers := orders(b.N, models.Sell, models.Limit, 100.5, 1)
+			takers := orders(b.N, models.Buy, models.Limit, 100.5, 1)
+			for i := 0; i < b.N; i++ {
+				makers[i].Symbol = symbols[i%n]
+				takers[i].Symbol = symbols[i%n]
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				m.Match(makers[i])
+				m.Match(takers[i])
+			}
+		})
+	}
+}
+
+// ─── OrderBook ────────────────────────────────────────────────────────────────
+
+func BenchmarkOrderBookAddPop(b *testing.B) {
+	book := NewOrderBook("BTC-USD")
+	for _, o := range orders(10_000, models.Buy, models.Limit, 99, 1) {
+		book.Add(o)
+	}
+	incoming := orders(b.N, models.Buy, models.Limit, 99, 1)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		book.Add(incoming[i])
+		book.PopBestBid()
+	}
+}
+
+func BenchmarkOrderBookDepth(b *testing.B) {
+	m := NewMatcher()
+	restingBook(m, "BTC-USD", 10_000, 1000)
+	book := m.BookFor("BTC-USD")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		book.Depth(20)
+	}
+}
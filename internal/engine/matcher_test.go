@@ -38,6 +38,9 @@ func TestFullMatch(t *testing.T) {
 	if trades[0].Quantity != 10.0 {
 		t.Errorf("expected qty 10, got %f", trades[0].Quantity)
 	}
+	if trades[0].TakerSide == nil || *trades[0].TakerSide != models.Buy {
+		t.Errorf("expected the incoming buy to be the taker, got %v", trades[0].TakerSide)
+	}
 	if buy.Status != models.StatusFilled {
 		t.Errorf("expected buy to be filled, got %s", buy.Status)
 	}
@@ -170,3 +173,94 @@ func TestCancelOrder(t *testing.T) {
 		t.Errorf("expected 0 trades after cancel, got %d", len(trades))
 	}
 }
+
+func TestHaltRejectsNewOrders(t *testing.T) {
+	m := NewMatcher()
+
+	resting := newOrder(models.Sell, models.Limit, 100.0, 5.0)
+	m.Match(resting)
+	m.Halt("BTC-USD")
+
+	buy := newOrder(models.Buy, models.Limit, 100.0, 5.0)
+	if trades := m.Match(buy); len(trades) != 0 {
+		t.Fatalf("expected no trades while halted, got %d", len(trades))
+	}
+	if buy.Status != models.StatusRejected {
+		t.Errorf("expected rejected, got %s", buy.Status)
+	}
+	if _, ok := m.Order(resting.ID); !ok {
+		t.Error("expected resting order to survive the halt")
+	}
+
+	m.Resume("BTC-USD")
+	buy = newOrder(models.Buy, models.Limit, 100.0, 5.0)
+	if trades := m.Match(buy); len(trades) != 1 {
+		t.Fatalf("expected a trade after resume, got %d", len(trades))
+	}
+
+	stats, _ := m.Stats("BTC-USD")
+	if stats.LastSequence != 3 || stats.LastTrade == nil || stats.RestingOrders != 0 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+}
+
+func TestStatsFollowBookChanges(t *testing.T) {
+	m := NewMatcher()
+
+	bid := newOrder(models.Buy, models.Limit, 99.0, 1.0)
+	cancelled := newOrder(models.Buy, models.Limit, 98.0, 1.0)
+	ask := newOrder(models.Sell, models.Limit, 101.0, 3.0)
+	filled := newOrder(models.Sell, models.Limit, 102.0, 1.0)
+	for _, o := range []*models.Order{bid, cancelled, ask, filled} {
+		m.Match(o)
+	}
+	m.Cancel("BTC-USD", cancelled.ID)
+	m.Match(newOrder(models.Buy, models.Limit, 101.0, 1.0)) // partially fills ask
+	m.Match(newOrder(models.Buy, models.Limit, 102.0, 3.0)) // takes the rest of ask, and filled
+
+	stats, _ := m.Stats("BTC-USD")
+	if stats.RestingBids != 1 || stats.BidLevels != 1 || stats.RestingAsks != 0 || stats.AskLevels != 0 {
+		t.Errorf("expected only the 99 bid left, got %+v", stats)
+	}
+}
+
+func TestBookInPriorityOrder(t *testing.T) {
+	m := NewMatcher()
+
+	low := newOrder(models.Buy, models.Limit, 99.0, 1.0)
+	first := newOrder(models.Buy, models.Limit, 100.0, 1.0)
+	second := newOrder(models.Buy, models.Limit, 100.0, 1.0)
+	second.CreatedAt = first.CreatedAt.Add(time.Millisecond)
+	for _, o := range []*models.Order{low, second, first} {
+		m.Match(o)
+	}
+
+	bids, asks, ok := m.Book("BTC-USD")
+	if !ok || len(asks) != 0 || len(bids) != 3 {
+		t.Fatalf("expected 3 bids, got %d bids %d asks", len(bids), len(asks))
+	}
+	if bids[0].ID != first.ID || bids[1].ID != second.ID || bids[2].ID != low.ID {
+		t.Error("expected bids by price, then time")
+	}
+}
+
+func TestRestoreThenRedelivery(t *testing.T) {
+	m := NewMatcher()
+
+	resting := newOrder(models.Sell, models.Limit, 100.0, 5.0)
+	if n := m.Restore([]*models.Order{resting, newOrder(models.Buy, models.Market, 0, 1.0)}); n != 1 {
+		t.Fatalf("expected 1 restored order, got %d", n)
+	}
+
+	// The same order arriving from Kafka must not rest a second time.
+	redelivered := *resting
+	m.Match(&redelivered)
+	if stats, _ := m.Stats("BTC-USD"); stats.RestingOrders != 1 {
+		t.Fatalf("expected 1 resting order after redelivery, got %d", stats.RestingOrders)
+	}
+
+	buy := newOrder(models.Buy, models.Limit, 100.0, 10.0)
+	if trades := m.Match(buy); len(trades) != 1 || trades[0].Quantity != 5.0 {
+		t.Fatalf("expected one fill of 5 against the restored order, got %+v", trades)
+	}
+}
@@ -2,6 +2,7 @@ package engine
 
 import (
 	"container/heap"
+	"sort"
 	"sync"
 
 	"github.com/Im-Manav/ome/pkg/models"
@@ -62,11 +63,33 @@ func (h *sellHeap) Pop() any {
 // on a single goroutine per symbol — enforced by the engine.
 
 type OrderBook struct {
-	symbol string
-	buys   *buyHeap                    // max-heap of resting buy orders
-	sells  *sellHeap                   // min-heap of resting sell orders
-	index  map[uuid.UUID]*models.Order // O(1) lookup for cancellation
-	mu     sync.RWMutex
+	symbol    string
+	buys      *buyHeap                    // max-heap of resting buy orders
+	sells     *sellHeap                   // min-heap of resting sell orders
+	index     map[uuid.UUID]*models.Order // O(1) lookup for cancellation
+	bidCounts levelCounts
+	askCounts levelCounts
+	mu        sync.RWMutex
+}
+
+// levelCounts tracks the active orders on one side of a book per price,
+// kept up to date as orders come and go so reading a book's size never
+// walks the heap. Cancelled orders still in the heap are not counted.
+type levelCounts struct {
+	orders int
+	prices map[float64]int // price -> active orders
+}
+
+func (c *levelCounts) add(price float64) {
+	c.orders++
+	c.prices[price]++
+}
+
+func (c *levelCounts) remove(price float64) {
+	c.orders--
+	if c.prices[price]--; c.prices[price] <= 0 {
+		delete(c.prices, price)
+	}
 }
 
 func NewOrderBook(symbol string) *OrderBook {
@@ -75,13 +98,23 @@ func NewOrderBook(symbol string) *OrderBook {
 	heap.Init(bh)
 	heap.Init(sh)
 	return &OrderBook{
-		symbol: symbol,
-		buys:   bh,
-		sells:  sh,
-		index:  make(map[uuid.UUID]*models.Order),
+		symbol:    symbol,
+		buys:      bh,
+		sells:     sh,
+		index:     make(map[uuid.UUID]*models.Order),
+		bidCounts: levelCounts{prices: make(map[float64]int)},
+		askCounts: levelCounts{prices: make(map[float64]int)},
 	}
 }
 
+// counts returns the counters for one side. Caller holds ob.mu.
+func (ob *OrderBook) counts(side models.Side) *levelCounts {
+	if side == models.Buy {
+		return &ob.bidCounts
+	}
+	return &ob.askCounts
+}
+
 // Add pushes a resting order into the appropriate heap.
 // Call this only for orders that didn't fully match.
 func (ob *OrderBook) Add(order *models.Order) {
@@ -89,6 +122,7 @@ func (ob *OrderBook) Add(order *models.Order) {
 	defer ob.mu.Unlock()
 
 	ob.index[order.ID] = order
+	ob.counts(order.Side).add(order.Price)
 	switch order.Side {
 	case models.Buy:
 		heap.Push(ob.buys, order)
@@ -110,6 +144,7 @@ func (ob *OrderBook) Cancel(orderID uuid.UUID) (found bool, side models.Side) {
 	}
 	order.Status = models.StatusCancelled
 	delete(ob.index, orderID)
+	ob.counts(order.Side).remove(order.Price)
 	return true, order.Side
 }
 
@@ -166,6 +201,7 @@ func (ob *OrderBook) PopBestBid() *models.Order {
 			continue
 		}
 		delete(ob.index, order.ID)
+		ob.counts(order.Side).remove(order.Price)
 		return order
 	}
 	return nil
@@ -182,6 +218,7 @@ func (ob *OrderBook) PopBestAsk() *models.Order {
 			continue
 		}
 		delete(ob.index, order.ID)
+		ob.counts(order.Side).remove(order.Price)
 		return order
 	}
 	return nil
@@ -198,6 +235,60 @@ func (ob *OrderBook) Depth(levels int) (bids, asks []models.OrderBookLevel) {
 	return
 }
 
+// Get returns a copy of a resting order by ID.
+func (ob *OrderBook) Get(orderID uuid.UUID) (models.Order, bool) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	order, ok := ob.index[orderID]
+	if !ok {
+		return models.Order{}, false
+	}
+	return *order, true
+}
+
+// Orders returns copies of every active resting order (L3), bids and
+// asks each sorted in matching priority — best price, then earliest.
+func (ob *OrderBook) Orders() (bids, asks []models.Order) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+
+	bids = activeOrders(*ob.buys)
+	sort.Slice(bids, func(i, j int) bool {
+		if bids[i].Price != bids[j].Price {
+			return bids[i].Price > bids[j].Price
+		}
+		return bids[i].CreatedAt.Before(bids[j].CreatedAt)
+	})
+
+	asks = activeOrders(*ob.sells)
+	sort.Slice(asks, func(i, j int) bool {
+		if asks[i].Price != asks[j].Price {
+			return asks[i].Price < asks[j].Price
+		}
+		return asks[i].CreatedAt.Before(asks[j].CreatedAt)
+	})
+	return bids, asks
+}
+
+func activeOrders(resting []*models.Order) []models.Order {
+	orders := make([]models.Order, 0, len(resting))
+	for _, o := range resting {
+		if o.Status != models.StatusCancelled {
+			orders = append(orders, *o)
+		}
+	}
+	return orders
+}
+
+// Counts returns how many active orders rest on each side and at how
+// many distinct prices, without copying or walking the book.
+func (ob *OrderBook) Counts() (bids, asks, bidLevels, askLevels int) {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.bidCounts.orders, ob.askCounts.orders, len(ob.bidCounts.prices), len(ob.askCounts.prices)
+}
+
 // Size returns the total number of active resting orders.
 func (ob *OrderBook) Size() int {
 	ob.mu.RLock()
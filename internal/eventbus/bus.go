@@ -0,0 +1,274 @@
+// Package eventbus is an in-process stand-in for the Kafka cluster.
+//
+// It speaks kafka-go's Message type so the real Producer, OrderConsumer
+// and TradeConsumer run on top of it unchanged — envelopes, codecs and
+// handlers included. Only the transport is swapped out, which is what
+// lets the whole order → match → trade → candle flow run under go test.
+package eventbus
+
+import (
+	"context"
+	"hash/fnv"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/kafka"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// DefaultPartitions is the partition count used by New when none is given.
+const DefaultPartitions = 4
+
+// Bus holds every topic's log in memory.
+//
+// Semantics mirror what the services rely on from Kafka:
+//   - messages with the same key land on the same partition, in order
+//   - every consumer group sees every message
+//   - within a group each partition is owned by exactly one reader
+//   - uncommitted messages are redelivered when a partition changes owner
+//
+// Unlike Kafka, a new group starts from the beginning of each partition,
+// so tests can publish before their consumers are running.
+type Bus struct {
+	partitions int
+
+	mu      sync.Mutex
+	topics  map[string]*topic
+	changed chan struct{} // closed and replaced on every write or rebalance
+}
+
+type topic struct {
+	logs   [][]kafkago.Message // one append-only log per partition
+	groups map[string]*group
+	next   int // round-robin partition for keyless messages
+}
+
+type group struct {
+	members   []*Reader
+	owner     []*Reader // partition -> owning reader
+	position  []int64   // partition -> next offset to hand out
+	committed []int64   // partition -> next offset not yet committed
+}
+
+func New(partitions int) *Bus {
+	if partitions <= 0 {
+		partitions = DefaultPartitions
+	}
+	return &Bus{
+		partitions: partitions,
+		topics:     make(map[string]*topic),
+		changed:    make(chan struct{}),
+	}
+}
+
+// Writer returns a writer for a topic. It satisfies kafka.MessageWriter.
+func (b *Bus) Writer(topic string) kafka.MessageWriter {
+	return &Writer{bus: b, topic: topic}
+}
+
+// Reader joins a consumer group on a topic. It satisfies kafka.MessageReader.
+// Partitions are rebalanced across the group's readers on every join and close.
+func (b *Bus) Reader(topic, groupID string) *Reader {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	g := b.group(topic, groupID)
+	r := &Reader{bus: b, topic: topic, group: g}
+	g.members = append(g.members, r)
+	b.rebalance(g)
+	return r
+}
+
+// Publisher returns a kafka.Producer that writes to this bus.
+// The producer implements ports.EventPublisher.
+func (b *Bus) Publisher(source string, codecs kafka.TopicCodecs) *kafka.Producer {
+	return kafka.NewProducerWithWriters(source, codecs, b.Writer)
+}
+
+// topic returns the topic, creating it on first use. Caller holds b.mu.
+func (b *Bus) topic(name string) *topic {
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{
+			logs:   make([][]kafkago.Message, b.partitions),
+			groups: make(map[string]*group),
+		}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// group returns the consumer group, creating it on first use. Caller holds b.mu.
+func (b *Bus) group(topicName, groupID string) *group {
+	t := b.topic(topicName)
+	g, ok := t.groups[groupID]
+	if !ok {
+		g = &group{
+			owner:     make([]*Reader, b.partitions),
+			position:  make([]int64, b.partitions),
+			committed: make([]int64, b.partitions),
+		}
+		t.groups[groupID] = g
+	}
+	return g
+}
+
+// rebalance assigns partitions round-robin across the group's readers.
+// A partition that changes owner rewinds to its last commit, so
+// anything fetched but not committed is delivered again. Caller holds b.mu.
+func (b *Bus) rebalance(g *group) {
+	for p := range g.owner {
+		var next *Reader
+		if len(g.members) > 0 {
+			next = g.members[p%len(g.members)]
+		}
+		if g.owner[p] != next {
+			g.owner[p] = next
+			g.position[p] = g.committed[p]
+		}
+	}
+	b.notify()
+}
+
+// notify wakes every reader blocked in FetchMessage. Caller holds b.mu.
+func (b *Bus) notify() {
+	close(b.changed)
+	b.changed = make(chan struct{})
+}
+
+func partitionFor(key []byte, partitions int) int {
+	h := fnv.New32a()
+	h.Write(key)
+	return int(h.Sum32() % uint32(partitions))
+}
+
+// ─── Writer ───────────────────────────────────────────────────────────────────
+
+type Writer struct {
+	bus   *Bus
+	topic string
+}
+
+// WriteMessages appends messages to the topic. All messages in one call
+// become visible to readers together.
+func (w *Writer) WriteMessages(ctx context.Context, msgs ...kafkago.Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b := w.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t := b.topic(w.topic)
+	for _, msg := range msgs {
+		p := t.next % b.partitions
+		if len(msg.Key) > 0 {
+			p = partitionFor(msg.Key, b.partitions)
+		} else {
+			t.next++
+		}
+
+		msg.Topic = w.topic
+		msg.Partition = p
+		msg.Offset = int64(len(t.logs[p]))
+		if msg.Time.IsZero() {
+			msg.Time = time.Now().UTC()
+		}
+		t.logs[p] = append(t.logs[p], msg)
+	}
+	b.notify()
+	return nil
+}
+
+func (w *Writer) Close() error { return nil }
+
+// ─── Reader ───────────────────────────────────────────────────────────────────
+
+type Reader struct {
+	bus    *Bus
+	topic  string
+	group  *group
+	closed bool
+	cursor int // partition to try first, so one busy partition can't starve the rest
+}
+
+// FetchMessage blocks until a message is available on a partition this
+// reader owns, ctx is cancelled, or the reader is closed (io.EOF).
+func (r *Reader) FetchMessage(ctx context.Context) (kafkago.Message, error) {
+	b := r.bus
+	for {
+		b.mu.Lock()
+		if r.closed {
+			b.mu.Unlock()
+			return kafkago.Message{}, io.EOF
+		}
+		if msg, ok := r.next(); ok {
+			b.mu.Unlock()
+			return msg, nil
+		}
+		changed := b.changed
+		b.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return kafkago.Message{}, ctx.Err()
+		}
+	}
+}
+
+// next hands out the next message from an owned partition. Caller holds bus.mu.
+func (r *Reader) next() (kafkago.Message, bool) {
+	logs := r.bus.topics[r.topic].logs
+	g := r.group
+	for i := range logs {
+		p := (r.cursor + i) % len(logs)
+		if g.owner[p] != r || g.position[p] >= int64(len(logs[p])) {
+			continue
+		}
+		msg := logs[p][g.position[p]]
+		msg.HighWaterMark = int64(len(logs[p]))
+		g.position[p]++
+		r.cursor = p + 1
+		return msg, true
+	}
+	return kafkago.Message{}, false
+}
+
+// CommitMessages records the group's progress. Commits never move backwards.
+func (r *Reader) CommitMessages(ctx context.Context, msgs ...kafkago.Message) error {
+	b := r.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	for _, msg := range msgs {
+		if next := msg.Offset + 1; next > r.group.committed[msg.Partition] {
+			r.group.committed[msg.Partition] = next
+		}
+	}
+	return nil
+}
+
+// Close leaves the group and hands this reader's partitions to the others.
+func (r *Reader) Close() error {
+	b := r.bus
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	g := r.group
+	for i, m := range g.members {
+		if m == r {
+			g.members = append(g.members[:i], g.members[i+1:]...)
+			break
+		}
+	}
+	b.rebalance(g)
+	return nil
+}
@@ -0,0 +1,92 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+func fetch(t *testing.T, r *Reader) kafkago.Message {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	msg, err := r.FetchMessage(ctx)
+	if err != nil {
+		t.Fatalf("FetchMessage: %v", err)
+	}
+	return msg
+}
+
+func TestPerKeyOrdering(t *testing.T) {
+	bus := New(4)
+	w := bus.Writer("orders")
+	for i := 0; i < 20; i++ {
+		key := []byte(fmt.Sprintf("SYM-%d", i%3))
+		_ = w.WriteMessages(context.Background(), kafkago.Message{Key: key, Value: []byte(fmt.Sprint(i))})
+	}
+
+	r := bus.Reader("orders", "engine")
+	last := map[string]int{}
+	for i := 0; i < 20; i++ {
+		msg := fetch(t, r)
+		var n int
+		fmt.Sscan(string(msg.Value), &n)
+		if prev, ok := last[string(msg.Key)]; ok && n <= prev {
+			t.Fatalf("key %s: got %d after %d", msg.Key, n, prev)
+		}
+		last[string(msg.Key)] = n
+	}
+}
+
+func TestEveryGroupSeesEveryMessage(t *testing.T) {
+	bus := New(2)
+	_ = bus.Writer("trades").WriteMessages(context.Background(),
+		kafkago.Message{Key: []byte("BTC-USD"), Value: []byte("t1")},
+	)
+
+	for _, groupID := range []string{"marketdata", "websocket"} {
+		msg := fetch(t, bus.Reader("trades", groupID))
+		if string(msg.Value) != "t1" {
+			t.Errorf("group %s: expected t1, got %s", groupID, msg.Value)
+		}
+	}
+}
+
+func TestGroupMembersSplitPartitions(t *testing.T) {
+	bus := New(2)
+	a := bus.Reader("orders", "engine")
+	b := bus.Reader("orders", "engine")
+
+	g := a.group
+	if g.owner[0] == g.owner[1] {
+		t.Fatalf("expected partitions split across readers, both owned by one")
+	}
+	if g.owner[0] != a && g.owner[1] != a || g.owner[0] != b && g.owner[1] != b {
+		t.Fatalf("expected each reader to own a partition")
+	}
+}
+
+func TestUncommittedRedeliveredAfterRebalance(t *testing.T) {
+	bus := New(1)
+	w := bus.Writer("orders")
+	_ = w.WriteMessages(context.Background(),
+		kafkago.Message{Key: []byte("k"), Value: []byte("m1")},
+		kafkago.Message{Key: []byte("k"), Value: []byte("m2")},
+	)
+
+	first := bus.Reader("orders", "engine")
+	m1 := fetch(t, first)
+	if err := first.CommitMessages(context.Background(), m1); err != nil {
+		t.Fatalf("CommitMessages: %v", err)
+	}
+	fetch(t, first) // m2 fetched but never committed — simulated crash
+	first.Close()
+
+	second := bus.Reader("orders", "engine")
+	if got := fetch(t, second); string(got.Value) != "m2" {
+		t.Errorf("expected uncommitted m2 to be redelivered, got %s", got.Value)
+	}
+}
@@ -0,0 +1,275 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/cache"
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/internal/kafka"
+	"github.com/Im-Manav/ome/internal/marketdata"
+	"github.com/Im-Manav/ome/internal/service"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+// memRepo is just enough of the repository ports for the flow to run.
+type memRepo struct {
+	mu     sync.Mutex
+	orders map[uuid.UUID]models.Order
+	trades []models.Trade
+}
+
+func newMemRepo() *memRepo {
+	return &memRepo{orders: make(map[uuid.UUID]models.Order)}
+}
+
+func (r *memRepo) SaveOrder(o *models.Order) error { return r.UpdateOrder(o) }
+func (r *memRepo) UpdateOrder(o *models.Order) error {
+	r.mu.Lock()
+	r.orders[o.ID] = *o
+	r.mu.Unlock()
+	return nil
+}
+func (r *memRepo) CancelOrder(id uuid.UUID) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o := r.orders[id]
+	o.Status = models.StatusCancelled
+	r.orders[id] = o
+	return nil
+}
+func (r *memRepo) ReplaceOrder(oldID uuid.UUID, o *models.Order) error {
+	if err := r.CancelOrder(oldID); err != nil {
+		return err
+	}
+	return r.UpdateOrder(o)
+}
+func (r *memRepo) FillOrders(fills []models.OrderFill) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, f := range fills {
+		o, ok := r.orders[f.OrderID]
+		if !ok || (o.Status != models.StatusOpen && o.Status != models.StatusPartial) {
+			continue
+		}
+		o.FilledQty += f.Quantity
+		o.RemainingQty = max(o.RemainingQty-f.Quantity, 0)
+		o.Status = models.StatusPartial
+		if o.RemainingQty == 0 {
+			o.Status = models.StatusFilled
+		}
+		r.orders[f.OrderID] = o
+	}
+	return nil
+}
+func (r *memRepo) GetOrderByID(id uuid.UUID) (*models.Order, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	o, ok := r.orders[id]
+	if !ok {
+		return nil, fmt.Errorf("order %s not found", id)
+	}
+	return &o, nil
+}
+func (r *memRepo) GetOrderByClientOrderID(uuid.UUID, string) (*models.Order, error) {
+	return nil, nil
+}
+func (r *memRepo) GetOpenOrdersBySymbol(string) ([]*models.Order, error) { return nil, nil }
+func (r *memRepo) GetOpenOrders() ([]*models.Order, error)               { return nil, nil }
+func (r *memRepo) GetOrdersByUserID(uuid.UUID) ([]*models.Order, error)  { return nil, nil }
+func (r *memRepo) ListOrdersByUserID(uuid.UUID, models.OrderFilter) ([]*models.Order, error) {
+	return nil, nil
+}
+func (r *memRepo) SaveTrade(t *models.Trade) error                       { return r.SaveTrades([]models.Trade{*t}) }
+func (r *memRepo) GetTradesBySymbol(string, int) ([]models.Trade, error) { return nil, nil }
+func (r *memRepo) GetTradesByOrderID(uuid.UUID) ([]models.Trade, error)  { return nil, nil }
+func (r *memRepo) GetTradesSince(time.Time) ([]models.Trade, error)      { return nil, nil }
+func (r *memRepo) ListTradesByUserID(uuid.UUID, models.TradeFilter) ([]models.Trade, error) {
+	return nil, nil
+}
+func (r *memRepo) GetTradesByUserID(uuid.UUID, int) ([]models.Trade, error) { return nil, nil }
+func (r *memRepo) SaveTrades(ts []models.Trade) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.trades = append(r.trades, ts...)
+	return nil
+}
+func (r *memRepo) UpsertOHLCV(*models.OHLCV) error                      { return nil }
+func (r *memRepo) GetOHLCV(string, string, int) ([]models.OHLCV, error) { return nil, nil }
+func (r *memRepo) GetOHLCVRange(string, string, time.Time, time.Time, int) ([]models.OHLCV, error) {
+	return nil, nil
+}
+
+type noopBroadcaster struct{}
+
+func (noopBroadcaster) BroadcastTrade(models.TradeEvent)                  {}
+func (noopBroadcaster) BroadcastOrderBookUpdate(models.OrderBookSnapshot) {}
+
+// candleRecorder captures what the candle builder publishes.
+type candleRecorder chan models.OHLCV
+
+func (c candleRecorder) Publish(_ context.Context, _ string, payload any) error {
+	c <- payload.(models.OHLCV)
+	return nil
+}
+
+func TestOrderToCandleFlow(t *testing.T) {
+	logger.InitForTest()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := New(DefaultPartitions)
+	repo := newMemRepo()
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	// Gateway side: validate, persist, publish to the orders topic, and
+	// wait on the engine's executions for ?wait=true placements.
+	gatewaySvc := service.NewOrderService(repo, repo, bus.Publisher(kafka.SourceGateway, nil), mem, noopBroadcaster{})
+	executions := service.NewExecutionWaiter(mem, 2*time.Second)
+	if err := executions.Start(ctx); err != nil {
+		t.Fatalf("start execution waiter: %v", err)
+	}
+	gatewaySvc.SetExecutions(executions)
+
+	// Engine side: consume orders, match, publish trades, persist fills.
+	enginePub := bus.Publisher(kafka.SourceEngine, kafka.TopicCodecs{kafka.TopicTrades: kafka.ProtobufCodec})
+	engineSvc := service.NewOrderService(repo, repo, enginePub, mem, noopBroadcaster{})
+	orders := kafka.NewOrderConsumerFromReader(bus.Reader(kafka.TopicOrders, kafka.GroupEngine), engine.NewMatcher(), enginePub)
+	orders.AddHandler(engineSvc.PostMatchHandler)
+	go orders.Start(ctx)
+
+	// Market data side: consume trades, build candles.
+	candles := make(candleRecorder, 8)
+	trades := kafka.NewTradeConsumerFromReader(bus.Reader(kafka.TopicTrades, kafka.GroupMarketData))
+	trades.AddHandler(marketdata.NewCandleBuilder(repo, candles).HandleTrade)
+	go trades.Start(ctx)
+
+	seller, buyer := uuid.New(), uuid.New()
+	sell, err := gatewaySvc.PlaceOrder(ctx, models.PlaceOrderRequest{
+		Symbol: "BTC-USD", Side: models.Sell, Type: models.Limit, Price: 100, Quantity: 2,
+	}, seller)
+	if err != nil {
+		t.Fatalf("place sell: %v", err)
+	}
+	buy, err := gatewaySvc.PlaceOrderAndWait(ctx, models.PlaceOrderRequest{
+		Symbol: "BTC-USD", Side: models.Buy, Type: models.Limit, Price: 100, Quantity: 2,
+	}, buyer)
+	if err != nil {
+		t.Fatalf("place buy: %v", err)
+	}
+	if buy.Pending || buy.Order.Status != models.StatusFilled || len(buy.Trades) != 1 {
+		t.Errorf("expected the waited buy to come back filled with 1 trade, got %+v", buy)
+	}
+
+	select {
+	case candle := <-candles:
+		if candle.Symbol != "BTC-USD" || candle.Close != 100 || candle.Volume != 2 {
+			t.Errorf("unexpected candle: %+v", candle)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for a candle")
+	}
+
+	// Trades are published before the post-match handlers run,
+	// so the candle can arrive before the fill is persisted.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		o, err := repo.GetOrderByID(buy.Order.ID)
+		if err != nil {
+			t.Fatalf("GetOrderByID: %v", err)
+		}
+		repo.mu.Lock()
+		persisted := len(repo.trades)
+		repo.mu.Unlock()
+
+		if o.Status == models.StatusFilled && persisted == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected filled buy and 1 persisted trade, got %s and %d", o.Status, persisted)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	if sell.Order.Status != models.StatusOpen {
+		t.Errorf("expected sell to be accepted as open, got %s", sell.Order.Status)
+	}
+}
+
+func TestCancelAndAmendLeaveTheBook(t *testing.T) {
+	logger.InitForTest()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	bus := New(DefaultPartitions)
+	repo := newMemRepo()
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	gatewaySvc := service.NewOrderService(repo, repo, bus.Publisher(kafka.SourceGateway, nil), mem, noopBroadcaster{})
+	executions := service.NewExecutionWaiter(mem, 2*time.Second)
+	if err := executions.Start(ctx); err != nil {
+		t.Fatalf("start execution waiter: %v", err)
+	}
+	gatewaySvc.SetExecutions(executions)
+
+	matcher := engine.NewMatcher()
+	enginePub := bus.Publisher(kafka.SourceEngine, nil)
+	engineSvc := service.NewOrderService(repo, repo, enginePub, mem, noopBroadcaster{})
+	orders := kafka.NewOrderConsumerFromReader(bus.Reader(kafka.TopicOrders, kafka.GroupEngine), matcher, enginePub)
+	orders.AddHandler(engineSvc.PostMatchHandler)
+	go orders.Start(ctx)
+
+	seller := uuid.New()
+	place := func(price, qty float64) models.Order {
+		t.Helper()
+		resp, err := gatewaySvc.PlaceOrder(ctx, models.PlaceOrderRequest{
+			Symbol: "BTC-USD", Side: models.Sell, Type: models.Limit, Price: price, Quantity: qty,
+		}, seller)
+		if err != nil {
+			t.Fatalf("place sell: %v", err)
+		}
+		return resp.Order
+	}
+	amended := place(100, 2)
+	cancelled := place(102, 1)
+
+	replacement, err := gatewaySvc.AmendOrder(ctx, amended.ID, models.AmendOrderRequest{Price: 101, Quantity: 1}, seller)
+	if err != nil {
+		t.Fatalf("AmendOrder: %v", err)
+	}
+	if err := gatewaySvc.CancelOrder(ctx, cancelled.ID, seller); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+
+	// Only the replacement may rest: the original and the cancelled
+	// order must be gone from the engine's book, not just the database.
+	deadline := time.Now().Add(2 * time.Second)
+	for {
+		_, asks, _ := matcher.Book("BTC-USD")
+		if len(asks) == 1 && asks[0].ID == replacement.Order.ID {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected only the replacement resting, got %+v", asks)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// A buy for more than the book holds fills against the replacement
+	// alone, so the seller is never overfilled.
+	buy, err := gatewaySvc.PlaceOrderAndWait(ctx, models.PlaceOrderRequest{
+		Symbol: "BTC-USD", Side: models.Buy, Type: models.Limit, Price: 105, Quantity: 3,
+	}, uuid.New())
+	if err != nil {
+		t.Fatalf("place buy: %v", err)
+	}
+	if len(buy.Trades) != 1 || buy.Trades[0].Quantity != 1 || buy.Trades[0].SellOrderID != replacement.Order.ID {
+		t.Errorf("expected one fill of 1 against the replacement, got %+v", buy.Trades)
+	}
+}
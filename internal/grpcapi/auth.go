@@ -0,0 +1,120 @@
+package grpcapi
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/api"
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/internal/service"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// MetadataAuthorization carries the access token, as "Bearer <token>".
+const MetadataAuthorization = "authorization"
+
+type userIDKey struct{}
+
+// authenticator checks every call the way api.Auth and api.RateLimit
+// check REST requests: a valid, unrevoked access token for an account
+// that isn't frozen, within the user's rate limit. Calls and streams
+// share the user's REST budget; a stream counts once, when it opens.
+type authenticator struct {
+	authSvc *service.AuthService
+	cache   ports.Cache
+}
+
+func (a *authenticator) unary(
+	ctx context.Context,
+	req any,
+	info *grpc.UnaryServerInfo,
+	handler grpc.UnaryHandler,
+) (any, error) {
+	ctx, err := a.authenticate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (a *authenticator) stream(
+	srv any,
+	ss grpc.ServerStream,
+	info *grpc.StreamServerInfo,
+	handler grpc.StreamHandler,
+) error {
+	ctx, err := a.authenticate(ss.Context())
+	if err != nil {
+		return err
+	}
+	return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+}
+
+// authenticate returns ctx carrying the caller's user ID, or the status
+// to fail the call with.
+func (a *authenticator) authenticate(ctx context.Context) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	values := md.Get(MetadataAuthorization)
+	if len(values) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+	scheme, token, ok := strings.Cut(values[0], " ")
+	if !ok || !strings.EqualFold(scheme, "bearer") {
+		return nil, status.Error(codes.Unauthenticated, "invalid authorization format, expected: Bearer <token>")
+	}
+
+	claims, err := a.authSvc.ValidateToken(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	blocked, err := a.authSvc.IsBlocklisted(ctx, claims.JTI)
+	if err != nil || blocked {
+		return nil, status.Error(codes.Unauthenticated, "token has been revoked")
+	}
+	if claims.SessionID != "" {
+		revoked, err := a.authSvc.IsSessionRevoked(ctx, claims.SessionID)
+		if err != nil || revoked {
+			return nil, status.Error(codes.Unauthenticated, "session has been revoked")
+		}
+	}
+	userID, err := uuid.Parse(claims.UserID)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid user id in token")
+	}
+
+	// Fails closed, like the blocklist
+	frozen, err := a.authSvc.IsFrozen(ctx, userID)
+	if err != nil {
+		return nil, status.Error(codes.Unavailable, "could not check account status")
+	}
+	if frozen {
+		return nil, status.Error(codes.PermissionDenied, "account frozen")
+	}
+
+	// Fails open, like api.RateLimit — don't block orders if Redis is down
+	count, err := a.cache.IncrWithExpiry(ctx, userID.String(), time.Minute)
+	if err == nil && count > api.RateLimitRequests {
+		return nil, status.Error(codes.ResourceExhausted, "Rate limit exceeded")
+	}
+
+	return context.WithValue(ctx, userIDKey{}, userID), nil
+}
+
+// userID returns the caller set by the interceptors.
+func userID(ctx context.Context) uuid.UUID {
+	id, _ := ctx.Value(userIDKey{}).(uuid.UUID)
+	return id
+}
+
+// authenticatedStream swaps in the context carrying the user ID.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context { return s.ctx }
@@ -0,0 +1,229 @@
+package grpcapi
+
+import (
+	"net/http"
+
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/models"
+	eventsv1 "github.com/Im-Manav/ome/pkg/pb/ome/events/v1"
+	tradingv1 "github.com/Im-Manav/ome/pkg/pb/ome/trading/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// toStatus maps a service error to a gRPC status, via the same table
+// as the REST API so both report errors alike.
+func toStatus(err error) error {
+	appErr := apperrors.ToHTTP(err)
+	code := codes.Internal
+	switch appErr.Code {
+	case http.StatusBadRequest:
+		code = codes.InvalidArgument
+	case http.StatusUnauthorized:
+		code = codes.Unauthenticated
+	case http.StatusForbidden:
+		code = codes.PermissionDenied
+	case http.StatusNotFound:
+		code = codes.NotFound
+	case http.StatusConflict:
+		code = codes.AlreadyExists
+	case http.StatusUnprocessableEntity:
+		code = codes.FailedPrecondition
+	case http.StatusTooManyRequests:
+		code = codes.ResourceExhausted
+	}
+	return status.Error(code, appErr.Message)
+}
+
+func parseOrderID(id string) (uuid.UUID, error) {
+	orderID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, status.Error(codes.InvalidArgument, "invalid order id")
+	}
+	return orderID, nil
+}
+
+// ─── Models → protobuf ────────────────────────────────────────────────────────
+
+func toOrder(o *models.Order) *tradingv1.Order {
+	return &tradingv1.Order{
+		Id:            o.ID.String(),
+		ClientOrderId: o.ClientOrderID,
+		Symbol:        o.Symbol,
+		Side:          eventsv1.Side(o.Side),
+		Type:          eventsv1.OrderType(o.Type),
+		Price:         o.Price,
+		Quantity:      o.Quantity,
+		FilledQty:     o.FilledQty,
+		RemainingQty:  o.RemainingQty,
+		Status:        eventsv1.OrderStatus(o.Status),
+		CreatedAt:     timestamppb.New(o.CreatedAt),
+		UpdatedAt:     timestamppb.New(o.UpdatedAt),
+	}
+}
+
+func toOrders(orders []*models.Order) []*tradingv1.Order {
+	out := make([]*tradingv1.Order, len(orders))
+	for i, o := range orders {
+		out[i] = toOrder(o)
+	}
+	return out
+}
+
+func toTrade(t models.Trade) *tradingv1.Trade {
+	return &tradingv1.Trade{
+		Id:          t.ID.String(),
+		Symbol:      t.Symbol,
+		BuyOrderId:  t.BuyOrderID.String(),
+		SellOrderId: t.SellOrderID.String(),
+		Price:       t.Price,
+		Quantity:    t.Quantity,
+		TakerSide:   takerSide(t.TakerSide),
+		ExecutedAt:  timestamppb.New(t.ExecutedAt),
+	}
+}
+
+// takerSide is unset for trades recorded before it was tracked.
+func takerSide(side *models.Side) *eventsv1.Side {
+	if side == nil {
+		return nil
+	}
+	return eventsv1.Side(*side).Enum()
+}
+
+func toTrades(trades []models.Trade) []*tradingv1.Trade {
+	out := make([]*tradingv1.Trade, len(trades))
+	for i, t := range trades {
+		out[i] = toTrade(t)
+	}
+	return out
+}
+
+// toFill describes f from the side of orderID, which is on side.
+func toFill(f models.Fill, orderID uuid.UUID, side models.Side) *tradingv1.Fill {
+	var liquidity tradingv1.Liquidity
+	switch f.Liquidity {
+	case models.LiquidityMaker:
+		liquidity = tradingv1.Liquidity_LIQUIDITY_MAKER
+	case models.LiquidityTaker:
+		liquidity = tradingv1.Liquidity_LIQUIDITY_TAKER
+	}
+	return &tradingv1.Fill{
+		Trade:     toTrade(f.Trade),
+		OrderId:   orderID.String(),
+		Side:      eventsv1.Side(side),
+		Liquidity: liquidity,
+		Fee:       f.Fee,
+	}
+}
+
+func toPlaceOrderResponse(resp *models.PlaceOrderResponse) *tradingv1.PlaceOrderResponse {
+	return &tradingv1.PlaceOrderResponse{
+		Order:     toOrder(&resp.Order),
+		Trades:    toTrades(resp.Trades),
+		Pending:   resp.Pending,
+		Duplicate: resp.Duplicate,
+	}
+}
+
+func toOrderDetail(d *models.OrderDetail) *tradingv1.GetOrderResponse {
+	fills := make([]*tradingv1.Fill, len(d.Fills))
+	for i, f := range d.Fills {
+		fills[i] = toFill(f, d.Order.ID, d.Order.Side)
+	}
+	return &tradingv1.GetOrderResponse{
+		Order:        toOrder(&d.Order),
+		Fills:        fills,
+		AvgFillPrice: d.AvgFillPrice,
+		Fees:         d.Fees,
+	}
+}
+
+func toOrderBook(snap models.OrderBookSnapshot) *tradingv1.OrderBook {
+	return &tradingv1.OrderBook{
+		Symbol:    snap.Symbol,
+		Bids:      toLevels(snap.Bids),
+		Asks:      toLevels(snap.Asks),
+		Timestamp: timestamppb.New(snap.Timestamp),
+	}
+}
+
+func toLevels(levels []models.OrderBookLevel) []*tradingv1.PriceLevel {
+	out := make([]*tradingv1.PriceLevel, len(levels))
+	for i, l := range levels {
+		out[i] = &tradingv1.PriceLevel{Price: l.Price, Quantity: l.Quantity, Orders: int32(l.Orders)}
+	}
+	return out
+}
+
+func toExecution(ue models.UserExecution) *tradingv1.Execution {
+	exec := &tradingv1.Execution{Fills: make([]*tradingv1.Fill, len(ue.Fills))}
+	if ue.Order != nil {
+		exec.Order = toOrder(ue.Order)
+	}
+	for i, f := range ue.Fills {
+		side := models.Buy
+		if f.Role == models.RoleSeller {
+			side = models.Sell
+		}
+		exec.Fills[i] = toFill(f.Fill, f.OrderID, side)
+	}
+	return exec
+}
+
+// ─── Protobuf → models ────────────────────────────────────────────────────────
+
+func fromPlaceOrderRequest(req *tradingv1.PlaceOrderRequest) models.PlaceOrderRequest {
+	return models.PlaceOrderRequest{
+		Symbol:        req.GetSymbol(),
+		Side:          models.Side(req.GetSide()),
+		Type:          models.OrderType(req.GetType()),
+		Price:         req.GetPrice(),
+		Quantity:      req.GetQuantity(),
+		ClientOrderID: req.GetClientOrderId(),
+	}
+}
+
+// fromListOrdersRequest builds the filter GET /api/v1/orders builds from
+// its query string.
+func fromListOrdersRequest(req *tradingv1.ListOrdersRequest) (models.OrderFilter, error) {
+	f := models.OrderFilter{Symbol: req.GetSymbol()}
+	for _, s := range req.GetStatuses() {
+		f.Statuses = append(f.Statuses, models.OrderStatus(s))
+	}
+	if req.Side != nil {
+		side := models.Side(req.GetSide())
+		f.Side = &side
+	}
+	if req.GetFrom() != nil {
+		f.From = req.GetFrom().AsTime()
+	}
+	if req.GetTo() != nil {
+		f.To = req.GetTo().AsTime()
+	}
+	f.Limit = int(req.GetLimit())
+	if req.GetCursor() != "" {
+		cursor, err := models.DecodeCursor(req.GetCursor())
+		if err != nil {
+			return f, toStatus(apperrors.ErrInvalidCursor)
+		}
+		f.After = &cursor
+	}
+	return f, nil
+}
+
+// validClientOrderID applies the REST binding for client_order_id:
+// at most 64 printable ASCII characters.
+func validClientOrderID(id string) error {
+	if len(id) > 64 {
+		return status.Error(codes.InvalidArgument, "client_order_id must be at most 64 characters")
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] < 0x20 || id[i] > 0x7e {
+			return status.Error(codes.InvalidArgument, "client_order_id must be printable ASCII")
+		}
+	}
+	return nil
+}
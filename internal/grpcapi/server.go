@@ -0,0 +1,248 @@
+// Package grpcapi serves the trading API over gRPC, for strategy
+// services that want typed, low-overhead access. It is a thin layer over
+// the same services as the REST API in package api; see
+// proto/ome/trading/v1/trading.proto for the contract.
+package grpcapi
+
+import (
+	"context"
+
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/internal/service"
+	"github.com/Im-Manav/ome/pkg/models"
+	eventsv1 "github.com/Im-Manav/ome/pkg/pb/ome/events/v1"
+	tradingv1 "github.com/Im-Manav/ome/pkg/pb/ome/trading/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type Server struct {
+	tradingv1.UnimplementedTradingServiceServer
+	orderSvc *service.OrderService
+	cache    ports.Cache
+}
+
+// NewServer returns a gRPC server with the trading service registered
+// behind the auth and rate limit interceptors.
+func NewServer(
+	orderSvc *service.OrderService,
+	authSvc *service.AuthService,
+	cache ports.Cache,
+) *grpc.Server {
+	auth := &authenticator{authSvc: authSvc, cache: cache}
+	srv := grpc.NewServer(
+		grpc.UnaryInterceptor(auth.unary),
+		grpc.StreamInterceptor(auth.stream),
+	)
+	tradingv1.RegisterTradingServiceServer(srv, &Server{orderSvc: orderSvc, cache: cache})
+	return srv
+}
+
+// Shutdown stops srv, letting calls in flight finish until ctx is done.
+// Subscriptions never finish on their own, so they are cut off then.
+func Shutdown(ctx context.Context, srv *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		srv.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		srv.Stop()
+	}
+}
+
+// ─── Orders ───────────────────────────────────────────────────────────────────
+
+func (s *Server) PlaceOrder(
+	ctx context.Context,
+	req *tradingv1.PlaceOrderRequest,
+) (*tradingv1.PlaceOrderResponse, error) {
+	if err := validClientOrderID(req.GetClientOrderId()); err != nil {
+		return nil, err
+	}
+
+	place := s.orderSvc.PlaceOrder
+	if req.GetWait() {
+		place = s.orderSvc.PlaceOrderAndWait
+	}
+	resp, err := place(ctx, fromPlaceOrderRequest(req), userID(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toPlaceOrderResponse(resp), nil
+}
+
+func (s *Server) CancelOrder(
+	ctx context.Context,
+	req *tradingv1.CancelOrderRequest,
+) (*tradingv1.CancelOrderResponse, error) {
+	var order *models.Order
+	switch ref := req.GetRef().(type) {
+	case *tradingv1.CancelOrderRequest_OrderId:
+		orderID, err := parseOrderID(ref.OrderId)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.orderSvc.CancelOrder(ctx, orderID, userID(ctx)); err != nil {
+			return nil, toStatus(err)
+		}
+		order = &models.Order{ID: orderID, Status: models.StatusCancelled}
+	case *tradingv1.CancelOrderRequest_ClientOrderId:
+		var err error
+		if order, err = s.orderSvc.CancelOrderByClientOrderID(ctx, ref.ClientOrderId, userID(ctx)); err != nil {
+			return nil, toStatus(err)
+		}
+	default:
+		return nil, status.Error(codes.InvalidArgument, "order_id or client_order_id required")
+	}
+
+	return &tradingv1.CancelOrderResponse{
+		OrderId: order.ID.String(),
+		Status:  eventsv1.OrderStatus(order.Status),
+	}, nil
+}
+
+func (s *Server) AmendOrder(
+	ctx context.Context,
+	req *tradingv1.AmendOrderRequest,
+) (*tradingv1.AmendOrderResponse, error) {
+	orderID, err := parseOrderID(req.GetOrderId())
+	if err != nil {
+		return nil, err
+	}
+	if err := validClientOrderID(req.GetClientOrderId()); err != nil {
+		return nil, err
+	}
+
+	resp, err := s.orderSvc.AmendOrder(ctx, orderID, models.AmendOrderRequest{
+		Price:         req.GetPrice(),
+		Quantity:      req.GetQuantity(),
+		ClientOrderID: req.GetClientOrderId(),
+	}, userID(ctx))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &tradingv1.AmendOrderResponse{
+		CancelledOrderId: orderID.String(),
+		Order:            toOrder(&resp.Order),
+	}, nil
+}
+
+func (s *Server) GetOrder(
+	ctx context.Context,
+	req *tradingv1.GetOrderRequest,
+) (*tradingv1.GetOrderResponse, error) {
+	var (
+		detail *models.OrderDetail
+		err    error
+	)
+	switch ref := req.GetRef().(type) {
+	case *tradingv1.GetOrderRequest_OrderId:
+		orderID, parseErr := parseOrderID(ref.OrderId)
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		detail, err = s.orderSvc.GetOrderDetail(ctx, orderID, userID(ctx))
+	case *tradingv1.GetOrderRequest_ClientOrderId:
+		detail, err = s.orderSvc.GetOrderDetailByClientOrderID(ctx, ref.ClientOrderId, userID(ctx))
+	default:
+		return nil, status.Error(codes.InvalidArgument, "order_id or client_order_id required")
+	}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toOrderDetail(detail), nil
+}
+
+func (s *Server) ListOrders(
+	ctx context.Context,
+	req *tradingv1.ListOrdersRequest,
+) (*tradingv1.ListOrdersResponse, error) {
+	filter, err := fromListOrdersRequest(req)
+	if err != nil {
+		return nil, err
+	}
+	page, err := s.orderSvc.GetUserOrders(ctx, userID(ctx), filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &tradingv1.ListOrdersResponse{
+		Orders:     toOrders(page.Orders),
+		NextCursor: page.NextCursor,
+	}, nil
+}
+
+// ─── Market data ──────────────────────────────────────────────────────────────
+
+func (s *Server) GetOrderBook(
+	ctx context.Context,
+	req *tradingv1.GetOrderBookRequest,
+) (*tradingv1.OrderBook, error) {
+	if req.GetSymbol() == "" {
+		return nil, status.Error(codes.InvalidArgument, "symbol required")
+	}
+	snap, err := s.orderSvc.GetOrderBook(ctx, req.GetSymbol())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toOrderBook(*snap), nil
+}
+
+func (s *Server) SubscribeTrades(
+	req *tradingv1.SubscribeTradesRequest,
+	stream grpc.ServerStreamingServer[tradingv1.Trade],
+) error {
+	if req.GetSymbol() == "" {
+		return status.Error(codes.InvalidArgument, "symbol required")
+	}
+	events, err := s.cache.SubscribeTrades(stream.Context(), req.GetSymbol())
+	if err != nil {
+		return toStatus(err)
+	}
+	return relay(stream, events, func(e models.TradeEvent) *tradingv1.Trade {
+		return toTrade(e.Trade)
+	})
+}
+
+func (s *Server) SubscribeOrderBook(
+	req *tradingv1.SubscribeOrderBookRequest,
+	stream grpc.ServerStreamingServer[tradingv1.OrderBook],
+) error {
+	if req.GetSymbol() == "" {
+		return status.Error(codes.InvalidArgument, "symbol required")
+	}
+	snaps, err := s.cache.SubscribeOrderBook(stream.Context(), req.GetSymbol())
+	if err != nil {
+		return toStatus(err)
+	}
+	return relay(stream, snaps, toOrderBook)
+}
+
+func (s *Server) SubscribeExecutions(
+	req *tradingv1.SubscribeExecutionsRequest,
+	stream grpc.ServerStreamingServer[tradingv1.Execution],
+) error {
+	execs, err := s.orderSvc.SubscribeExecutions(stream.Context(), userID(stream.Context()))
+	if err != nil {
+		return toStatus(err)
+	}
+	return relay(stream, execs, toExecution)
+}
+
+// relay sends everything from a subscription until the client goes away
+// or the subscription ends, which it only does on its own if the cache
+// is shutting down.
+func relay[T, M any](stream grpc.ServerStreamingServer[M], updates <-chan T, convert func(T) *M) error {
+	for u := range updates {
+		if err := stream.Send(convert(u)); err != nil {
+			return err
+		}
+	}
+	if err := stream.Context().Err(); err != nil {
+		return status.FromContextError(err).Err()
+	}
+	return status.Error(codes.Unavailable, "subscription closed")
+}
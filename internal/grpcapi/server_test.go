@@ -0,0 +1,275 @@
+package grpcapi
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/cache"
+	"github.com/Im-Manav/ome/internal/config"
+	"github.com/Im-Manav/ome/internal/db"
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/internal/service"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	eventsv1 "github.com/Im-Manav/ome/pkg/pb/ome/events/v1"
+	tradingv1 "github.com/Im-Manav/ome/pkg/pb/ome/trading/v1"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// nopPublisher accepts every order without an engine behind it.
+type nopPublisher struct{ ports.EventPublisher }
+
+func (nopPublisher) PublishOrders(context.Context, []models.Order) error      { return nil }
+func (nopPublisher) PublishOrderEvent(context.Context, models.Order) error    { return nil }
+func (nopPublisher) PublishOrderEvents(context.Context, []models.Order) error { return nil }
+func (nopPublisher) PublishCancels(context.Context, []models.Order) error     { return nil }
+
+type nopBroadcaster struct{}
+
+func (nopBroadcaster) BroadcastTrade(models.TradeEvent)                  {}
+func (nopBroadcaster) BroadcastOrderBookUpdate(models.OrderBookSnapshot) {}
+
+// testEnv is the trading API served over an in-memory connection, with
+// one registered user.
+type testEnv struct {
+	client tradingv1.TradingServiceClient
+	ctx    context.Context // carries the user's token
+	mem    *cache.Memory
+	repo   *db.SQLiteRepository
+	userID uuid.UUID
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	logger.InitForTest()
+
+	database, err := db.NewSQLiteConnection(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteConnection: %v", err)
+	}
+	if err := db.Migrate(database); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	repo := db.NewSQLiteRepository(database)
+	mem := cache.NewMemory()
+	t.Cleanup(func() { mem.Close() })
+
+	cfg := &config.Config{JWTSecret: "test", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}
+	authSvc := service.NewAuthService(repo, repo, mem, cfg)
+	orderSvc := service.NewOrderService(repo, repo, nopPublisher{}, mem, nopBroadcaster{})
+	orderSvc.SetInstruments(service.NewInstrumentService(repo))
+
+	lis := bufconn.Listen(1 << 20)
+	srv := NewServer(orderSvc, authSvc, mem)
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	auth, err := authSvc.Register(context.Background(),
+		models.RegisterRequest{Email: "bot@example.com", Password: "password123"}, service.Client{})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	return &testEnv{
+		client: tradingv1.NewTradingServiceClient(conn),
+		ctx:    metadata.AppendToOutgoingContext(context.Background(), MetadataAuthorization, "Bearer "+auth.Token),
+		mem:    mem,
+		repo:   repo,
+		userID: auth.User.ID,
+	}
+}
+
+func (e *testEnv) placeLimit(t *testing.T, price float64, clientOrderID string) *tradingv1.Order {
+	t.Helper()
+	resp, err := e.client.PlaceOrder(e.ctx, &tradingv1.PlaceOrderRequest{
+		Symbol: "BTC-USD", Side: eventsv1.Side_SIDE_BUY, Type: eventsv1.OrderType_ORDER_TYPE_LIMIT,
+		Price: price, Quantity: 2, ClientOrderId: clientOrderID,
+	})
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	return resp.Order
+}
+
+func (e *testEnv) status(t *testing.T, orderID string) eventsv1.OrderStatus {
+	t.Helper()
+	resp, err := e.client.GetOrder(e.ctx, &tradingv1.GetOrderRequest{
+		Ref: &tradingv1.GetOrderRequest_OrderId{OrderId: orderID},
+	})
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	return resp.Order.Status
+}
+
+func TestOrderLifecycle(t *testing.T) {
+	env := newTestEnv(t)
+	client, ctx := env.client, env.ctx
+
+	if _, err := client.ListOrders(context.Background(), &tradingv1.ListOrdersRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("expected Unauthenticated without a token, got %v", err)
+	}
+
+	placed := env.placeLimit(t, 100, "bot-1")
+	if _, err := client.PlaceOrder(ctx, &tradingv1.PlaceOrderRequest{Symbol: "BTC-USD", Quantity: 1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("expected InvalidArgument for a limit order without a price, got %v", err)
+	}
+
+	amended, err := client.AmendOrder(ctx, &tradingv1.AmendOrderRequest{OrderId: placed.Id, Price: 101})
+	if err != nil {
+		t.Fatalf("AmendOrder: %v", err)
+	}
+	if amended.Order.Id == placed.Id || amended.Order.Price != 101 || amended.Order.Quantity != 2 {
+		t.Errorf("expected a new order at 101 for 2, got %+v", amended.Order)
+	}
+
+	original, err := client.GetOrder(ctx, &tradingv1.GetOrderRequest{
+		Ref: &tradingv1.GetOrderRequest_ClientOrderId{ClientOrderId: "bot-1"},
+	})
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if original.Order.Status != eventsv1.OrderStatus_ORDER_STATUS_CANCELLED {
+		t.Errorf("expected the amended order to be cancelled, got %v", original.Order.Status)
+	}
+
+	if _, err := client.CancelOrder(ctx, &tradingv1.CancelOrderRequest{
+		Ref: &tradingv1.CancelOrderRequest_OrderId{OrderId: amended.Order.Id},
+	}); err != nil {
+		t.Fatalf("CancelOrder: %v", err)
+	}
+	if _, err := client.CancelOrder(ctx, &tradingv1.CancelOrderRequest{
+		Ref: &tradingv1.CancelOrderRequest_OrderId{OrderId: uuid.NewString()},
+	}); status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound for an unknown order, got %v", err)
+	}
+
+	page, err := client.ListOrders(ctx, &tradingv1.ListOrdersRequest{
+		Statuses: []eventsv1.OrderStatus{eventsv1.OrderStatus_ORDER_STATUS_CANCELLED},
+	})
+	if err != nil {
+		t.Fatalf("ListOrders: %v", err)
+	}
+	if len(page.Orders) != 2 {
+		t.Errorf("expected both orders cancelled, got %d", len(page.Orders))
+	}
+}
+
+func TestFailedAmendKeepsOriginal(t *testing.T) {
+	env := newTestEnv(t)
+	taken := env.placeLimit(t, 90, "bot-taken")
+	order := env.placeLimit(t, 100, "bot-1")
+
+	_, err := env.client.AmendOrder(env.ctx, &tradingv1.AmendOrderRequest{
+		OrderId: order.Id, Price: 101, ClientOrderId: taken.ClientOrderId,
+	})
+	if status.Code(err) != codes.AlreadyExists {
+		t.Errorf("amend reusing a client order id: expected AlreadyExists, got %v", err)
+	}
+	if got := env.status(t, order.Id); got != eventsv1.OrderStatus_ORDER_STATUS_OPEN {
+		t.Errorf("after a duplicate client order id: expected the original open, got %v", got)
+	}
+
+	halted := models.NewInstrument("BTC-USD")
+	halted.Status = models.InstrumentHalted
+	if err := env.repo.UpsertInstrument(&halted); err != nil {
+		t.Fatalf("UpsertInstrument: %v", err)
+	}
+	_, err = env.client.AmendOrder(env.ctx, &tradingv1.AmendOrderRequest{OrderId: order.Id, Price: 101})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("amend on a halted symbol: expected InvalidArgument, got %v", err)
+	}
+	if got := env.status(t, order.Id); got != eventsv1.OrderStatus_ORDER_STATUS_OPEN {
+		t.Errorf("after a halted symbol: expected the original open, got %v", got)
+	}
+}
+
+func TestFillWithoutTakerSideIsUnknown(t *testing.T) {
+	env := newTestEnv(t)
+	placed := env.placeLimit(t, 100, "")
+	buyID := uuid.MustParse(placed.Id)
+
+	// A trade recorded before the taker side was.
+	if err := env.repo.SaveTrades([]models.Trade{{
+		ID: uuid.New(), Symbol: "BTC-USD", BuyOrderID: buyID, SellOrderID: uuid.New(),
+		BuyUserID: env.userID, SellUserID: uuid.New(), Price: 100, Quantity: 1, ExecutedAt: time.Now(),
+	}}); err != nil {
+		t.Fatalf("SaveTrades: %v", err)
+	}
+
+	detail, err := env.client.GetOrder(env.ctx, &tradingv1.GetOrderRequest{
+		Ref: &tradingv1.GetOrderRequest_OrderId{OrderId: placed.Id},
+	})
+	if err != nil {
+		t.Fatalf("GetOrder: %v", err)
+	}
+	if len(detail.Fills) != 1 {
+		t.Fatalf("expected 1 fill, got %d", len(detail.Fills))
+	}
+	fill := detail.Fills[0]
+	if fill.Liquidity != tradingv1.Liquidity_LIQUIDITY_UNSPECIFIED || fill.Fee != nil ||
+		fill.Trade.TakerSide != nil || detail.Fees != nil {
+		t.Errorf("expected unknown liquidity and fees, got %+v (fees %v)", fill, detail.Fees)
+	}
+}
+
+func TestSubscribeExecutionsOnlySendsOwnFills(t *testing.T) {
+	env := newTestEnv(t)
+	client, mem, userID := env.client, env.mem, env.userID
+	ctx, cancel := context.WithTimeout(env.ctx, 5*time.Second)
+	defer cancel()
+
+	stream, err := client.SubscribeExecutions(ctx, &tradingv1.SubscribeExecutionsRequest{})
+	if err != nil {
+		t.Fatalf("SubscribeExecutions: %v", err)
+	}
+
+	// Someone else's taker order fills the user's resting sell.
+	resting := uuid.New()
+	other := models.Execution{Order: models.Order{ID: uuid.New(), UserID: uuid.New(), Side: models.Buy}}
+	mine := other
+	taker := models.Buy
+	mine.Trades = []models.Trade{{
+		ID: uuid.New(), BuyOrderID: other.Order.ID, SellOrderID: resting,
+		BuyUserID: other.Order.UserID, SellUserID: userID, Price: 100, Quantity: 1, TakerSide: &taker,
+	}}
+
+	// The server subscribes after the stream opens; keep publishing
+	// until it does.
+	go func() {
+		for ctx.Err() == nil {
+			mem.PublishExecution(ctx, other)
+			mem.PublishExecution(ctx, mine)
+			time.Sleep(10 * time.Millisecond)
+		}
+	}()
+
+	exec, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("Recv: %v", err)
+	}
+	if exec.Order != nil || len(exec.Fills) != 1 {
+		t.Fatalf("expected only the user's fill, got %+v", exec)
+	}
+	fill := exec.Fills[0]
+	if fill.OrderId != resting.String() || fill.Side != eventsv1.Side_SIDE_SELL ||
+		fill.Liquidity != tradingv1.Liquidity_LIQUIDITY_MAKER {
+		t.Errorf("expected a maker sell on the resting order, got %+v", fill)
+	}
+}
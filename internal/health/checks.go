@@ -0,0 +1,96 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	kafkago "github.com/segmentio/kafka-go"
+	"gorm.io/gorm"
+)
+
+// ─── Dependencies ─────────────────────────────────────────────────────────────
+
+// Database pings through the connection pool, so a pool whose
+// connections are all broken or exhausted fails the check.
+func Database(db *gorm.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		if err := sqlDB.PingContext(ctx); err != nil {
+			return err
+		}
+		if stats := sqlDB.Stats(); stats.MaxOpenConnections > 0 && stats.InUse >= stats.MaxOpenConnections {
+			return fmt.Errorf("pool exhausted: %d/%d connections in use", stats.InUse, stats.MaxOpenConnections)
+		}
+		return nil
+	}
+}
+
+// Pinger is implemented by the cache clients.
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// Cache pings the cache backend.
+func Cache(p Pinger) CheckFunc {
+	return p.Ping
+}
+
+// KafkaBrokers passes when at least one broker accepts a connection —
+// clients bootstrap the rest of the cluster from any one of them.
+func KafkaBrokers(brokers []string) CheckFunc {
+	return func(ctx context.Context) error {
+		var errs []string
+		for _, addr := range brokers {
+			conn, err := kafkago.DialContext(ctx, "tcp", addr)
+			if err == nil {
+				return conn.Close()
+			}
+			errs = append(errs, fmt.Sprintf("%s: %v", addr, err))
+		}
+		if len(errs) == 0 {
+			return errors.New("no brokers configured")
+		}
+		return errors.New(strings.Join(errs, "; "))
+	}
+}
+
+// ConsumerLag fails when the consumer is more than max messages behind.
+func ConsumerLag(lag func() int64, max int64) CheckFunc {
+	return func(context.Context) error {
+		if n := lag(); n > max {
+			return fmt.Errorf("lag %d exceeds %d", n, max)
+		}
+		return nil
+	}
+}
+
+// ─── Startup ──────────────────────────────────────────────────────────────────
+
+// Gate fails until Open is called — for startup work such as rebuilding
+// the order books that must finish before the service takes traffic.
+type Gate struct {
+	open   atomic.Bool
+	reason string
+}
+
+// NewGate returns a closed gate that reports reason while closed.
+func NewGate(reason string) *Gate {
+	return &Gate{reason: reason}
+}
+
+func (g *Gate) Open() {
+	g.open.Store(true)
+}
+
+func (g *Gate) Check(context.Context) error {
+	if !g.open.Load() {
+		return errors.New(g.reason)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+// Package health runs liveness and readiness checks and serves them as
+// /livez and /readyz. Liveness answers "should this process be
+// restarted", readiness "should it get traffic" — so readiness covers
+// the dependencies and liveness only the process itself.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+
+	// DefaultTimeout bounds each check so one hung dependency can't
+	// hold the probe past the orchestrator's own timeout.
+	DefaultTimeout = 2 * time.Second
+)
+
+// CheckFunc returns nil when the thing it checks is healthy.
+type CheckFunc func(ctx context.Context) error
+
+// Result is the outcome of one check.
+type Result struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	LatencyMs float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is what /livez and /readyz return. Status is ok only when
+// every check passed.
+type Report struct {
+	Status string   `json:"status"`
+	Checks []Result `json:"checks"`
+}
+
+type check struct {
+	name string
+	fn   CheckFunc
+}
+
+// Checker holds the checks for one service.
+type Checker struct {
+	mu        sync.RWMutex
+	liveness  []check
+	readiness []check
+	timeout   time.Duration
+}
+
+func New() *Checker {
+	return &Checker{timeout: DefaultTimeout}
+}
+
+// AddLiveness registers a check that, when failing, means the process
+// is wedged and should be restarted. Keep these to in-process state —
+// a database outage must not restart every pod.
+func (c *Checker) AddLiveness(name string, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.liveness = append(c.liveness, check{name: name, fn: fn})
+}
+
+// AddReadiness registers a check that must pass before the service
+// takes traffic.
+func (c *Checker) AddReadiness(name string, fn CheckFunc) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.readiness = append(c.readiness, check{name: name, fn: fn})
+}
+
+// Live runs the liveness checks.
+func (c *Checker) Live(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := c.liveness
+	c.mu.RUnlock()
+	return c.run(ctx, checks)
+}
+
+// Ready runs the liveness and readiness checks — a process that isn't
+// live isn't ready either.
+func (c *Checker) Ready(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := append(append([]check(nil), c.liveness...), c.readiness...)
+	c.mu.RUnlock()
+	return c.run(ctx, checks)
+}
+
+// run executes checks concurrently, each under its own timeout.
+func (c *Checker) run(ctx context.Context, checks []check) Report {
+	report := Report{Status: StatusOK, Checks: make([]Result, len(checks))}
+
+	var wg sync.WaitGroup
+	for i, ch := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			report.Checks[i] = c.runOne(ctx, ch)
+		}()
+	}
+	wg.Wait()
+
+	for _, r := range report.Checks {
+		if r.Status != StatusOK {
+			report.Status = StatusFail
+		}
+	}
+	return report
+}
+
+func (c *Checker) runOne(ctx context.Context, ch check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	// A check that ignores ctx (e.g. waiting on a lock) still can't
+	// block the probe — its result is abandoned at the deadline.
+	done := make(chan error, 1)
+	start := time.Now()
+	go func() { done <- ch.fn(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	r := Result{
+		Name:      ch.name,
+		Status:    StatusOK,
+		LatencyMs: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		r.Status = StatusFail
+		r.Error = err.Error()
+	}
+	return r
+}
+
+// LiveHandler serves the liveness report: 200 when ok, 503 otherwise.
+func (c *Checker) LiveHandler(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, c.Live(r.Context()))
+}
+
+// ReadyHandler serves the readiness report: 200 when ok, 503 otherwise.
+func (c *Checker) ReadyHandler(w http.ResponseWriter, r *http.Request) {
+	writeReport(w, c.Ready(r.Context()))
+}
+
+func writeReport(w http.ResponseWriter, report Report) {
+	code := http.StatusOK
+	if report.Status != StatusOK {
+		code = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(report)
+}
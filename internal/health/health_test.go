@@ -0,0 +1,54 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestReadyReportsEveryCheck(t *testing.T) {
+	c := New()
+	c.timeout = 50 * time.Millisecond
+
+	gate := NewGate("warming up")
+	c.AddLiveness("process", func(context.Context) error { return nil })
+	c.AddReadiness("warmup", gate.Check)
+	c.AddReadiness("hung", func(context.Context) error { select {} })
+
+	if live := c.Live(context.Background()); live.Status != StatusOK {
+		t.Fatalf("expected live, got %+v", live)
+	}
+
+	rec := httptest.NewRecorder()
+	c.ReadyHandler(rec, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", rec.Code)
+	}
+
+	var report Report
+	if err := json.Unmarshal(rec.Body.Bytes(), &report); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(report.Checks) != 3 {
+		t.Fatalf("expected 3 checks, got %+v", report.Checks)
+	}
+	byName := map[string]Result{}
+	for _, r := range report.Checks {
+		byName[r.Name] = r
+	}
+	if byName["process"].Status != StatusOK || byName["warmup"].Error != "warming up" {
+		t.Errorf("unexpected results: %+v", report.Checks)
+	}
+	if hung := byName["hung"]; hung.Error != context.DeadlineExceeded.Error() || hung.Status != StatusFail || hung.LatencyMs < 50 {
+		t.Errorf("expected hung check to time out, got %+v", hung)
+	}
+
+	gate.Open()
+	c.readiness = c.readiness[:1]
+	if ready := c.Ready(context.Background()); ready.Status != StatusOK {
+		t.Errorf("expected ready once the gate opens, got %+v", ready)
+	}
+}
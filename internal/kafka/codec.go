@@ -0,0 +1,141 @@
+package kafka
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Content types carried in the content-type header. A message without
+// the header is JSON — that's what every producer wrote before codecs
+// became configurable.
+const (
+	HeaderContentType   = "content-type"
+	ContentTypeJSON     = "application/json"
+	ContentTypeProtobuf = "application/x-protobuf"
+)
+
+// Codec encodes envelopes and their payloads for the wire.
+// Producers pick one per topic; consumers pick one per message from
+// the content-type header, so both formats can share a topic while
+// services migrate.
+type Codec interface {
+	ContentType() string
+	EncodeEnvelope(env *Envelope) ([]byte, error)
+	DecodeEnvelope(data []byte) (*Envelope, error)
+	EncodePayload(v any) ([]byte, error)
+	DecodePayload(data []byte, v any) error
+}
+
+var (
+	JSONCodec     Codec = jsonCodec{}
+	ProtobufCodec Codec = protobufCodec{}
+)
+
+// CodecByName resolves a codec from its config name.
+func CodecByName(name string) (Codec, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "json":
+		return JSONCodec, nil
+	case "protobuf", "proto":
+		return ProtobufCodec, nil
+	default:
+		return nil, fmt.Errorf("unknown kafka codec %q", name)
+	}
+}
+
+func codecForContentType(contentType string) (Codec, error) {
+	switch contentType {
+	case "", ContentTypeJSON:
+		return JSONCodec, nil
+	case ContentTypeProtobuf:
+		return ProtobufCodec, nil
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", contentType)
+	}
+}
+
+// TopicCodecs selects the producer codec per topic.
+// Topics without an entry are written as JSON.
+type TopicCodecs map[string]Codec
+
+// ParseTopicCodecs builds TopicCodecs from config, e.g.
+// {"orders": "protobuf", "trades": "json"}.
+func ParseTopicCodecs(spec map[string]string) (TopicCodecs, error) {
+	codecs := make(TopicCodecs, len(spec))
+	for topic, name := range spec {
+		codec, err := CodecByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("topic %s: %w", topic, err)
+		}
+		codecs[topic] = codec
+	}
+	return codecs, nil
+}
+
+func (tc TopicCodecs) For(topic string) Codec {
+	if codec, ok := tc[topic]; ok {
+		return codec
+	}
+	return JSONCodec
+}
+
+// ─── JSON ─────────────────────────────────────────────────────────────────────
+
+type jsonCodec struct{}
+
+// jsonEnvelope is the JSON body shape of an Envelope.
+// The payload is embedded as a JSON value rather than a string.
+type jsonEnvelope struct {
+	ID         uuid.UUID       `json:"id"`
+	Type       string          `json:"type"`
+	Version    SchemaVersion   `json:"version"`
+	Source     string          `json:"source"`
+	Epoch      uint64          `json:"epoch,omitempty"`
+	Sequence   uint64          `json:"sequence"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	ProducedAt time.Time       `json:"produced_at"`
+	Payload    json.RawMessage `json:"payload"`
+}
+
+func (jsonCodec) ContentType() string { return ContentTypeJSON }
+
+func (jsonCodec) EncodeEnvelope(env *Envelope) ([]byte, error) {
+	return json.Marshal(jsonEnvelope{
+		ID:         env.ID,
+		Type:       env.Type,
+		Version:    env.Version,
+		Source:     env.Source,
+		Epoch:      env.Epoch,
+		Sequence:   env.Sequence,
+		OccurredAt: env.OccurredAt,
+		ProducedAt: env.ProducedAt,
+		Payload:    env.Payload,
+	})
+}
+
+func (jsonCodec) DecodeEnvelope(data []byte) (*Envelope, error) {
+	var je jsonEnvelope
+	if err := json.Unmarshal(data, &je); err != nil {
+		return nil, fmt.Errorf("unmarshal envelope: %w", err)
+	}
+	return &Envelope{
+		ID:         je.ID,
+		Type:       je.Type,
+		Version:    je.Version,
+		Source:     je.Source,
+		Epoch:      je.Epoch,
+		Sequence:   je.Sequence,
+		OccurredAt: je.OccurredAt,
+		ProducedAt: je.ProducedAt,
+		Payload:    je.Payload,
+		codec:      JSONCodec,
+	}, nil
+}
+
+func (jsonCodec) EncodePayload(v any) ([]byte, error) { return json.Marshal(v) }
+
+func (jsonCodec) DecodePayload(data []byte, v any) error { return json.Unmarshal(data, v) }
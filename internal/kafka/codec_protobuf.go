@@ -0,0 +1,214 @@
+package kafka
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/models"
+	eventsv1 "github.com/Im-Manav/ome/pkg/pb/ome/events/v1"
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// protobufCodec encodes events with the messages generated from
+// proto/ome/events/v1/events.proto. Order and TradeEvent are the only
+// payload types on the wire, so the conversions are written out by hand
+// rather than going through reflection.
+type protobufCodec struct{}
+
+func (protobufCodec) ContentType() string { return ContentTypeProtobuf }
+
+func (protobufCodec) EncodeEnvelope(env *Envelope) ([]byte, error) {
+	return proto.Marshal(&eventsv1.Envelope{
+		Id:         env.ID[:],
+		Type:       env.Type,
+		Version:    env.Version.String(),
+		Source:     env.Source,
+		Epoch:      env.Epoch,
+		Sequence:   env.Sequence,
+		OccurredAt: timestamppb.New(env.OccurredAt),
+		ProducedAt: timestamppb.New(env.ProducedAt),
+		Payload:    env.Payload,
+	})
+}
+
+func (protobufCodec) DecodeEnvelope(data []byte) (*Envelope, error) {
+	var pe eventsv1.Envelope
+	if err := proto.Unmarshal(data, &pe); err != nil {
+		return nil, fmt.Errorf("unmarshal envelope: %w", err)
+	}
+	version, err := ParseSchemaVersion(pe.Version)
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuidFromBytes(pe.Id)
+	if err != nil {
+		return nil, fmt.Errorf("envelope id: %w", err)
+	}
+	return &Envelope{
+		ID:         id,
+		Type:       pe.Type,
+		Version:    version,
+		Source:     pe.Source,
+		Epoch:      pe.Epoch,
+		Sequence:   pe.Sequence,
+		OccurredAt: timeFromProto(pe.OccurredAt),
+		ProducedAt: timeFromProto(pe.ProducedAt),
+		Payload:    pe.Payload,
+		codec:      ProtobufCodec,
+	}, nil
+}
+
+func (protobufCodec) EncodePayload(v any) ([]byte, error) {
+	switch p := v.(type) {
+	case models.Order:
+		return proto.Marshal(orderToProto(&p))
+	case *models.Order:
+		return proto.Marshal(orderToProto(p))
+	case models.TradeEvent:
+		return proto.Marshal(tradeEventToProto(&p))
+	case *models.TradeEvent:
+		return proto.Marshal(tradeEventToProto(p))
+	default:
+		return nil, fmt.Errorf("protobuf codec: unsupported payload %T", v)
+	}
+}
+
+func (protobufCodec) DecodePayload(data []byte, v any) error {
+	switch p := v.(type) {
+	case *models.Order:
+		var po eventsv1.Order
+		if err := proto.Unmarshal(data, &po); err != nil {
+			return err
+		}
+		return orderFromProto(&po, p)
+	case *models.TradeEvent:
+		var pt eventsv1.TradeEvent
+		if err := proto.Unmarshal(data, &pt); err != nil {
+			return err
+		}
+		return tradeEventFromProto(&pt, p)
+	default:
+		return fmt.Errorf("protobuf codec: unsupported payload %T", v)
+	}
+}
+
+// ─── Conversions ──────────────────────────────────────────────────────────────
+
+func orderToProto(o *models.Order) *eventsv1.Order {
+	return &eventsv1.Order{
+		Id:            o.ID[:],
+		UserId:        o.UserID[:],
+		ClientOrderId: o.ClientOrderID,
+		Symbol:        o.Symbol,
+		Side:          eventsv1.Side(o.Side),
+		Type:          eventsv1.OrderType(o.Type),
+		Price:         o.Price,
+		Quantity:      o.Quantity,
+		FilledQty:     o.FilledQty,
+		RemainingQty:  o.RemainingQty,
+		Status:        eventsv1.OrderStatus(o.Status),
+		CreatedAt:     timestamppb.New(o.CreatedAt),
+		UpdatedAt:     timestamppb.New(o.UpdatedAt),
+	}
+}
+
+func orderFromProto(po *eventsv1.Order, o *models.Order) error {
+	var err error
+	if o.ID, err = uuidFromBytes(po.Id); err != nil {
+		return fmt.Errorf("order id: %w", err)
+	}
+	if o.UserID, err = uuidFromBytes(po.UserId); err != nil {
+		return fmt.Errorf("order user id: %w", err)
+	}
+	o.ClientOrderID = po.ClientOrderId
+	o.Symbol = po.Symbol
+	o.Side = models.Side(po.Side)
+	o.Type = models.OrderType(po.Type)
+	o.Price = po.Price
+	o.Quantity = po.Quantity
+	o.FilledQty = po.FilledQty
+	o.RemainingQty = po.RemainingQty
+	o.Status = models.OrderStatus(po.Status)
+	o.CreatedAt = timeFromProto(po.CreatedAt)
+	o.UpdatedAt = timeFromProto(po.UpdatedAt)
+	return nil
+}
+
+func tradeEventToProto(e *models.TradeEvent) *eventsv1.TradeEvent {
+	return &eventsv1.TradeEvent{
+		Trade: &eventsv1.Trade{
+			Id:          e.ID[:],
+			Symbol:      e.Symbol,
+			BuyOrderId:  e.BuyOrderID[:],
+			SellOrderId: e.SellOrderID[:],
+			BuyUserId:   e.BuyUserID[:],
+			SellUserId:  e.SellUserID[:],
+			Price:       e.Price,
+			Quantity:    e.Quantity,
+			ExecutedAt:  timestamppb.New(e.ExecutedAt),
+			TakerSide:   sideToProto(e.TakerSide),
+		},
+		BuyerFilled:  e.BuyerFilled,
+		SellerFilled: e.SellerFilled,
+	}
+}
+
+func tradeEventFromProto(pe *eventsv1.TradeEvent, e *models.TradeEvent) error {
+	pt := pe.GetTrade()
+	ids := []struct {
+		dst *uuid.UUID
+		src []byte
+	}{
+		{&e.ID, pt.GetId()},
+		{&e.BuyOrderID, pt.GetBuyOrderId()},
+		{&e.SellOrderID, pt.GetSellOrderId()},
+		{&e.BuyUserID, pt.GetBuyUserId()},
+		{&e.SellUserID, pt.GetSellUserId()},
+	}
+	for _, id := range ids {
+		parsed, err := uuidFromBytes(id.src)
+		if err != nil {
+			return fmt.Errorf("trade event: %w", err)
+		}
+		*id.dst = parsed
+	}
+	e.Symbol = pt.GetSymbol()
+	e.Price = pt.GetPrice()
+	e.Quantity = pt.GetQuantity()
+	e.ExecutedAt = timeFromProto(pt.GetExecutedAt())
+	if pt.TakerSide != nil {
+		taker := models.Side(pt.GetTakerSide())
+		e.TakerSide = &taker
+	}
+	e.BuyerFilled = pe.BuyerFilled
+	e.SellerFilled = pe.SellerFilled
+	return nil
+}
+
+// sideToProto converts an optional side; nil stays unset.
+func sideToProto(side *models.Side) *eventsv1.Side {
+	if side == nil {
+		return nil
+	}
+	return eventsv1.Side(*side).Enum()
+}
+
+// uuidFromBytes accepts an empty slice as the nil UUID so zero-valued
+// fields round-trip the same way they do in JSON.
+func uuidFromBytes(b []byte) (uuid.UUID, error) {
+	if len(b) == 0 {
+		return uuid.Nil, nil
+	}
+	return uuid.FromBytes(b)
+}
+
+// timeFromProto maps an unset timestamp back to the zero time.Time
+// instead of the Unix epoch.
+func timeFromProto(ts *timestamppb.Timestamp) time.Time {
+	if ts == nil {
+		return time.Time{}
+	}
+	return ts.AsTime()
+}
@@ -0,0 +1,139 @@
+package kafka
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+func newTestTradeEvent() models.TradeEvent {
+	taker := models.Sell
+	return models.TradeEvent{
+		Trade: models.Trade{
+			ID:          uuid.New(),
+			Symbol:      "ETH-USD",
+			BuyOrderID:  uuid.New(),
+			SellOrderID: uuid.New(),
+			BuyUserID:   uuid.New(),
+			SellUserID:  uuid.New(),
+			Price:       3200.5,
+			Quantity:    0.25,
+			ExecutedAt:  time.Now().UTC(),
+			TakerSide:   &taker,
+		},
+		BuyerFilled: true,
+	}
+}
+
+func TestProtobufRoundTrip(t *testing.T) {
+	event := newTestTradeEvent()
+	env, err := newEnvelope(ProtobufCodec, EventTradeExecuted, SourceEngine, 3, event.ExecutedAt, event)
+	if err != nil {
+		t.Fatalf("newEnvelope: %v", err)
+	}
+	env.Epoch = 42
+
+	got, err := DecodeEnvelope(envelopeMessage(t, env, TopicTrades))
+	if err != nil {
+		t.Fatalf("DecodeEnvelope: %v", err)
+	}
+	if got.ID != env.ID || got.Epoch != 42 || got.Sequence != 3 || got.Version != env.Version {
+		t.Errorf("metadata mismatch: %+v", got)
+	}
+
+	var decoded models.TradeEvent
+	if err := got.Decode(&decoded); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if decoded.ID != event.ID || decoded.BuyOrderID != event.BuyOrderID ||
+		decoded.Price != event.Price || !decoded.ExecutedAt.Equal(event.ExecutedAt) ||
+		decoded.BuyerFilled != event.BuyerFilled || decoded.TakerSide == nil || *decoded.TakerSide != *event.TakerSide {
+		t.Errorf("expected %+v, got %+v", event, decoded)
+	}
+}
+
+func TestMixedContentTypesOnOneTopic(t *testing.T) {
+	order := newTestOrder(models.StatusOpen)
+	for _, codec := range []Codec{JSONCodec, ProtobufCodec} {
+		env, err := newEnvelope(codec, EventOrderPlaced, SourceGateway, 1, order.CreatedAt, order)
+		if err != nil {
+			t.Fatalf("%s: newEnvelope: %v", codec.ContentType(), err)
+		}
+		got, err := DecodeEnvelope(envelopeMessage(t, env, TopicOrders))
+		if err != nil {
+			t.Fatalf("%s: DecodeEnvelope: %v", codec.ContentType(), err)
+		}
+		var decoded models.Order
+		if err := got.Decode(&decoded); err != nil {
+			t.Fatalf("%s: Decode: %v", codec.ContentType(), err)
+		}
+		if decoded.ID != order.ID || decoded.Price != order.Price {
+			t.Errorf("%s: expected %+v, got %+v", codec.ContentType(), order, decoded)
+		}
+	}
+}
+
+func TestProtobufOrderRoundTrip(t *testing.T) {
+	order := newTestOrder(models.StatusPartial)
+	order.ClientOrderID = "bot-42"
+	order.FilledQty, order.RemainingQty = 0.4, 0.6
+	order.UpdatedAt = order.CreatedAt.Add(time.Second)
+
+	env, err := newEnvelope(ProtobufCodec, EventOrderPlaced, SourceGateway, 1, order.CreatedAt, order)
+	if err != nil {
+		t.Fatalf("newEnvelope: %v", err)
+	}
+	got, err := DecodeEnvelope(envelopeMessage(t, env, TopicOrders))
+	if err != nil {
+		t.Fatalf("DecodeEnvelope: %v", err)
+	}
+	var decoded models.Order
+	if err := got.Decode(&decoded); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if decoded.ClientOrderID != order.ClientOrderID || decoded.UserID != order.UserID ||
+		decoded.Status != order.Status || decoded.FilledQty != order.FilledQty ||
+		decoded.RemainingQty != order.RemainingQty || !decoded.UpdatedAt.Equal(order.UpdatedAt) {
+		t.Errorf("expected %+v, got %+v", order, decoded)
+	}
+}
+
+func BenchmarkCodecs(b *testing.B) {
+	order := newTestOrder(models.StatusOpen)
+	for _, codec := range []Codec{JSONCodec, ProtobufCodec} {
+		env, _ := newEnvelope(codec, EventOrderPlaced, SourceGateway, 1, order.CreatedAt, order)
+		data, _ := codec.EncodeEnvelope(env)
+		msg := kafkago.Message{
+			Topic:   TopicOrders,
+			Value:   data,
+			Headers: env.headers(),
+		}
+
+		b.Run(codec.ContentType()+"/encode", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				env, _ := newEnvelope(codec, EventOrderPlaced, SourceGateway, 1, order.CreatedAt, order)
+				if _, err := codec.EncodeEnvelope(env); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run(codec.ContentType()+"/decode", func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				env, err := DecodeEnvelope(msg)
+				if err != nil {
+					b.Fatal(err)
+				}
+				var o models.Order
+				if err := env.Decode(&o); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
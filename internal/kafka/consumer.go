@@ -2,26 +2,60 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"strconv"
+	"sync"
 	"time"
 
 	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/internal/metrics"
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/internal/tracing"
 	"github.com/Im-Manav/ome/pkg/logger"
 	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
 	kafkago "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+// MessageReader is the part of *kafkago.Reader the consumers use.
+// Lets the in-memory event bus stand in for a real broker.
+type MessageReader interface {
+	FetchMessage(ctx context.Context) (kafkago.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafkago.Message) error
+	Close() error
+}
+
+// dedupeWindow is how many recent order IDs the order consumer
+// remembers to drop redeliveries.
+const dedupeWindow = 100_000
+
+// clockSkew is how far the gateway's clock may run behind the engine's.
+// Orders created up to this long after the consumer started are still
+// checked against the database, in case an earlier process matched them.
+const clockSkew = time.Minute
+
 type OrderConsumer struct {
-	reader   *kafkago.Reader
-	matcher  *engine.Matcher
-	producer *Producer
-	handlers []PostMatchHandler
+	reader    MessageReader
+	matcher   *engine.Matcher
+	publisher ports.EventPublisher
+	handlers  []PostMatchHandler
+	cancels   []CancelHandler
+	lag       lagTracker
+	seen      *recentIDs
+	orders    ports.OrderRepository
+	started   time.Time
 }
 
 type PostMatchHandler func(ctx context.Context, order models.Order, trades []models.Trade) error
 
+// CancelHandler runs after a cancelled order is taken out of its book.
+type CancelHandler func(ctx context.Context, order models.Order) error
+
 func NewOrderConsumer(
 	brokers []string,
 	groupID string,
@@ -39,10 +73,22 @@ func NewOrderConsumer(
 		CommitInterval: 0,
 	})
 
+	return NewOrderConsumerFromReader(reader, matcher, producer)
+}
+
+// NewOrderConsumerFromReader creates an order consumer over any reader,
+// e.g. one from the in-memory event bus.
+func NewOrderConsumerFromReader(
+	reader MessageReader,
+	matcher *engine.Matcher,
+	publisher ports.EventPublisher,
+) *OrderConsumer {
 	return &OrderConsumer{
-		reader:   reader,
-		matcher:  matcher,
-		producer: producer,
+		reader:    reader,
+		matcher:   matcher,
+		publisher: publisher,
+		seen:      newRecentIDs(dedupeWindow),
+		started:   time.Now(),
 	}
 }
 
@@ -50,6 +96,18 @@ func (c *OrderConsumer) AddHandler(h PostMatchHandler) {
 	c.handlers = append(c.handlers, h)
 }
 
+// AddCancelHandler registers a handler for orders removed from a book
+// by a cancel request.
+func (c *OrderConsumer) AddCancelHandler(h CancelHandler) {
+	c.cancels = append(c.cancels, h)
+}
+
+// SetOrders lets the consumer drop orders an earlier engine process
+// already matched, which its in-memory window has no record of.
+func (c *OrderConsumer) SetOrders(orders ports.OrderRepository) {
+	c.orders = orders
+}
+
 func (c *OrderConsumer) Start(ctx context.Context) error {
 	logger.Info("kafka order consumer started",
 		zap.String("topic", TopicOrders),
@@ -58,13 +116,14 @@ func (c *OrderConsumer) Start(ctx context.Context) error {
 	for {
 		msg, err := c.reader.FetchMessage(ctx)
 		if err != nil {
-			if ctx.Err() != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
 				logger.Info("kafka consumer shutting down")
 				return nil
 			}
 			logger.Error("kafka fetch message failed", logger.Err(err))
 			continue
 		}
+		c.lag.record(msg)
 
 		if err := c.processMessage(ctx, msg); err != nil {
 			logger.Error("kafka process message failed",
@@ -80,10 +139,52 @@ func (c *OrderConsumer) Start(ctx context.Context) error {
 	}
 }
 
-func (c *OrderConsumer) processMessage(ctx context.Context, msg kafkago.Message) error {
+func (c *OrderConsumer) processMessage(ctx context.Context, msg kafkago.Message) (err error) {
+	ctx, span := startConsumerSpan(ctx, msg)
+	defer func() {
+		tracing.RecordError(span, err)
+		span.End()
+	}()
+
+	env, err := DecodeEnvelope(msg)
+	if err != nil {
+		return fmt.Errorf("decode order envelope: %w", err)
+	}
+	switch env.Type {
+	case EventOrderPlaced:
+	case EventOrderCancelRequested:
+		return c.processCancel(ctx, env)
+	default:
+		logger.Warn("skipping unexpected event on orders topic",
+			zap.String("event_type", env.Type),
+			zap.String("event_id", env.ID.String()),
+		)
+		return nil
+	}
+
 	var order models.Order
-	if err := json.Unmarshal(msg.Value, &order); err != nil {
-		return fmt.Errorf("unmarshal order: %w", err)
+	if err := env.Decode(&order); err != nil {
+		return err
+	}
+
+	// Orders are delivered at least once — the gateway's outbox relay
+	// republishes a batch if it crashes before marking it sent. Matching
+	// a repeat would fill the order twice.
+	switch c.skip(order) {
+	case skipDuplicate:
+		metrics.DuplicateOrders.Inc()
+		logger.Warn("skipping duplicate order",
+			zap.String("order_id", order.ID.String()),
+			zap.String("event_id", env.ID.String()),
+		)
+		return nil
+	case skipCancelled:
+		metrics.CancelledOrdersSkipped.Inc()
+		logger.Info("skipping order cancelled before matching",
+			zap.String("order_id", order.ID.String()),
+			zap.String("event_id", env.ID.String()),
+		)
+		return nil
 	}
 
 	logger.Info("processing order",
@@ -94,7 +195,19 @@ func (c *OrderConsumer) processMessage(ctx context.Context, msg kafkago.Message)
 		zap.Float64("quantity", order.Quantity),
 	)
 
+	_, matchSpan := tracing.Start(ctx, "engine.Match", trace.WithAttributes(
+		attribute.String("ome.order_id", order.ID.String()),
+		attribute.String("ome.symbol", order.Symbol),
+	))
+	start := time.Now()
 	trades := c.matcher.Match(&order)
+	metrics.MatchDuration.Observe(time.Since(start).Seconds())
+	matchSpan.SetAttributes(
+		attribute.Int("ome.trades", len(trades)),
+		attribute.String("ome.order_status", order.Status.String()),
+	)
+	matchSpan.End()
+	recordMatch(order, trades)
 
 	logger.Info("match complete",
 		zap.String("order_id", order.ID.String()),
@@ -108,7 +221,7 @@ func (c *OrderConsumer) processMessage(ctx context.Context, msg kafkago.Message)
 			BuyerFilled:  isFilled(order, trade, models.Buy),
 			SellerFilled: isFilled(order, trade, models.Sell),
 		}
-		if err := c.producer.PublishTradeEvent(ctx, event); err != nil {
+		if err := c.publisher.PublishTradeEvent(ctx, event); err != nil {
 			logger.Error("failed to publish trade event",
 				logger.Err(err),
 				zap.String("trade_id", trade.ID.String()),
@@ -116,18 +229,171 @@ func (c *OrderConsumer) processMessage(ctx context.Context, msg kafkago.Message)
 		}
 	}
 
-	if err := c.producer.PublishOrderEvent(ctx, order); err != nil {
+	if err := c.publisher.PublishOrderEvent(ctx, order); err != nil {
 		logger.Error("failed to publish order event", logger.Err(err))
 	}
 
-	for _, handler := range c.handlers {
-		if err := handler(ctx, order, trades); err != nil {
+	for i, handler := range c.handlers {
+		err := tracing.WithSpan(ctx, fmt.Sprintf("PostMatchHandler[%d]", i), func(ctx context.Context) error {
+			return handler(ctx, order, trades)
+		})
+		if err != nil {
 			logger.Error("post-match handler failed", logger.Err(err))
 		}
 	}
 	return nil
 }
 
+// processCancel takes a cancelled order out of its book. The gateway
+// has already cancelled it in the database. A cancel for an order that
+// isn't resting — filled first, or removed by an earlier delivery of
+// the same cancel — changes nothing.
+func (c *OrderConsumer) processCancel(ctx context.Context, env *Envelope) error {
+	var order models.Order
+	if err := env.Decode(&order); err != nil {
+		return err
+	}
+	if !c.matcher.Cancel(order.Symbol, order.ID) {
+		return nil
+	}
+	logger.Info("order removed from book",
+		zap.String("order_id", order.ID.String()),
+		zap.String("symbol", order.Symbol),
+	)
+	for _, handler := range c.cancels {
+		if err := handler(ctx, order); err != nil {
+			logger.Error("cancel handler failed", logger.Err(err))
+		}
+	}
+	return nil
+}
+
+// Why an order is not matched.
+const (
+	skipNone      = iota
+	skipDuplicate // matched before
+	skipCancelled // cancelled unfilled, most likely before it reached the engine
+)
+
+// skip reports whether, and why, order should not be matched. The
+// window covers redeliveries while this process runs; only an order
+// created before it started can have been matched by an earlier
+// process, so only those are looked up in the database. Such an order
+// has since left the open status or taken a fill. One still open and
+// unfilled may never have been matched — RestoreBooks rests those too
+// — so it is matched again, which Matcher.Match allows for.
+func (c *OrderConsumer) skip(order models.Order) int {
+	if !c.seen.add(order.ID) {
+		return skipDuplicate
+	}
+	if c.orders == nil || order.CreatedAt.After(c.started.Add(clockSkew)) {
+		return skipNone
+	}
+	stored, err := c.orders.GetOrderByID(order.ID)
+	if err != nil {
+		// Not saved, or the database is down: matching it is the
+		// lesser risk than dropping it.
+		logger.Warn("could not check order for redelivery",
+			logger.Err(err), zap.String("order_id", order.ID.String()))
+		return skipNone
+	}
+	switch {
+	case stored.Status == models.StatusOpen && stored.FilledQty == 0:
+		return skipNone
+	case stored.Status == models.StatusCancelled && stored.FilledQty == 0:
+		return skipCancelled
+	}
+	return skipDuplicate
+}
+
+// recordMatch updates the engine metrics for one processed order.
+func recordMatch(order models.Order, trades []models.Trade) {
+	if len(trades) > 0 {
+		metrics.Trades.WithLabelValues(order.Symbol).Add(float64(len(trades)))
+	}
+	switch {
+	case order.Status == models.StatusRejected:
+		metrics.OrdersRejected.WithLabelValues(metrics.StageEngine, "halted").Inc()
+	case order.Status == models.StatusCancelled && order.Type == models.Market:
+		metrics.OrdersRejected.WithLabelValues(metrics.StageEngine, "insufficient_liquidity").Inc()
+	}
+}
+
+// recentIDs remembers the last n IDs added, forgetting the oldest first.
+// Only the consumer goroutine uses it, so it isn't locked.
+type recentIDs struct {
+	ids  map[uuid.UUID]struct{}
+	ring []uuid.UUID
+	next int
+}
+
+func newRecentIDs(n int) *recentIDs {
+	return &recentIDs{ids: make(map[uuid.UUID]struct{}, n), ring: make([]uuid.UUID, 0, n)}
+}
+
+// add records id and reports whether it was new.
+func (r *recentIDs) add(id uuid.UUID) bool {
+	if _, ok := r.ids[id]; ok {
+		return false
+	}
+	if len(r.ring) < cap(r.ring) {
+		r.ring = append(r.ring, id)
+	} else {
+		delete(r.ids, r.ring[r.next])
+		r.ring[r.next] = id
+		r.next = (r.next + 1) % len(r.ring)
+	}
+	r.ids[id] = struct{}{}
+	return true
+}
+
+// lagTracker keeps the latest lag per partition for the readiness
+// probe and mirrors it to the consumer lag gauge.
+type lagTracker struct {
+	mu         sync.Mutex
+	partitions map[int]int64
+}
+
+// record sets consumer lag from the high water mark the broker
+// returned with msg. Readers that don't report one are skipped.
+func (l *lagTracker) record(msg kafkago.Message) {
+	if msg.HighWaterMark <= 0 {
+		return
+	}
+	lag := max(msg.HighWaterMark-msg.Offset-1, 0)
+	metrics.ConsumerLag.WithLabelValues(msg.Topic, strconv.Itoa(msg.Partition)).Set(float64(lag))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.partitions == nil {
+		l.partitions = make(map[int]int64)
+	}
+	l.partitions[msg.Partition] = lag
+}
+
+// total is the lag summed over every partition seen so far.
+func (l *lagTracker) total() int64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	var sum int64
+	for _, lag := range l.partitions {
+		sum += lag
+	}
+	return sum
+}
+
+// Lag is how many messages the consumer is behind, as of the last
+// message it fetched from each partition.
+func (c *OrderConsumer) Lag() int64 {
+	return c.lag.total()
+}
+
+// Lag is how many messages the consumer is behind, as of the last
+// message it fetched from each partition.
+func (c *TradeConsumer) Lag() int64 {
+	return c.lag.total()
+}
+
 func isFilled(order models.Order, trade models.Trade, side models.Side) bool {
 	if order.Side == side {
 		return order.Status == models.StatusFilled
@@ -144,8 +410,9 @@ func (c *OrderConsumer) Close() error {
 // TradeConsumer reads from the trades topic.
 // Used by the market data service to build OHLCV candles.
 type TradeConsumer struct {
-	reader   *kafkago.Reader
+	reader   MessageReader
 	handlers []TradeHandler
+	lag      lagTracker
 }
 
 type TradeHandler func(ctx context.Context, event models.TradeEvent) error
@@ -162,6 +429,11 @@ func NewTradeConsumer(brokers []string, groupID string) *TradeConsumer {
 		CommitInterval: 0,
 	})
 
+	return NewTradeConsumerFromReader(reader)
+}
+
+// NewTradeConsumerFromReader creates a trade consumer over any reader.
+func NewTradeConsumerFromReader(reader MessageReader) *TradeConsumer {
 	return &TradeConsumer{reader: reader}
 }
 
@@ -177,32 +449,56 @@ func (c *TradeConsumer) Start(ctx context.Context) error {
 	for {
 		msg, err := c.reader.FetchMessage(ctx)
 		if err != nil {
-			if ctx.Err() != nil {
+			if ctx.Err() != nil || errors.Is(err, io.EOF) {
 				return nil
 			}
 			logger.Error("trade consumer fetch failed", logger.Err(err))
 			continue
 		}
+		c.lag.record(msg)
+		c.processMessage(ctx, msg)
 
-		var event models.TradeEvent
-		if err := json.Unmarshal(msg.Value, &event); err != nil {
-			logger.Error("trade unmarshal failed", logger.Err(err))
-			_ = c.reader.CommitMessages(ctx, msg)
-			continue
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			logger.Error("trade commit failed", logger.Err(err))
 		}
+	}
+}
 
-		for _, handler := range c.handlers {
-			if err := handler(ctx, event); err != nil {
-				logger.Error("trade handler failed", logger.Err(err))
-			}
-		}
+// processMessage runs every handler for one trade. Undecodable messages
+// are logged and skipped — the caller commits either way.
+func (c *TradeConsumer) processMessage(ctx context.Context, msg kafkago.Message) {
+	ctx, span := startConsumerSpan(ctx, msg)
+	defer span.End()
+
+	event, err := decodeTradeEvent(msg)
+	if err != nil {
+		tracing.RecordError(span, err)
+		logger.Error("trade decode failed", logger.Err(err))
+		return
+	}
 
-		if err := c.reader.CommitMessages(ctx, msg); err != nil {
-			logger.Error("trade commit failed", logger.Err(err))
+	for _, handler := range c.handlers {
+		if err := handler(ctx, event); err != nil {
+			tracing.RecordError(span, err)
+			logger.Error("trade handler failed", logger.Err(err))
 		}
 	}
 }
 
+// decodeTradeEvent unwraps a trades-topic message into a TradeEvent.
+func decodeTradeEvent(msg kafkago.Message) (models.TradeEvent, error) {
+	var event models.TradeEvent
+	env, err := DecodeEnvelope(msg)
+	if err != nil {
+		return event, err
+	}
+	if env.Type != EventTradeExecuted {
+		return event, fmt.Errorf("%w: %s on trades topic", ErrUnknownEventType, env.Type)
+	}
+	err = env.Decode(&event)
+	return event, err
+}
+
 func (c *TradeConsumer) Close() error {
 	return c.reader.Close()
 }
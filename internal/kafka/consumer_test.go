@@ -0,0 +1,134 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/internal/metrics"
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestOrderConsumerSkipsRedelivery(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	writers := map[string]*recordingWriter{}
+	producer := NewProducerWithWriters(SourceGateway, nil, func(topic string) MessageWriter {
+		writers[topic] = &recordingWriter{}
+		return writers[topic]
+	})
+
+	// The outbox relay published the order, crashed before marking it
+	// sent, and published it again after the restart.
+	order := newTestOrder(models.StatusOpen)
+	for range 2 {
+		if err := producer.PublishOrders(ctx, []models.Order{order}); err != nil {
+			t.Fatalf("PublishOrders: %v", err)
+		}
+	}
+
+	consumer := NewOrderConsumerFromReader(nil, engine.NewMatcher(), producer)
+	matched := 0
+	consumer.AddHandler(func(context.Context, models.Order, []models.Trade) error {
+		matched++
+		return nil
+	})
+	for _, msg := range writers[TopicOrders].msgs {
+		msg.Topic = TopicOrders // set by the reader in production
+		if err := consumer.processMessage(ctx, msg); err != nil {
+			t.Fatalf("processMessage: %v", err)
+		}
+	}
+	if matched != 1 {
+		t.Errorf("expected the order matched once, got %d", matched)
+	}
+
+	// The window forgets the oldest IDs first.
+	seen := newRecentIDs(2)
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	seen.add(a)
+	seen.add(b)
+	seen.add(c)
+	if !seen.add(a) || seen.add(c) {
+		t.Error("expected the oldest ID to be forgotten and the newest kept")
+	}
+}
+
+// countingOrders answers GetOrderByID from a map and counts the calls.
+type countingOrders struct {
+	ports.OrderRepository // only GetOrderByID is used
+
+	orders  map[uuid.UUID]models.Order
+	lookups int
+}
+
+func (r *countingOrders) GetOrderByID(id uuid.UUID) (*models.Order, error) {
+	r.lookups++
+	o, ok := r.orders[id]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return &o, nil
+}
+
+func TestOrderConsumerChecksOnlyOrdersOlderThanItself(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	writers := map[string]*recordingWriter{}
+	producer := NewProducerWithWriters(SourceGateway, nil, func(topic string) MessageWriter {
+		writers[topic] = &recordingWriter{}
+		return writers[topic]
+	})
+
+	// Two orders from before the restart: one the last process filled,
+	// one the user cancelled before it reached the engine. And one
+	// placed after the restart, which only this process can have seen.
+	filled := newTestOrder(models.StatusOpen)
+	cancelled := newTestOrder(models.StatusOpen)
+	fresh := newTestOrder(models.StatusOpen)
+	filled.CreatedAt = time.Now().Add(-time.Hour)
+	cancelled.CreatedAt = time.Now().Add(-time.Hour)
+	fresh.CreatedAt = time.Now().Add(2 * clockSkew)
+	repo := &countingOrders{orders: map[uuid.UUID]models.Order{
+		filled.ID:    {ID: filled.ID, Status: models.StatusFilled, FilledQty: filled.Quantity},
+		cancelled.ID: {ID: cancelled.ID, Status: models.StatusCancelled},
+	}}
+	if err := producer.PublishOrders(ctx, []models.Order{filled, cancelled, fresh}); err != nil {
+		t.Fatalf("PublishOrders: %v", err)
+	}
+
+	consumer := NewOrderConsumerFromReader(nil, engine.NewMatcher(), producer)
+	consumer.SetOrders(repo)
+	var matched []uuid.UUID
+	consumer.AddHandler(func(_ context.Context, order models.Order, _ []models.Trade) error {
+		matched = append(matched, order.ID)
+		return nil
+	})
+	duplicates := testutil.ToFloat64(metrics.DuplicateOrders)
+	skipped := testutil.ToFloat64(metrics.CancelledOrdersSkipped)
+	for _, msg := range writers[TopicOrders].msgs {
+		msg.Topic = TopicOrders
+		if err := consumer.processMessage(ctx, msg); err != nil {
+			t.Fatalf("processMessage: %v", err)
+		}
+	}
+
+	if len(matched) != 1 || matched[0] != fresh.ID {
+		t.Errorf("expected only the new order matched, got %v", matched)
+	}
+	if repo.lookups != 2 {
+		t.Errorf("expected the database checked for the 2 older orders only, got %d lookups", repo.lookups)
+	}
+	if got := testutil.ToFloat64(metrics.DuplicateOrders) - duplicates; got != 1 {
+		t.Errorf("expected 1 duplicate, got %v", got)
+	}
+	if got := testutil.ToFloat64(metrics.CancelledOrdersSkipped) - skipped; got != 1 {
+		t.Errorf("expected 1 cancelled order skipped, got %v", got)
+	}
+}
@@ -0,0 +1,330 @@
+package kafka
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// Event types — one per domain fact. Consumers switch on the type
+// instead of guessing what a payload means from its fields.
+const (
+	EventOrderPlaced          = "order.placed"           // orders: new order for the engine
+	EventOrderCancelRequested = "order.cancel_requested" // orders: take a cancelled order out of the book
+	EventOrderAccepted        = "order.accepted"         // order-events: rests in the book untouched
+	EventOrderPartiallyFilled = "order.partially_filled" // order-events: some quantity matched
+	EventOrderFilled          = "order.filled"           // order-events: fully matched
+	EventOrderCancelled       = "order.cancelled"        // order-events: cancelled by user or engine
+	EventOrderRejected        = "order.rejected"         // order-events: never reached the book
+	EventTradeExecuted        = "trade.executed"         // trades: one fill between two orders
+)
+
+// Kafka header names. Every field a router or consumer needs to decide
+// what to do with a message is duplicated here so it never has to
+// decode the body just to skip it.
+const (
+	HeaderEventID      = "ome-event-id"
+	HeaderEventType    = "ome-event-type"
+	HeaderEventVersion = "ome-event-version"
+	HeaderSource       = "ome-source"
+	HeaderEpoch        = "ome-epoch"
+	HeaderSequence     = "ome-sequence"
+	HeaderOccurredAt   = "ome-occurred-at"
+)
+
+var (
+	ErrUnsupportedVersion = errors.New("unsupported event schema version")
+	ErrUnknownEventType   = errors.New("unknown event type")
+)
+
+// SchemaVersion is a major.minor schema version.
+// Minor bumps only ever add optional fields, so a consumer can decode
+// any minor of a major it knows. Major bumps are breaking and need an
+// upcaster to bring older events forward.
+type SchemaVersion struct {
+	Major int
+	Minor int
+}
+
+func (v SchemaVersion) String() string {
+	return fmt.Sprintf("%d.%d", v.Major, v.Minor)
+}
+
+func (v SchemaVersion) MarshalText() ([]byte, error) {
+	return []byte(v.String()), nil
+}
+
+func (v *SchemaVersion) UnmarshalText(text []byte) error {
+	parsed, err := ParseSchemaVersion(string(text))
+	if err != nil {
+		return err
+	}
+	*v = parsed
+	return nil
+}
+
+func ParseSchemaVersion(s string) (SchemaVersion, error) {
+	majorStr, minorStr, _ := strings.Cut(s, ".")
+	major, err := strconv.Atoi(majorStr)
+	if err != nil {
+		return SchemaVersion{}, fmt.Errorf("parse schema version %q: %w", s, err)
+	}
+	minor := 0
+	if minorStr != "" {
+		if minor, err = strconv.Atoi(minorStr); err != nil {
+			return SchemaVersion{}, fmt.Errorf("parse schema version %q: %w", s, err)
+		}
+	}
+	return SchemaVersion{Major: major, Minor: minor}, nil
+}
+
+// schemaVersions is the version this build produces for each event type.
+// Bump the minor when adding a field, the major (plus an upcaster) when
+// removing or changing one.
+var schemaVersions = map[string]SchemaVersion{
+	EventOrderPlaced:          {Major: 1, Minor: 0},
+	EventOrderCancelRequested: {Major: 1, Minor: 0},
+	EventOrderAccepted:        {Major: 1, Minor: 0},
+	EventOrderPartiallyFilled: {Major: 1, Minor: 0},
+	EventOrderFilled:          {Major: 1, Minor: 0},
+	EventOrderCancelled:       {Major: 1, Minor: 0},
+	EventOrderRejected:        {Major: 1, Minor: 0},
+	EventTradeExecuted:        {Major: 1, Minor: 0},
+}
+
+// Envelope wraps every message on every topic.
+// The same metadata is written to Kafka headers by the producer.
+// Payload is encoded with the same codec as the envelope itself.
+//
+// Sequence numbers live in producer memory and start again at 1 when
+// the process restarts. Epoch is the producer's start time in Unix
+// nanoseconds, so order messages by (Epoch, Sequence): a lower sequence
+// under a newer epoch is a restart, not a gap or a replay.
+type Envelope struct {
+	ID         uuid.UUID
+	Type       string
+	Version    SchemaVersion
+	Source     string
+	Epoch      uint64    // producer start time, Unix nanoseconds; 0 if unknown
+	Sequence   uint64    // monotonic per source, epoch, topic and key
+	OccurredAt time.Time // when the domain fact happened
+	ProducedAt time.Time // when it was written to Kafka
+	Payload    []byte
+
+	codec Codec
+}
+
+// Decode unmarshals the payload into v.
+func (e *Envelope) Decode(v any) error {
+	codec := e.codec
+	if codec == nil {
+		codec = JSONCodec
+	}
+	if err := codec.DecodePayload(e.Payload, v); err != nil {
+		return fmt.Errorf("decode %s payload: %w", e.Type, err)
+	}
+	return nil
+}
+
+// headers renders the envelope metadata as Kafka headers.
+func (e *Envelope) headers() []kafkago.Header {
+	return []kafkago.Header{
+		{Key: HeaderContentType, Value: []byte(e.codec.ContentType())},
+		{Key: HeaderEventID, Value: []byte(e.ID.String())},
+		{Key: HeaderEventType, Value: []byte(e.Type)},
+		{Key: HeaderEventVersion, Value: []byte(e.Version.String())},
+		{Key: HeaderSource, Value: []byte(e.Source)},
+		{Key: HeaderEpoch, Value: []byte(strconv.FormatUint(e.Epoch, 10))},
+		{Key: HeaderSequence, Value: []byte(strconv.FormatUint(e.Sequence, 10))},
+		{Key: HeaderOccurredAt, Value: []byte(e.OccurredAt.Format(time.RFC3339Nano))},
+	}
+}
+
+// newEnvelope builds an envelope at the current schema version for
+// eventType, encoding the payload with codec.
+func newEnvelope(
+	codec Codec,
+	eventType, source string,
+	seq uint64,
+	occurredAt time.Time,
+	payload any,
+) (*Envelope, error) {
+	version, ok := schemaVersions[eventType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, eventType)
+	}
+	data, err := codec.EncodePayload(payload)
+	if err != nil {
+		return nil, fmt.Errorf("encode %s payload: %w", eventType, err)
+	}
+	return &Envelope{
+		ID:         uuid.New(),
+		Type:       eventType,
+		Version:    version,
+		Source:     source,
+		Sequence:   seq,
+		OccurredAt: occurredAt.UTC(),
+		ProducedAt: time.Now().UTC(),
+		Payload:    data,
+		codec:      codec,
+	}, nil
+}
+
+// DecodeEnvelope turns a Kafka message into an envelope at the schema
+// version this build understands.
+//
+//   - same major, any minor  → returned as-is (unknown fields are ignored)
+//   - older major            → run through the upcasters
+//   - newer major            → ErrUnsupportedVersion, the consumer must be upgraded
+//
+// The codec is picked from the content-type header; messages without one
+// are JSON. Messages written before envelopes existed (bare Order /
+// TradeEvent JSON) are treated as version 0.0 and upcast like any other
+// old event.
+func DecodeEnvelope(msg kafkago.Message) (*Envelope, error) {
+	env, err := parseEnvelope(msg)
+	if err != nil {
+		return nil, err
+	}
+
+	current, ok := schemaVersions[env.Type]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownEventType, env.Type)
+	}
+	if env.Version.Major > current.Major {
+		return nil, fmt.Errorf("%w: %s %s (consumer supports %d.x)",
+			ErrUnsupportedVersion, env.Type, env.Version, current.Major)
+	}
+	for env.Version.Major < current.Major {
+		up, ok := upcasters[upcasterKey{eventType: env.Type, fromMajor: env.Version.Major}]
+		if !ok {
+			return nil, fmt.Errorf("%w: no upcaster for %s %s",
+				ErrUnsupportedVersion, env.Type, env.Version)
+		}
+		if err := up(env); err != nil {
+			return nil, fmt.Errorf("upcast %s %s: %w", env.Type, env.Version, err)
+		}
+	}
+	return env, nil
+}
+
+func parseEnvelope(msg kafkago.Message) (*Envelope, error) {
+	codec, err := codecForContentType(headerValue(msg, HeaderContentType))
+	if err != nil {
+		return nil, err
+	}
+	if codec == JSONCodec &&
+		headerValue(msg, HeaderEventType) == "" && !looksLikeEnvelope(msg.Value) {
+		return legacyEnvelope(msg)
+	}
+	return codec.DecodeEnvelope(msg.Value)
+}
+
+// looksLikeEnvelope reports whether a body has the envelope's shape.
+// Only used for messages without headers, e.g. replayed from a dump.
+func looksLikeEnvelope(body []byte) bool {
+	var probe struct {
+		Type    string          `json:"type"`
+		Payload json.RawMessage `json:"payload"`
+	}
+	if err := json.Unmarshal(body, &probe); err != nil {
+		return false
+	}
+	return probe.Type != "" && len(probe.Payload) > 0
+}
+
+// legacyEnvelope wraps a pre-envelope message as version 0.0.
+// The event type is inferred from the topic it was read from.
+func legacyEnvelope(msg kafkago.Message) (*Envelope, error) {
+	env := &Envelope{
+		Version:    SchemaVersion{Major: 0, Minor: 0},
+		Source:     "legacy",
+		OccurredAt: msg.Time,
+		ProducedAt: msg.Time,
+		Payload:    bytes.Clone(msg.Value),
+		codec:      JSONCodec,
+	}
+
+	switch msg.Topic {
+	case TopicOrders:
+		env.Type = EventOrderPlaced
+	case TopicTrades:
+		env.Type = EventTradeExecuted
+	case TopicOrderEvents:
+		// order-events used to carry the whole Order for every status
+		// change — the status is the only way to tell them apart.
+		var order models.Order
+		if err := json.Unmarshal(msg.Value, &order); err != nil {
+			return nil, fmt.Errorf("unmarshal legacy order event: %w", err)
+		}
+		env.Type = orderEventType(order)
+	default:
+		return nil, fmt.Errorf("%w: legacy message on topic %q", ErrUnknownEventType, msg.Topic)
+	}
+	return env, nil
+}
+
+// orderEventType maps an order's status to the order-events type
+// that describes it.
+func orderEventType(order models.Order) string {
+	switch order.Status {
+	case models.StatusPartial:
+		return EventOrderPartiallyFilled
+	case models.StatusFilled:
+		return EventOrderFilled
+	case models.StatusCancelled:
+		return EventOrderCancelled
+	case models.StatusRejected:
+		return EventOrderRejected
+	default:
+		return EventOrderAccepted
+	}
+}
+
+func headerValue(msg kafkago.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+// ─── Upcasters ────────────────────────────────────────────────────────────────
+
+// Upcaster rewrites an envelope from one major version to the next.
+// It must bump env.Version itself; DecodeEnvelope keeps calling
+// upcasters until the envelope reaches the current major.
+type Upcaster func(env *Envelope) error
+
+type upcasterKey struct {
+	eventType string
+	fromMajor int
+}
+
+var upcasters = map[upcasterKey]Upcaster{}
+
+// RegisterUpcaster adds an upcaster for eventType from fromMajor to fromMajor+1.
+// Call from an init() next to the schema change that needed it.
+func RegisterUpcaster(eventType string, fromMajor int, up Upcaster) {
+	upcasters[upcasterKey{eventType: eventType, fromMajor: fromMajor}] = up
+}
+
+func init() {
+	// v0 → v1: bare JSON bodies were already the v1 payload shapes,
+	// only the envelope around them is new.
+	for eventType := range schemaVersions {
+		RegisterUpcaster(eventType, 0, func(env *Envelope) error {
+			env.Version = SchemaVersion{Major: 1, Minor: 0}
+			return nil
+		})
+	}
+}
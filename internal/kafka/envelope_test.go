@@ -0,0 +1,143 @@
+package kafka
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+func newTestOrder(status models.OrderStatus) models.Order {
+	return models.Order{
+		ID:           uuid.New(),
+		UserID:       uuid.New(),
+		Symbol:       "BTC-USD",
+		Side:         models.Buy,
+		Type:         models.Limit,
+		Price:        100,
+		Quantity:     1,
+		RemainingQty: 1,
+		Status:       status,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+func envelopeMessage(t *testing.T, env *Envelope, topic string) kafkago.Message {
+	t.Helper()
+	data, err := env.codec.EncodeEnvelope(env)
+	if err != nil {
+		t.Fatalf("marshal envelope: %v", err)
+	}
+	return kafkago.Message{Topic: topic, Value: data, Headers: env.headers()}
+}
+
+func TestEnvelopeRoundTrip(t *testing.T) {
+	order := newTestOrder(models.StatusOpen)
+	env, err := newEnvelope(JSONCodec, EventOrderPlaced, SourceGateway, 7, order.CreatedAt, order)
+	if err != nil {
+		t.Fatalf("newEnvelope: %v", err)
+	}
+
+	got, err := DecodeEnvelope(envelopeMessage(t, env, TopicOrders))
+	if err != nil {
+		t.Fatalf("DecodeEnvelope: %v", err)
+	}
+	if got.Type != EventOrderPlaced || got.Sequence != 7 || got.Source != SourceGateway {
+		t.Errorf("metadata mismatch: %+v", got)
+	}
+
+	var decoded models.Order
+	if err := got.Decode(&decoded); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if decoded.ID != order.ID {
+		t.Errorf("expected order %s, got %s", order.ID, decoded.ID)
+	}
+}
+
+func TestRestartedProducerTakesNewEpoch(t *testing.T) {
+	logger.InitForTest()
+	publish := func() *Envelope {
+		writer := &recordingWriter{}
+		producer := NewProducerWithWriters(SourceGateway, nil, func(string) MessageWriter { return writer })
+		if err := producer.PublishOrder(context.Background(), newTestOrder(models.StatusOpen)); err != nil {
+			t.Fatalf("PublishOrder: %v", err)
+		}
+		env, err := DecodeEnvelope(writer.msgs[0])
+		if err != nil {
+			t.Fatalf("DecodeEnvelope: %v", err)
+		}
+		if headerValue(writer.msgs[0], HeaderEpoch) != strconv.FormatUint(env.Epoch, 10) {
+			t.Errorf("epoch header %q does not match envelope epoch %d", headerValue(writer.msgs[0], HeaderEpoch), env.Epoch)
+		}
+		return env
+	}
+
+	before := publish()
+	after := publish()
+	if before.Sequence != 1 || after.Sequence != 1 {
+		t.Fatalf("expected both producers to start at sequence 1, got %d and %d", before.Sequence, after.Sequence)
+	}
+	if after.Epoch <= before.Epoch {
+		t.Errorf("expected the restarted producer's epoch %d to be after %d", after.Epoch, before.Epoch)
+	}
+}
+
+func TestEnvelopeNewerMinorAccepted(t *testing.T) {
+	env, _ := newEnvelope(JSONCodec, EventTradeExecuted, SourceEngine, 1, time.Now(), models.TradeEvent{})
+	env.Version = SchemaVersion{Major: 1, Minor: 9}
+	env.Payload = json.RawMessage(`{"symbol":"BTC-USD","field_from_the_future":true}`)
+
+	got, err := DecodeEnvelope(envelopeMessage(t, env, TopicTrades))
+	if err != nil {
+		t.Fatalf("expected newer minor to decode, got %v", err)
+	}
+	var event models.TradeEvent
+	if err := got.Decode(&event); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if event.Symbol != "BTC-USD" {
+		t.Errorf("expected symbol BTC-USD, got %q", event.Symbol)
+	}
+}
+
+func TestEnvelopeNewerMajorRejected(t *testing.T) {
+	env, _ := newEnvelope(JSONCodec, EventTradeExecuted, SourceEngine, 1, time.Now(), models.TradeEvent{})
+	env.Version = SchemaVersion{Major: 2, Minor: 0}
+
+	_, err := DecodeEnvelope(envelopeMessage(t, env, TopicTrades))
+	if !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+func TestLegacyOrderEventUpcast(t *testing.T) {
+	order := newTestOrder(models.StatusCancelled)
+	data, _ := json.Marshal(order)
+
+	env, err := DecodeEnvelope(kafkago.Message{Topic: TopicOrderEvents, Value: data})
+	if err != nil {
+		t.Fatalf("DecodeEnvelope: %v", err)
+	}
+	if env.Type != EventOrderCancelled {
+		t.Errorf("expected %s, got %s", EventOrderCancelled, env.Type)
+	}
+	if env.Version.Major != 1 {
+		t.Errorf("expected legacy event upcast to 1.x, got %s", env.Version)
+	}
+
+	var decoded models.Order
+	if err := env.Decode(&decoded); err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if decoded.ID != order.ID {
+		t.Errorf("expected order %s, got %s", order.ID, decoded.ID)
+	}
+}
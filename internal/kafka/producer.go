@@ -2,31 +2,74 @@ package kafka
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
+	"github.com/Im-Manav/ome/internal/metrics"
+	"github.com/Im-Manav/ome/internal/tracing"
 	"github.com/Im-Manav/ome/pkg/logger"
 	"github.com/Im-Manav/ome/pkg/models"
 	kafkago "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel/trace"
 	"go.uber.org/zap"
 )
 
+// MessageWriter is the part of *kafkago.Writer the producer uses.
+// Lets the in-memory event bus stand in for a real broker.
+type MessageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafkago.Message) error
+	Close() error
+}
+
 // Producer wraps kafka-go writers — one writer per topic.
 // Each writer is goroutine-safe; kafka-go handles batching internally.
+// Every message is wrapped in an Envelope stamped with the producing
+// service, the producer's epoch and a per-(topic, key) sequence number,
+// and encoded with the codec configured for its topic.
+//
+// Sequences are kept in memory only: a restarted producer takes a new
+// epoch and counts from 1 again. Nothing durable could seed them
+// without a read on every publish, and consumers only need them to
+// order one producer's messages, which (Epoch, Sequence) still does.
 type Producer struct {
-	orders      *kafkago.Writer
-	trades      *kafkago.Writer
-	orderEvents *kafkago.Writer
-	marketData  *kafkago.Writer
+	orders      MessageWriter
+	trades      MessageWriter
+	orderEvents MessageWriter
+	marketData  MessageWriter
+
+	source string
+	codecs TopicCodecs
+	epoch  uint64 // start time, Unix nanoseconds
+	seqMu  sync.Mutex
+	seq    map[string]uint64 // topic + "/" + key -> last sequence
+}
+
+// NewProducer creates a producer. source names the service writing the
+// events (see the Source* constants) and is carried in every envelope.
+// codecs picks the wire format per topic; nil means JSON everywhere.
+func NewProducer(brokers []string, source string, codecs TopicCodecs) *Producer {
+	return NewProducerWithWriters(source, codecs, func(topic string) MessageWriter {
+		return newWriter(brokers, topic)
+	})
 }
 
-func NewProducer(brokers []string) *Producer {
+// NewProducerWithWriters creates a producer over caller-supplied writers,
+// one per topic. Used to run the producer against the in-memory bus.
+func NewProducerWithWriters(
+	source string,
+	codecs TopicCodecs,
+	writerFor func(topic string) MessageWriter,
+) *Producer {
 	return &Producer{
-		orders:      newWriter(brokers, TopicOrders),
-		trades:      newWriter(brokers, TopicTrades),
-		orderEvents: newWriter(brokers, TopicOrderEvents),
-		marketData:  newWriter(brokers, TopicMarketData),
+		orders:      writerFor(TopicOrders),
+		trades:      writerFor(TopicTrades),
+		orderEvents: writerFor(TopicOrderEvents),
+		marketData:  writerFor(TopicMarketData),
+		source:      source,
+		codecs:      codecs,
+		epoch:       uint64(time.Now().UnixNano()),
+		seq:         make(map[string]uint64),
 	}
 }
 
@@ -52,62 +95,166 @@ func newWriter(brokers []string, topic string) *kafkago.Writer {
 // PublishOrder publishes an incoming order to the orders topic.
 // Key = symbol so all BTC-USD orders go to the same partition.
 func (p *Producer) PublishOrder(ctx context.Context, order models.Order) error {
-	return p.publish(ctx, p.orders, order.Symbol, order)
+	return p.publish(ctx, p.orders, TopicOrders, order.Symbol, EventOrderPlaced, order.CreatedAt, order)
+}
+
+// PublishOrders publishes a batch of incoming orders in one write, so a
+// batch costs one broker round trip rather than one per order.
+func (p *Producer) PublishOrders(ctx context.Context, orders []models.Order) error {
+	events := make([]outgoing, len(orders))
+	for i, order := range orders {
+		events[i] = outgoing{order.Symbol, EventOrderPlaced, order.CreatedAt, order}
+	}
+	return p.publishBatch(ctx, p.orders, TopicOrders, events)
+}
+
+// PublishCancels asks the engine to take cancelled orders out of its
+// books. They go on the orders topic, keyed by symbol like placements,
+// so the engine sees each one after the order it cancels and before
+// any order published after it — an amend's replacement included.
+func (p *Producer) PublishCancels(ctx context.Context, orders []models.Order) error {
+	events := make([]outgoing, len(orders))
+	for i, order := range orders {
+		events[i] = outgoing{order.Symbol, EventOrderCancelRequested, time.Now(), order}
+	}
+	return p.publishBatch(ctx, p.orders, TopicOrders, events)
 }
 
 // PublishTrade publishes a matched trade to the trades topic.
+// Wrapped as a TradeEvent without fill flags so the topic only
+// ever carries one payload shape.
 func (p *Producer) PublishTrade(ctx context.Context, trade models.Trade) error {
-	return p.publish(ctx, p.trades, trade.Symbol, trade)
+	return p.PublishTradeEvent(ctx, models.TradeEvent{Trade: trade})
 }
 
 // PublishTradeEvent publishes a trade event (with fill metadata) to trades topic.
 func (p *Producer) PublishTradeEvent(ctx context.Context, event models.TradeEvent) error {
-	return p.publish(ctx, p.trades, event.Symbol, event)
+	return p.publish(ctx, p.trades, TopicTrades, event.Symbol, EventTradeExecuted, event.ExecutedAt, event)
 }
 
 // PublishOrderEvent publishes an order status update (filled, cancelled, partial).
+// The event type is derived from the order's status.
 func (p *Producer) PublishOrderEvent(ctx context.Context, order models.Order) error {
-	return p.publish(ctx, p.orderEvents, order.Symbol, order)
+	return p.publish(ctx, p.orderEvents, TopicOrderEvents, order.Symbol, orderEventType(order), time.Now(), order)
+}
+
+// PublishOrderEvents publishes several order status updates in one write.
+func (p *Producer) PublishOrderEvents(ctx context.Context, orders []models.Order) error {
+	events := make([]outgoing, len(orders))
+	for i, order := range orders {
+		events[i] = outgoing{order.Symbol, orderEventType(order), time.Now(), order}
+	}
+	return p.publishBatch(ctx, p.orderEvents, TopicOrderEvents, events)
+}
+
+// nextSequence returns the next sequence number for a topic and key.
+func (p *Producer) nextSequence(topic, key string) uint64 {
+	p.seqMu.Lock()
+	defer p.seqMu.Unlock()
+	k := topic + "/" + key
+	p.seq[k]++
+	return p.seq[k]
 }
 
-// publish is the shared internal writer — marshals to JSON and writes.
+// outgoing is one event waiting to be written.
+type outgoing struct {
+	key        string
+	eventType  string
+	occurredAt time.Time
+	payload    any
+}
+
+// publish is the shared internal writer for a single event.
 func (p *Producer) publish(
 	ctx context.Context,
-	writer *kafkago.Writer,
+	writer MessageWriter,
+	topic string,
 	key string,
+	eventType string,
+	occurredAt time.Time,
 	payload any,
 ) error {
-	data, err := json.Marshal(payload)
-	if err != nil {
-		return fmt.Errorf("kafka publish marshal: %w", err)
+	return p.publishBatch(ctx, writer, topic, []outgoing{{key, eventType, occurredAt, payload}})
+}
+
+// publishBatch wraps each payload in an envelope, encodes it with the
+// topic's codec and writes them all in one call. Each message still
+// gets its own sequence number and producer span.
+func (p *Producer) publishBatch(
+	ctx context.Context,
+	writer MessageWriter,
+	topic string,
+	events []outgoing,
+) error {
+	if len(events) == 0 {
+		return nil
 	}
+	codec := p.codecs.For(topic)
+
+	msgs := make([]kafkago.Message, 0, len(events))
+	spans := make([]trace.Span, 0, len(events))
+	sequences := make([]uint64, 0, len(events))
+	defer func() {
+		for _, span := range spans {
+			span.End()
+		}
+	}()
 
-	msg := kafkago.Message{
-		Key:   []byte(key),
-		Value: data,
-		Time:  time.Now().UTC(),
+	for _, e := range events {
+		env, err := newEnvelope(codec, e.eventType, p.source, p.nextSequence(topic, e.key), e.occurredAt, e.payload)
+		if err != nil {
+			return fmt.Errorf("kafka publish envelope: %w", err)
+		}
+		env.Epoch = p.epoch
+
+		data, err := codec.EncodeEnvelope(env)
+		if err != nil {
+			return fmt.Errorf("kafka publish marshal: %w", err)
+		}
+
+		headers := env.headers()
+		_, span := startProducerSpan(ctx, topic, e.eventType, &headers)
+		spans = append(spans, span)
+		sequences = append(sequences, env.Sequence)
+
+		msgs = append(msgs, kafkago.Message{
+			Key:     []byte(e.key),
+			Value:   data,
+			Headers: headers,
+			Time:    env.ProducedAt,
+		})
 	}
 
-	if err := writer.WriteMessages(ctx, msg); err != nil {
+	if err := writer.WriteMessages(ctx, msgs...); err != nil {
+		for _, span := range spans {
+			tracing.RecordError(span, err)
+		}
+		metrics.PublishErrors.WithLabelValues(topic).Add(float64(len(msgs)))
 		logger.Error("kafka publish failed",
-			zap.String("topic", writer.Topic),
-			zap.String("key", key),
+			zap.String("topic", topic),
+			zap.String("key", events[0].key),
+			zap.Int("messages", len(msgs)),
 			logger.Err(err),
 		)
-		return fmt.Errorf("kafka publish to %s: %w", writer.Topic, err)
+		return fmt.Errorf("kafka publish to %s: %w", topic, err)
 	}
 
-	logger.Info("kafka published",
-		zap.String("topic", writer.Topic),
-		zap.String("key", key),
-	)
+	for i, e := range events {
+		logger.Info("kafka published",
+			zap.String("topic", topic),
+			zap.String("key", e.key),
+			zap.String("event_type", e.eventType),
+			zap.Uint64("epoch", p.epoch),
+			zap.Uint64("sequence", sequences[i]),
+		)
+	}
 	return nil
 }
 
 // Close flushes and closes all writers. Call on shutdown.
 func (p *Producer) Close() error {
 	var errs []error
-	for _, w := range []*kafkago.Writer{
+	for _, w := range []MessageWriter{
 		p.orders, p.trades, p.orderEvents, p.marketData,
 	} {
 		if err := w.Close(); err != nil {
@@ -17,3 +17,11 @@ const (
 	GroupMarketData = "ome-marketdata" // market data service consumes trades
 	GroupWebSocket  = "ome-websocket"  // WebSocket hub consumes trades + order-events
 )
+
+// Sources — the service name stamped on every event envelope so
+// consumers and operators can tell who produced a message.
+const (
+	SourceGateway    = "gateway"
+	SourceEngine     = "engine"
+	SourceMarketData = "marketdata"
+)
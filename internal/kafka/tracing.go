@@ -0,0 +1,70 @@
+package kafka
+
+import (
+	"context"
+
+	"github.com/Im-Manav/ome/internal/tracing"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// headerCarrier lets the OpenTelemetry propagator read and write
+// trace context (traceparent, tracestate, baggage) as Kafka headers.
+type headerCarrier struct {
+	headers *[]kafkago.Header
+}
+
+func (c headerCarrier) Get(key string) string {
+	return headerValue(kafkago.Message{Headers: *c.headers}, key)
+}
+
+func (c headerCarrier) Set(key, value string) {
+	for i, h := range *c.headers {
+		if h.Key == key {
+			(*c.headers)[i].Value = []byte(value)
+			return
+		}
+	}
+	*c.headers = append(*c.headers, kafkago.Header{Key: key, Value: []byte(value)})
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, len(*c.headers))
+	for i, h := range *c.headers {
+		keys[i] = h.Key
+	}
+	return keys
+}
+
+// startProducerSpan starts the span for one publish and writes its
+// context into headers, so the consumer's span joins the same trace.
+func startProducerSpan(ctx context.Context, topic, eventType string, headers *[]kafkago.Header) (context.Context, trace.Span) {
+	ctx, span := tracing.Start(ctx, "publish "+topic,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", topic),
+			attribute.String("ome.event_type", eventType),
+		),
+	)
+	otel.GetTextMapPropagator().Inject(ctx, headerCarrier{headers: headers})
+	return ctx, span
+}
+
+// startConsumerSpan continues the trace carried in msg's headers.
+func startConsumerSpan(ctx context.Context, msg kafkago.Message) (context.Context, trace.Span) {
+	headers := msg.Headers
+	ctx = otel.GetTextMapPropagator().Extract(ctx, headerCarrier{headers: &headers})
+	return tracing.Start(ctx, "process "+msg.Topic,
+		trace.WithSpanKind(trace.SpanKindConsumer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "kafka"),
+			attribute.String("messaging.destination.name", msg.Topic),
+			attribute.Int("messaging.kafka.partition", msg.Partition),
+			attribute.Int64("messaging.kafka.offset", msg.Offset),
+			attribute.String("messaging.kafka.message.key", string(msg.Key)),
+		),
+	)
+}
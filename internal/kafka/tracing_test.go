@@ -0,0 +1,66 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	kafkago "github.com/segmentio/kafka-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// recordingWriter keeps every message written to it.
+type recordingWriter struct{ msgs []kafkago.Message }
+
+func (w *recordingWriter) WriteMessages(_ context.Context, msgs ...kafkago.Message) error {
+	w.msgs = append(w.msgs, msgs...)
+	return nil
+}
+func (w *recordingWriter) Close() error { return nil }
+
+func TestTraceContextCrossesKafka(t *testing.T) {
+	logger.InitForTest()
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+
+	writers := map[string]*recordingWriter{}
+	producer := NewProducerWithWriters(SourceGateway, nil, func(topic string) MessageWriter {
+		writers[topic] = &recordingWriter{}
+		return writers[topic]
+	})
+
+	// Gateway side: publish inside a request span.
+	ctx, request := otel.Tracer("test").Start(context.Background(), "POST /api/v1/orders")
+	if err := producer.PublishOrder(ctx, newTestOrder(models.StatusOpen)); err != nil {
+		t.Fatalf("PublishOrder: %v", err)
+	}
+	request.End()
+
+	// Engine side: a fresh context, so the trace can only come from the headers.
+	msg := writers[TopicOrders].msgs[0]
+	msg.Topic = TopicOrders // set by the reader in production
+	consumer := NewOrderConsumerFromReader(nil, engine.NewMatcher(), producer)
+	if err := consumer.processMessage(context.Background(), msg); err != nil {
+		t.Fatalf("processMessage: %v", err)
+	}
+
+	traceID := request.SpanContext().TraceID()
+	seen := map[string]bool{}
+	for _, span := range recorder.Ended() {
+		if span.SpanContext().TraceID() != traceID {
+			t.Errorf("span %q is on a different trace", span.Name())
+		}
+		seen[span.Name()] = true
+	}
+	for _, name := range []string{"publish " + TopicOrders, "process " + TopicOrders, "engine.Match", "publish " + TopicOrderEvents} {
+		if !seen[name] {
+			t.Errorf("missing span %q", name)
+		}
+	}
+}
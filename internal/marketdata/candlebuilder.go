@@ -1,11 +1,11 @@
-package main
+package marketdata
 
 import (
 	"context"
 	"sync"
 	"time"
 
-	"github.com/Im-Manav/ome/internal/db"
+	"github.com/Im-Manav/ome/internal/ports"
 	"github.com/Im-Manav/ome/pkg/logger"
 	"github.com/Im-Manav/ome/pkg/models"
 	"go.uber.org/zap"
@@ -19,17 +19,18 @@ import (
 // implemented here with a plain map + mutex since throughput for a
 // portfolio project doesn't need a dedicated stream processor.
 type CandleBuilder struct {
-	repo    *db.Repository
-	pub     candlePublisher
+	repo    ports.OHLCVRepository
+	pub     CandlePublisher
 	mu      sync.Mutex
 	candles map[string]*models.OHLCV
 }
 
-type candlePublisher interface {
+// CandlePublisher is the pub/sub side of ports.Cache the builder needs.
+type CandlePublisher interface {
 	Publish(ctx context.Context, channel string, payload any) error
 }
 
-func NewCandleBuilder(repo *db.Repository, pub candlePublisher) *CandleBuilder {
+func NewCandleBuilder(repo ports.OHLCVRepository, pub CandlePublisher) *CandleBuilder {
 	return &CandleBuilder{
 		repo:    repo,
 		pub:     pub,
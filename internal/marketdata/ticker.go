@@ -0,0 +1,226 @@
+package marketdata
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// TickerWindow is how far back a ticker's stats reach.
+const TickerWindow = 24 * time.Hour
+
+// TickerStore is the part of ports.Cache the tracker writes to.
+type TickerStore interface {
+	SetTicker(ctx context.Context, ticker models.Ticker) error
+}
+
+// TickerTracker maintains a rolling 24-hour ticker per symbol from the
+// trade stream and writes it to the cache after every change.
+//
+// Each symbol's window is a run of one-minute buckets, so the stats are
+// exact to the minute: a trade drops out within a minute of turning 24h
+// old, and memory per symbol is capped at ~1440 buckets however busy
+// the market is.
+type TickerTracker struct {
+	store   TickerStore
+	now     func() time.Time
+	mu      sync.Mutex
+	windows map[string]*rollingWindow
+
+	// replayed holds the IDs of trades loaded by Warm, so the same
+	// trades redelivered by Kafka after a restart aren't counted twice.
+	replayed map[uuid.UUID]time.Time
+}
+
+// tickerBucket aggregates the trades executed in one minute.
+type tickerBucket struct {
+	start     time.Time
+	openAt    time.Time // execution time of the trade that set open
+	open      float64
+	high, low float64
+	volume    float64
+	quote     float64
+	count     int64
+}
+
+type rollingWindow struct {
+	buckets []tickerBucket // oldest first
+	last    float64
+	lastAt  time.Time
+}
+
+func NewTickerTracker(store TickerStore) *TickerTracker {
+	return &TickerTracker{
+		store:    store,
+		now:      func() time.Time { return time.Now().UTC() },
+		windows:  make(map[string]*rollingWindow),
+		replayed: make(map[uuid.UUID]time.Time),
+	}
+}
+
+// Warm replays stored trades — the last TickerWindow of them — so the
+// tickers are complete from the first request after a restart. Call it
+// before the trade consumer starts.
+func (t *TickerTracker) Warm(ctx context.Context, trades []models.Trade) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, trade := range trades {
+		t.window(trade.Symbol).add(trade, t.now())
+		t.replayed[trade.ID] = trade.ExecutedAt
+	}
+	for symbol := range t.windows {
+		t.write(ctx, symbol)
+	}
+}
+
+// HandleTrade is called for every trade consumed from Kafka.
+func (t *TickerTracker) HandleTrade(ctx context.Context, event models.TradeEvent) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.replayed[event.ID]; ok {
+		delete(t.replayed, event.ID)
+		return nil
+	}
+	t.window(event.Symbol).add(event.Trade, t.now())
+	t.write(ctx, event.Symbol)
+	return nil
+}
+
+// StartRefreshLoop rewrites every ticker on each tick so old trades roll
+// out of the window even for a symbol that has stopped trading.
+func (t *TickerTracker) StartRefreshLoop(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.refresh(ctx)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (t *TickerTracker) refresh(ctx context.Context) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	cutoff := t.now().Add(-TickerWindow)
+	for id, executedAt := range t.replayed {
+		if executedAt.Before(cutoff) {
+			delete(t.replayed, id)
+		}
+	}
+	for symbol := range t.windows {
+		t.write(ctx, symbol)
+	}
+}
+
+// window returns the rolling window for a symbol, creating it if
+// needed. Caller holds t.mu.
+func (t *TickerTracker) window(symbol string) *rollingWindow {
+	w, ok := t.windows[symbol]
+	if !ok {
+		w = &rollingWindow{}
+		t.windows[symbol] = w
+	}
+	return w
+}
+
+// write stores a symbol's ticker. A failed write is logged and left for
+// the next trade or refresh to retry. Caller holds t.mu.
+func (t *TickerTracker) write(ctx context.Context, symbol string) {
+	ticker := t.windows[symbol].ticker(symbol, t.now())
+	if err := t.store.SetTicker(ctx, ticker); err != nil {
+		logger.Error("failed to store ticker",
+			logger.Err(err),
+			zap.String("symbol", symbol),
+		)
+	}
+}
+
+// ─── Rolling window ───────────────────────────────────────────────────────────
+
+// add counts a trade in its minute's bucket. Trades can arrive slightly
+// out of order across partitions, so buckets are kept sorted rather than
+// only ever appended.
+func (w *rollingWindow) add(trade models.Trade, now time.Time) {
+	if !trade.ExecutedAt.Before(w.lastAt) {
+		w.last, w.lastAt = trade.Price, trade.ExecutedAt
+	}
+
+	start := trade.ExecutedAt.Truncate(time.Minute)
+	if start.Before(windowStart(now)) {
+		return
+	}
+
+	i := sort.Search(len(w.buckets), func(i int) bool {
+		return !w.buckets[i].start.Before(start)
+	})
+	if i == len(w.buckets) || !w.buckets[i].start.Equal(start) {
+		w.buckets = append(w.buckets, tickerBucket{})
+		copy(w.buckets[i+1:], w.buckets[i:])
+		w.buckets[i] = tickerBucket{
+			start:  start,
+			openAt: trade.ExecutedAt,
+			open:   trade.Price,
+			high:   trade.Price,
+			low:    trade.Price,
+		}
+	}
+
+	b := &w.buckets[i]
+	if trade.ExecutedAt.Before(b.openAt) {
+		b.open, b.openAt = trade.Price, trade.ExecutedAt
+	}
+	b.high = max(b.high, trade.Price)
+	b.low = min(b.low, trade.Price)
+	b.volume += trade.Quantity
+	b.quote += trade.Price * trade.Quantity
+	b.count++
+}
+
+// ticker drops buckets that have left the window and sums the rest.
+func (w *rollingWindow) ticker(symbol string, now time.Time) models.Ticker {
+	start := windowStart(now)
+	expired := sort.Search(len(w.buckets), func(i int) bool {
+		return !w.buckets[i].start.Before(start)
+	})
+	w.buckets = w.buckets[expired:]
+
+	t := models.Ticker{
+		Symbol:    symbol,
+		LastPrice: w.last,
+		OpenTime:  start,
+		UpdatedAt: now,
+	}
+	for i, b := range w.buckets {
+		if i == 0 {
+			t.Open, t.High, t.Low = b.open, b.high, b.low
+		}
+		t.High = max(t.High, b.high)
+		t.Low = min(t.Low, b.low)
+		t.Volume += b.volume
+		t.QuoteVolume += b.quote
+		t.TradeCount += b.count
+	}
+	if t.Open > 0 {
+		t.PriceChange = t.LastPrice - t.Open
+		t.PriceChangePct = t.PriceChange / t.Open * 100
+	}
+	return t
+}
+
+// windowStart is the start of the oldest bucket still in the window.
+func windowStart(now time.Time) time.Time {
+	return now.Add(-TickerWindow).Truncate(time.Minute)
+}
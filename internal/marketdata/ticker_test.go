@@ -0,0 +1,71 @@
+package marketdata
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+type tickerStore struct {
+	last map[string]models.Ticker
+}
+
+func (s *tickerStore) SetTicker(_ context.Context, t models.Ticker) error {
+	s.last[t.Symbol] = t
+	return nil
+}
+
+func trade(price, qty float64, at time.Time) models.TradeEvent {
+	return models.TradeEvent{Trade: models.Trade{
+		ID: uuid.New(), Symbol: "BTC-USD", Price: price, Quantity: qty, ExecutedAt: at,
+	}}
+}
+
+func TestTickerRollingWindow(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2025, 1, 2, 12, 0, 30, 0, time.UTC)
+	store := &tickerStore{last: make(map[string]models.Ticker)}
+	tracker := NewTickerTracker(store)
+	tracker.now = func() time.Time { return now }
+
+	// Replayed from the database on startup; the first is outside the window.
+	old := trade(50, 1, now.Add(-25*time.Hour))
+	replayed := trade(100, 1, now.Add(-23*time.Hour))
+	tracker.Warm(ctx, []models.Trade{old.Trade, replayed.Trade})
+
+	// Kafka redelivers the replayed trade, then two new ones arrive out of order.
+	for _, e := range []models.TradeEvent{
+		replayed,
+		trade(110, 2, now.Add(-time.Second)),
+		trade(90, 1, now.Add(-time.Hour)),
+	} {
+		if err := tracker.HandleTrade(ctx, e); err != nil {
+			t.Fatalf("HandleTrade: %v", err)
+		}
+	}
+
+	got := store.last["BTC-USD"]
+	want := models.Ticker{
+		Symbol: "BTC-USD", LastPrice: 110, Open: 100, High: 110, Low: 90,
+		Volume: 4, QuoteVolume: 100 + 220 + 90, PriceChange: 10, PriceChangePct: 10,
+		TradeCount: 3, OpenTime: now.Add(-TickerWindow).Truncate(time.Minute), UpdatedAt: now,
+	}
+	if got != want {
+		t.Errorf("ticker mismatch\n got %+v\nwant %+v", got, want)
+	}
+
+	// An hour and a minute later the replayed trade has rolled out.
+	now = now.Add(time.Hour + time.Minute)
+	tracker.refresh(ctx)
+	got = store.last["BTC-USD"]
+	if got.Open != 90 || got.TradeCount != 2 || got.LastPrice != 110 {
+		t.Errorf("expected the 23h-old trade to roll out, got %+v", got)
+	}
+	if math.Abs(got.PriceChangePct-22.22) > 0.01 {
+		t.Errorf("expected +22.22%%, got %.2f", got.PriceChangePct)
+	}
+}
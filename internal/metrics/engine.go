@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// bookCollector reads resting order counts and depth from the matcher at
+// scrape time. The books keep those counts as orders come and go, so a
+// scrape costs the same however deep they are.
+type bookCollector struct {
+	matcher *engine.Matcher
+	resting *prometheus.Desc
+	levels  *prometheus.Desc
+}
+
+// RegisterEngine exports book gauges for every symbol the matcher holds.
+func RegisterEngine(matcher *engine.Matcher) {
+	prometheus.MustRegister(newBookCollector(matcher))
+}
+
+func newBookCollector(matcher *engine.Matcher) *bookCollector {
+	return &bookCollector{
+		matcher: matcher,
+		resting: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "book", "resting_orders"),
+			"Resting orders in the book.",
+			[]string{"symbol", "side"}, nil,
+		),
+		levels: prometheus.NewDesc(
+			prometheus.BuildFQName(namespace, "book", "depth_levels"),
+			"Distinct price levels in the book.",
+			[]string{"symbol", "side"}, nil,
+		),
+	}
+}
+
+func (c *bookCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.resting
+	ch <- c.levels
+}
+
+func (c *bookCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, symbol := range c.matcher.Symbols() {
+		s, ok := c.matcher.Stats(symbol)
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(c.resting, prometheus.GaugeValue, float64(s.RestingBids), symbol, "buy")
+		ch <- prometheus.MustNewConstMetric(c.resting, prometheus.GaugeValue, float64(s.RestingAsks), symbol, "sell")
+		ch <- prometheus.MustNewConstMetric(c.levels, prometheus.GaugeValue, float64(s.BidLevels), symbol, "buy")
+		ch <- prometheus.MustNewConstMetric(c.levels, prometheus.GaugeValue, float64(s.AskLevels), symbol, "sell")
+	}
+}
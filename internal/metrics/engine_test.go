@@ -0,0 +1,50 @@
+package metrics
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+func TestBookCollectorCostIndependentOfDepth(t *testing.T) {
+	// scrapeBytes is what one scrape of a book of n bids, spread over
+	// 50 prices, allocates.
+	scrapeBytes := func(n int) int64 {
+		m := engine.NewMatcher()
+		for i := range n {
+			m.Match(&models.Order{
+				ID: uuid.New(), Symbol: "BTC-USD", Side: models.Buy, Type: models.Limit,
+				Price: float64(100 - i%50), Quantity: 1, RemainingQty: 1, Status: models.StatusOpen,
+				CreatedAt: time.Now(),
+			})
+		}
+		c := newBookCollector(m)
+		ch := make(chan prometheus.Metric, 4) // four gauges per symbol
+		scrape := func() {
+			c.Collect(ch)
+			for range 4 {
+				<-ch
+			}
+		}
+		scrape() // warm up
+
+		const runs = 100
+		var before, after runtime.MemStats
+		runtime.ReadMemStats(&before)
+		for range runs {
+			scrape()
+		}
+		runtime.ReadMemStats(&after)
+		return int64(after.TotalAlloc-before.TotalAlloc) / runs
+	}
+
+	small, large := scrapeBytes(50), scrapeBytes(10_000)
+	if large > 2*small { // some slack for the runtime's own allocations
+		t.Errorf("scrape allocations grew with the book: %d bytes for 50 orders, %d for 10000", small, large)
+	}
+}
@@ -0,0 +1,159 @@
+// Package metrics defines every Prometheus metric the services export.
+//
+// Metrics live in one place so names and labels stay consistent across
+// binaries; each binary only serves the ones it actually touches.
+// Everything is registered on the default registry and served by Handler.
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/health"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.uber.org/zap"
+)
+
+const namespace = "ome"
+
+// Reject stages for OrdersRejected.
+const (
+	StageGateway = "gateway"
+	StageEngine  = "engine"
+)
+
+// ─── Gateway ──────────────────────────────────────────────────────────────────
+
+var (
+	OrdersReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_received_total",
+		Help:      "Orders submitted to the gateway, before validation.",
+	}, []string{"side", "type"})
+
+	OrdersRejected = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "orders_rejected_total",
+		Help:      "Orders rejected, by the stage that rejected them and why.",
+	}, []string{"stage", "reason"})
+)
+
+// ─── Engine ───────────────────────────────────────────────────────────────────
+
+var (
+	MatchDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "match_duration_seconds",
+		Help:      "Time spent in Matcher.Match per incoming order.",
+		Buckets:   prometheus.ExponentialBuckets(5e-6, 2, 16), // 5µs … ~160ms
+	})
+
+	Trades = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "trades_total",
+		Help:      "Trades executed by the matching engine.",
+	}, []string{"symbol"})
+)
+
+// ─── Kafka ────────────────────────────────────────────────────────────────────
+
+var (
+	ConsumerLag = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "kafka_consumer_lag",
+		Help:      "Messages behind the partition high water mark at the last fetch.",
+	}, []string{"topic", "partition"})
+
+	PublishErrors = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_publish_errors_total",
+		Help:      "Failed Kafka writes.",
+	}, []string{"topic"})
+
+	DuplicateOrders = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_duplicate_orders_total",
+		Help:      "Redelivered orders the engine skipped instead of matching again.",
+	})
+
+	CancelledOrdersSkipped = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "kafka_cancelled_orders_skipped_total",
+		Help:      "Orders the engine skipped because they were cancelled before it matched them.",
+	})
+)
+
+// ─── Pub/Sub and WebSocket ────────────────────────────────────────────────────
+
+var (
+	PubSubDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "pubsub_dropped_total",
+		Help:      "Pub/sub messages dropped because a subscriber's buffer was full.",
+	}, []string{"channel"})
+
+	WebSocketDropped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "websocket_dropped_total",
+		Help:      "WebSocket messages dropped: broadcast_full (hub queue full) or slow_client (client disconnected).",
+	}, []string{"reason"})
+)
+
+// RegisterWebSocketClients exports the hub's connected client count.
+func RegisterWebSocketClients(count func() int) {
+	promauto.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "websocket_clients",
+		Help:      "Connected WebSocket clients.",
+	}, func() float64 { return float64(count()) })
+}
+
+// ─── Predictor ────────────────────────────────────────────────────────────────
+
+var (
+	PredictionDuration = promauto.NewHistogram(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "prediction_duration_seconds",
+		Help:      "LLM round trip per prediction, successful or not.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 2, 10), // 100ms … ~51s
+	})
+
+	PredictionFailures = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "prediction_failures_total",
+		Help:      "Predictions that failed, by symbol.",
+	}, []string{"symbol"})
+)
+
+// Handler serves the default registry in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// Serve starts a bare HTTP server with /metrics, /livez, /readyz and
+// /health for binaries that have no API of their own. Shut it down
+// with the returned server.
+func Serve(addr string, checks *health.Checker) *http.Server {
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", Handler())
+	mux.HandleFunc("/livez", checks.LiveHandler)
+	mux.HandleFunc("/readyz", checks.ReadyHandler)
+	mux.HandleFunc("/health", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			logger.Error("metrics server failed", logger.Err(err), zap.String("addr", addr))
+		}
+	}()
+	return srv
+}
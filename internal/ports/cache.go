@@ -13,13 +13,52 @@ type Cache interface {
 	SetOrderBookSnapshot(ctx context.Context, symbol string, snap models.OrderBookSnapshot, ttl time.Duration) error
 	GetOrderBookSnapshot(ctx context.Context, symbol string) (*models.OrderBookSnapshot, error)
 
-	// Rate limiting - token bucket per user
+	// Tickers — rolling 24h stats, written by the market data service
+	SetTicker(ctx context.Context, ticker models.Ticker) error
+	GetTicker(ctx context.Context, symbol string) (*models.Ticker, error)
+	GetTickers(ctx context.Context) ([]models.Ticker, error)
+
+	// Rate limiting - token bucket per user. IncrByWithExpiry counts a
+	// weighted request, such as a batch, as n.
 	IncrWithExpiry(ctx context.Context, key string, expiry time.Duration) (int64, error)
+	IncrByWithExpiry(ctx context.Context, key string, n int64, expiry time.Duration) (int64, error)
 
-	// Pub/Sub - for broadcasting trades to Websocket clients
+	// Pub/Sub - for broadcasting trades to Websocket clients, and to
+	// gRPC streams on every gateway
 	Publish(ctx context.Context, channel string, payload any) error
 	Subscribe(ctx context.Context, channel string) (<-chan string, error)
+	PublishTrade(ctx context.Context, event models.TradeEvent) error
+	SubscribeTrades(ctx context.Context, symbol string) (<-chan models.TradeEvent, error)
 	PublishOrderBookUpdate(ctx context.Context, snap models.OrderBookSnapshot) error
+	SubscribeOrderBook(ctx context.Context, symbol string) (<-chan models.OrderBookSnapshot, error)
+
+	// Executions — the engine's per-order results, for synchronous
+	// order placement. Every gateway receives every execution.
+	PublishExecution(ctx context.Context, exec models.Execution) error
+	SubscribeExecutions(ctx context.Context) (<-chan models.Execution, error)
+
+	// Nonces — SetNonce reports false if the nonce was already used
+	// within ttl. API key signatures use it for replay protection.
+	SetNonce(ctx context.Context, nonce string, ttl time.Duration) (bool, error)
+
+	// Frozen accounts — checked by the auth middleware on every request
+	SetUserFrozen(ctx context.Context, userID string, frozen bool) error
+	IsUserFrozen(ctx context.Context, userID string) (bool, error)
+
+	// Revoked sessions — access tokens carry their session's ID, so
+	// revoking a session cuts them off before they expire. Markers only
+	// need to outlive the longest access token.
+	RevokeSession(ctx context.Context, sessionID string, ttl time.Duration) error
+	IsSessionRevoked(ctx context.Context, sessionID string) (bool, error)
+
+	// Idempotency keys — ReserveIdempotencyKey claims a key for the
+	// first request to use it, reporting false if it is already taken.
+	// GetIdempotentResponse returns nil while the first request is still
+	// running, and once the key has expired.
+	ReserveIdempotencyKey(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	SetIdempotentResponse(ctx context.Context, key string, resp models.IdempotentResponse, ttl time.Duration) error
+	GetIdempotentResponse(ctx context.Context, key string) (*models.IdempotentResponse, error)
+	ReleaseIdempotencyKey(ctx context.Context, key string) error
 
 	// Session - JWT blocklist for logout
 	SetWithExpiry(ctx context.Context, key, value string, expiry time.Duration) error
@@ -8,8 +8,14 @@ import (
 
 type EventPublisher interface {
 	PublishOrder(ctx context.Context, order models.Order) error
+	// PublishOrders writes a batch of orders in one call.
+	PublishOrders(ctx context.Context, orders []models.Order) error
+	// PublishCancels tells the engine to drop cancelled orders from its
+	// books, in order with the orders published before and after.
+	PublishCancels(ctx context.Context, orders []models.Order) error
 	PublishTrade(ctx context.Context, trade models.Trade) error
 	PublishOrderEvent(ctx context.Context, order models.Order) error
+	PublishOrderEvents(ctx context.Context, orders []models.Order) error
 	PublishTradeEvent(ctx context.Context, event models.TradeEvent) error
 	Close() error
 }
@@ -1,6 +1,8 @@
 package ports
 
 import (
+	"time"
+
 	"github.com/Im-Manav/ome/pkg/models"
 	"github.com/google/uuid"
 )
@@ -11,9 +13,21 @@ type OrderRepository interface {
 	SaveOrder(order *models.Order) error
 	UpdateOrder(order *models.Order) error
 	GetOrderByID(id uuid.UUID) (*models.Order, error)
+	// GetOrderByClientOrderID returns nil, nil if the user has no order
+	// with that client order ID.
+	GetOrderByClientOrderID(userID uuid.UUID, clientOrderID string) (*models.Order, error)
 	GetOpenOrdersBySymbol(symbol string) ([]*models.Order, error)
+	GetOpenOrders() ([]*models.Order, error)
 	GetOrdersByUserID(userID uuid.UUID) ([]*models.Order, error)
+	ListOrdersByUserID(userID uuid.UUID, filter models.OrderFilter) ([]*models.Order, error)
 	CancelOrder(id uuid.UUID) error
+	// ReplaceOrder cancels a live order and saves its replacement in
+	// one transaction. It fails, changing nothing, if the old order is
+	// no longer open or partially filled.
+	ReplaceOrder(oldID uuid.UUID, replacement *models.Order) error
+	// FillOrders applies fills to open or partially filled orders in
+	// one transaction. Orders already closed are left unchanged.
+	FillOrders(fills []models.OrderFill) error
 }
 
 // TradeRepository — all DB operations for trades
@@ -21,13 +35,19 @@ type TradeRepository interface {
 	SaveTrade(trade *models.Trade) error
 	SaveTrades(trades []models.Trade) error
 	GetTradesBySymbol(symbol string, limit int) ([]models.Trade, error)
+	GetTradesByOrderID(orderID uuid.UUID) ([]models.Trade, error)
+	GetTradesSince(since time.Time) ([]models.Trade, error)
+	ListTradesByUserID(userID uuid.UUID, filter models.TradeFilter) ([]models.Trade, error)
 	GetTradesByUserID(userID uuid.UUID, limit int) ([]models.Trade, error)
 }
 
 // OHLCVRepository — candlestick data (TimescaleDB)
 type OHLCVRepository interface {
 	UpsertOHLCV(bar *models.OHLCV) error
+	// GetOHLCV and GetOHLCVRange return the newest limit candles, or
+	// every candle if limit <= 0.
 	GetOHLCV(symbol, interval string, limit int) ([]models.OHLCV, error)
+	GetOHLCVRange(symbol, interval string, from, to time.Time, limit int) ([]models.OHLCV, error)
 }
 
 // UserRepository — auth
@@ -35,4 +55,73 @@ type UserRepository interface {
 	CreateUser(user *models.User) error
 	GetUserByEmail(email string) (*models.User, error)
 	GetUserByID(id uuid.UUID) (*models.User, error)
+	SearchUsers(filter models.UserFilter) ([]*models.User, error)
+	SetUserRole(id uuid.UUID, role models.Role) error
+	SetUserFrozen(id uuid.UUID, frozenAt *time.Time, reason string) error
+}
+
+// SessionRepository — login sessions and their refresh tokens
+type SessionRepository interface {
+	CreateSession(session *models.Session) error
+	GetSession(id uuid.UUID) (*models.Session, error)
+	// RotateSession swaps the refresh hash only if it is still oldHash
+	// and the session isn't revoked, reporting whether it did, and moves
+	// the session to its next generation. Of two refreshes racing with
+	// the same token, exactly one wins.
+	RotateSession(id uuid.UUID, oldHash, newHash, ip string, usedAt time.Time) (bool, error)
+	// ListActiveSessions returns a user's unrevoked, unexpired sessions,
+	// most recently used first.
+	ListActiveSessions(userID uuid.UUID, now time.Time) ([]*models.Session, error)
+	RevokeSession(id uuid.UUID, reason string) error
+	// RevokeUserSessions revokes every active session of a user and
+	// returns their IDs.
+	RevokeUserSessions(userID uuid.UUID, reason string) ([]uuid.UUID, error)
+	// ListSessionsRevokedSince returns the IDs of sessions revoked after since.
+	ListSessionsRevokedSince(since time.Time) ([]uuid.UUID, error)
+}
+
+// InstrumentRepository — tradable symbols
+type InstrumentRepository interface {
+	// GetInstrument returns nil, nil for an unknown symbol.
+	GetInstrument(symbol string) (*models.Instrument, error)
+	ListInstruments() ([]models.Instrument, error)
+	UpsertInstrument(inst *models.Instrument) error
+	// SeedInstruments creates the instruments that don't exist yet and
+	// leaves existing ones untouched.
+	SeedInstruments(insts []models.Instrument) error
+}
+
+// AuditRepository — admin action log
+type AuditRepository interface {
+	CreateAuditLog(entry *models.AuditLog) error
+	ListAuditLogs(limit int) ([]models.AuditLog, error)
+}
+
+// APIKeyRepository — programmatic access keys
+type APIKeyRepository interface {
+	CreateAPIKey(key *models.APIKey) error
+	GetAPIKeyByID(id uuid.UUID) (*models.APIKey, error)
+	GetAPIKeyByKey(key string) (*models.APIKey, error)
+	ListAPIKeysByUserID(userID uuid.UUID) ([]*models.APIKey, error)
+	RevokeAPIKey(id uuid.UUID) error
+}
+
+// OutboxRepository — events saved with the rows they describe, for the
+// outbox relay to publish
+type OutboxRepository interface {
+	// SaveOrderWithEvent saves an order and its event in one transaction.
+	SaveOrderWithEvent(order *models.Order, event *models.OutboxEvent) error
+	// CancelOrderWithEvent is OrderRepository.CancelOrder that also
+	// saves the cancel's event.
+	CancelOrderWithEvent(id uuid.UUID, event *models.OutboxEvent) error
+	// ReplaceOrderWithEvents is OrderRepository.ReplaceOrder that also
+	// saves the events, in the order given.
+	ReplaceOrderWithEvents(oldID uuid.UUID, replacement *models.Order, events []*models.OutboxEvent) error
+	// UnsentEvents returns up to limit events not yet marked sent,
+	// oldest first.
+	UnsentEvents(limit int) ([]models.OutboxEvent, error)
+	// MarkEventsSent records that the events were published.
+	MarkEventsSent(ids []int64) error
+	// DeleteSentEvents removes events sent before the given time.
+	DeleteSentEvents(before time.Time) (int64, error)
 }
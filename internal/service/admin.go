@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/ports"
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AdminService backs the /api/v1/admin routes: user search, roles,
+// account freezes and the audit log. Order and instrument actions go
+// through OrderService and InstrumentService.
+type AdminService struct {
+	users ports.UserRepository
+	audit ports.AuditRepository
+	cache ports.Cache
+}
+
+func NewAdminService(
+	users ports.UserRepository,
+	audit ports.AuditRepository,
+	cache ports.Cache,
+) *AdminService {
+	return &AdminService{
+		users: users,
+		audit: audit,
+		cache: cache,
+	}
+}
+
+// ─── Startup ──────────────────────────────────────────────────────────────────
+
+// PromoteAdmins gives the admin role to the listed users that exist.
+// Registering never grants it, so an email without an account is
+// promoted at the first start after its owner signs up.
+func (s *AdminService) PromoteAdmins(ctx context.Context, emails []string) error {
+	for _, email := range emails {
+		user, err := s.users.GetUserByEmail(strings.TrimSpace(email))
+		if err != nil || user.Role == models.RoleAdmin {
+			continue
+		}
+		if err := s.users.SetUserRole(user.ID, models.RoleAdmin); err != nil {
+			return fmt.Errorf("PromoteAdmins: %w", err)
+		}
+		logger.Info("user promoted to admin", zap.String("email", user.Email))
+	}
+	return nil
+}
+
+// SyncFrozen rewrites the cache's frozen markers from the users table,
+// for a cache that was flushed or, in standalone mode, lives in memory.
+func (s *AdminService) SyncFrozen(ctx context.Context) error {
+	frozen := true
+	users, err := s.users.SearchUsers(models.UserFilter{Frozen: &frozen})
+	if err != nil {
+		return fmt.Errorf("SyncFrozen: %w", err)
+	}
+	for _, u := range users {
+		if err := s.cache.SetUserFrozen(ctx, u.ID.String(), true); err != nil {
+			return fmt.Errorf("SyncFrozen: %w", err)
+		}
+	}
+	return nil
+}
+
+// ─── Users ────────────────────────────────────────────────────────────────────
+
+func (s *AdminService) SearchUsers(ctx context.Context, filter models.UserFilter) ([]*models.User, error) {
+	filter.Limit = models.ClampPageLimit(filter.Limit)
+	users, err := s.users.SearchUsers(filter)
+	if err != nil {
+		return nil, fmt.Errorf("SearchUsers: %w", err)
+	}
+	return users, nil
+}
+
+// SetRole changes a user's role. Admins can't change their own, so the
+// last admin can't lock everyone out by accident.
+func (s *AdminService) SetRole(ctx context.Context, actorID, userID uuid.UUID, role string) (*models.User, error) {
+	r, ok := models.ParseRole(role)
+	if !ok {
+		return nil, fmt.Errorf("%w: %q, want user, operator or admin", apperrors.ErrInvalidRole, role)
+	}
+	if actorID == userID {
+		return nil, fmt.Errorf("%w: you can't change your own role", apperrors.ErrForbidden)
+	}
+	if _, err := s.users.GetUserByID(userID); err != nil {
+		return nil, apperrors.ErrUserNotFound
+	}
+	if err := s.users.SetUserRole(userID, r); err != nil {
+		return nil, fmt.Errorf("SetRole: %w", err)
+	}
+	return s.users.GetUserByID(userID)
+}
+
+// Freeze stops a user logging in and makes their existing tokens and
+// API keys fail on the next request. Open orders are left alone —
+// cancel them separately if they have to go too.
+func (s *AdminService) Freeze(ctx context.Context, actorID, userID uuid.UUID, reason string) (*models.User, error) {
+	if actorID == userID {
+		return nil, fmt.Errorf("%w: you can't freeze your own account", apperrors.ErrForbidden)
+	}
+	now := time.Now().UTC()
+	return s.setFrozen(ctx, userID, &now, reason)
+}
+
+func (s *AdminService) Unfreeze(ctx context.Context, userID uuid.UUID) (*models.User, error) {
+	return s.setFrozen(ctx, userID, nil, "")
+}
+
+// setFrozen writes the database first — it's the source of truth, and
+// SyncFrozen can repair the cache from it but not the other way round.
+func (s *AdminService) setFrozen(ctx context.Context, userID uuid.UUID, at *time.Time, reason string) (*models.User, error) {
+	if _, err := s.users.GetUserByID(userID); err != nil {
+		return nil, apperrors.ErrUserNotFound
+	}
+	if err := s.users.SetUserFrozen(userID, at, reason); err != nil {
+		return nil, fmt.Errorf("setFrozen: %w", err)
+	}
+	if err := s.cache.SetUserFrozen(ctx, userID.String(), at != nil); err != nil {
+		return nil, fmt.Errorf("setFrozen: %w", err)
+	}
+	return s.users.GetUserByID(userID)
+}
+
+// ─── Audit log ────────────────────────────────────────────────────────────────
+
+func (s *AdminService) Record(ctx context.Context, entry *models.AuditLog) error {
+	entry.ID = uuid.New()
+	entry.CreatedAt = time.Now().UTC()
+	if err := s.audit.CreateAuditLog(entry); err != nil {
+		return fmt.Errorf("Record: %w", err)
+	}
+	return nil
+}
+
+func (s *AdminService) AuditLog(ctx context.Context, limit int) ([]models.AuditLog, error) {
+	entries, err := s.audit.ListAuditLogs(models.ClampPageLimit(limit))
+	if err != nil {
+		return nil, fmt.Errorf("AuditLog: %w", err)
+	}
+	return entries, nil
+}
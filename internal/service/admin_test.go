@@ -0,0 +1,156 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/cache"
+	"github.com/Im-Manav/ome/internal/config"
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+type userRepo struct {
+	users map[uuid.UUID]*models.User
+}
+
+func (r *userRepo) CreateUser(u *models.User) error {
+	r.users[u.ID] = u
+	return nil
+}
+
+func (r *userRepo) GetUserByEmail(email string) (*models.User, error) {
+	for _, u := range r.users {
+		if u.Email == email {
+			return u, nil
+		}
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (r *userRepo) GetUserByID(id uuid.UUID) (*models.User, error) {
+	if u, ok := r.users[id]; ok {
+		return u, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (r *userRepo) SearchUsers(filter models.UserFilter) ([]*models.User, error) {
+	var out []*models.User
+	for _, u := range r.users {
+		if filter.Frozen == nil || (u.FrozenAt != nil) == *filter.Frozen {
+			out = append(out, u)
+		}
+	}
+	return out, nil
+}
+
+func (r *userRepo) SetUserRole(id uuid.UUID, role models.Role) error {
+	r.users[id].Role = role
+	return nil
+}
+
+func (r *userRepo) SetUserFrozen(id uuid.UUID, frozenAt *time.Time, reason string) error {
+	r.users[id].FrozenAt, r.users[id].FrozenReason = frozenAt, reason
+	return nil
+}
+
+type auditRepo struct{ entries []models.AuditLog }
+
+func (r *auditRepo) CreateAuditLog(e *models.AuditLog) error {
+	r.entries = append(r.entries, *e)
+	return nil
+}
+
+func (r *auditRepo) ListAuditLogs(limit int) ([]models.AuditLog, error) { return r.entries, nil }
+
+func TestAdminFreezeAndRoles(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	admin := &models.User{ID: uuid.New(), Email: "root@example.com", Role: models.RoleUser}
+	alice := &models.User{ID: uuid.New(), Email: "alice@example.com", Role: models.RoleUser}
+	users := &userRepo{users: map[uuid.UUID]*models.User{admin.ID: admin, alice.ID: alice}}
+	svc := NewAdminService(users, &auditRepo{}, mem)
+
+	if err := svc.PromoteAdmins(ctx, []string{"root@example.com", "nobody@example.com"}); err != nil {
+		t.Fatalf("PromoteAdmins: %v", err)
+	}
+	if admin.Role != models.RoleAdmin {
+		t.Fatalf("root role = %s, want admin", admin.Role)
+	}
+
+	t.Run("no self-service", func(t *testing.T) {
+		if _, err := svc.Freeze(ctx, admin.ID, admin.ID, "oops"); !errors.Is(err, apperrors.ErrForbidden) {
+			t.Errorf("self freeze: got %v, want ErrForbidden", err)
+		}
+		if _, err := svc.SetRole(ctx, admin.ID, admin.ID, "user"); !errors.Is(err, apperrors.ErrForbidden) {
+			t.Errorf("self demote: got %v, want ErrForbidden", err)
+		}
+		if _, err := svc.SetRole(ctx, admin.ID, alice.ID, "superuser"); !errors.Is(err, apperrors.ErrInvalidRole) {
+			t.Errorf("bad role: got %v, want ErrInvalidRole", err)
+		}
+	})
+
+	t.Run("freeze marks the cache", func(t *testing.T) {
+		if _, err := svc.Freeze(ctx, admin.ID, alice.ID, "chargeback"); err != nil {
+			t.Fatalf("Freeze: %v", err)
+		}
+		if frozen, _ := mem.IsUserFrozen(ctx, alice.ID.String()); !frozen {
+			t.Error("alice not frozen in cache")
+		}
+
+		// A flushed cache is rebuilt from the users table.
+		mem.SetUserFrozen(ctx, alice.ID.String(), false)
+		if err := svc.SyncFrozen(ctx); err != nil {
+			t.Fatalf("SyncFrozen: %v", err)
+		}
+		if frozen, _ := mem.IsUserFrozen(ctx, alice.ID.String()); !frozen {
+			t.Error("alice not frozen after SyncFrozen")
+		}
+
+		if _, err := svc.Unfreeze(ctx, alice.ID); err != nil {
+			t.Fatalf("Unfreeze: %v", err)
+		}
+		if frozen, _ := mem.IsUserFrozen(ctx, alice.ID.String()); frozen || alice.FrozenAt != nil {
+			t.Error("alice still frozen after Unfreeze")
+		}
+	})
+}
+
+func TestRegisterNeverGrantsAdmin(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	users := &userRepo{users: map[uuid.UUID]*models.User{}}
+	cfg := &config.Config{
+		JWTSecret: "test", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour,
+		AdminEmails: []string{"root@example.com"},
+	}
+	auth := NewAuthService(users, &sessionRepo{sessions: map[uuid.UUID]*models.Session{}}, mem, cfg)
+
+	resp, err := auth.Register(ctx, models.RegisterRequest{Email: "root@example.com", Password: "password123"}, Client{})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	if resp.User.Role != models.RoleUser {
+		t.Fatalf("registered role = %s, want user", resp.User.Role)
+	}
+
+	// The next start promotes the account.
+	if err := NewAdminService(users, &auditRepo{}, mem).PromoteAdmins(ctx, cfg.AdminEmails); err != nil {
+		t.Fatalf("PromoteAdmins: %v", err)
+	}
+	if user, _ := users.GetUserByID(resp.User.ID); user.Role != models.RoleAdmin {
+		t.Errorf("role after PromoteAdmins = %s, want admin", user.Role)
+	}
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/ports"
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+// SignatureWindow is how far a signed request's timestamp may be from
+// the server clock. Each signature is remembered for twice as long, so a
+// replay is caught wherever in the window it lands.
+const SignatureWindow = 30 * time.Second
+
+const apiKeyPrefix = "ome_"
+
+// APIKeyService issues API keys and authenticates requests signed with
+// them. Secrets are encrypted with AES-GCM under a key derived from the
+// configured encryption key, with the public key as associated data so
+// a ciphertext can't be moved onto another key's row.
+type APIKeyService struct {
+	repo  ports.APIKeyRepository
+	cache ports.Cache
+	aead  cipher.AEAD
+	now   func() time.Time
+}
+
+func NewAPIKeyService(
+	repo ports.APIKeyRepository,
+	cache ports.Cache,
+	encryptionKey string,
+) (*APIKeyService, error) {
+	sum := sha256.Sum256([]byte(encryptionKey))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, fmt.Errorf("NewAPIKeyService: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("NewAPIKeyService: %w", err)
+	}
+	return &APIKeyService{
+		repo:  repo,
+		cache: cache,
+		aead:  aead,
+		now:   func() time.Time { return time.Now().UTC() },
+	}, nil
+}
+
+// SignRequest computes a request's signature: hex HMAC-SHA256, keyed
+// with the secret, over
+//
+//	timestamp + "\n" + METHOD + "\n" + path?query + "\n" + body
+//
+// where timestamp is Unix milliseconds, sent as X-OME-TIMESTAMP.
+func SignRequest(secret, timestamp, method, path string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp + "\n" + strings.ToUpper(method) + "\n" + path + "\n"))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// ─── Key management ───────────────────────────────────────────────────────────
+
+// Create issues a key. The secret is in the response and nowhere else —
+// it can't be shown again.
+func (s *APIKeyService) Create(
+	ctx context.Context,
+	userID uuid.UUID,
+	req models.CreateAPIKeyRequest,
+) (*models.CreateAPIKeyResponse, error) {
+	scopes, err := normaliseScopes(req.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	allowed, err := normaliseIPs(req.AllowedIPs)
+	if err != nil {
+		return nil, err
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(s.now()) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", apperrors.ErrInvalidAPIKeyReq)
+	}
+
+	publicKey, err := randomHex(16)
+	if err != nil {
+		return nil, fmt.Errorf("Create: %w", err)
+	}
+	publicKey = apiKeyPrefix + publicKey
+	secret, err := randomHex(32)
+	if err != nil {
+		return nil, fmt.Errorf("Create: %w", err)
+	}
+	sealed, err := s.seal(publicKey, secret)
+	if err != nil {
+		return nil, fmt.Errorf("Create: %w", err)
+	}
+
+	key := &models.APIKey{
+		ID:              uuid.New(),
+		UserID:          userID,
+		Key:             publicKey,
+		Name:            req.Name,
+		Scopes:          strings.Join(scopes, ","),
+		AllowedIPs:      strings.Join(allowed, ","),
+		SecretEncrypted: sealed,
+		ExpiresAt:       req.ExpiresAt,
+		CreatedAt:       s.now(),
+	}
+	if err := s.repo.CreateAPIKey(key); err != nil {
+		return nil, fmt.Errorf("Create: %w", err)
+	}
+	return &models.CreateAPIKeyResponse{APIKeyView: key.View(), Secret: secret}, nil
+}
+
+func (s *APIKeyService) List(ctx context.Context, userID uuid.UUID) ([]models.APIKeyView, error) {
+	keys, err := s.repo.ListAPIKeysByUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	views := make([]models.APIKeyView, len(keys))
+	for i, k := range keys {
+		views[i] = k.View()
+	}
+	return views, nil
+}
+
+// Revoke stops a key working immediately. Revoking a revoked key is a
+// no-op; another user's key looks the same as a missing one.
+func (s *APIKeyService) Revoke(ctx context.Context, userID, id uuid.UUID) error {
+	key, err := s.repo.GetAPIKeyByID(id)
+	if err != nil || key.UserID != userID {
+		return apperrors.ErrAPIKeyNotFound
+	}
+	if key.RevokedAt != nil {
+		return nil
+	}
+	if err := s.repo.RevokeAPIKey(id); err != nil {
+		return fmt.Errorf("Revoke: %w", err)
+	}
+	return nil
+}
+
+// ─── Request authentication ───────────────────────────────────────────────────
+
+// SignedRequest is what the middleware extracts from a request signed
+// with an API key.
+type SignedRequest struct {
+	Key       string
+	Timestamp string // Unix milliseconds
+	Signature string
+	Method    string
+	Path      string // path and raw query, as sent
+	Body      []byte
+	ClientIP  string
+}
+
+// Authenticate checks a signed request and returns the key it was
+// signed with. Failures wrap ErrUnauthorized, or ErrForbidden for a
+// good signature from an address the key doesn't allow.
+func (s *APIKeyService) Authenticate(ctx context.Context, req SignedRequest) (*models.APIKey, error) {
+	ms, err := strconv.ParseInt(req.Timestamp, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: timestamp must be unix milliseconds", apperrors.ErrUnauthorized)
+	}
+	if skew := s.now().Sub(time.UnixMilli(ms)); skew > SignatureWindow || skew < -SignatureWindow {
+		return nil, fmt.Errorf("%w: timestamp outside the %s window", apperrors.ErrUnauthorized, SignatureWindow)
+	}
+
+	key, err := s.repo.GetAPIKeyByKey(req.Key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unknown api key", apperrors.ErrUnauthorized)
+	}
+	if !key.Active(s.now()) {
+		return nil, fmt.Errorf("%w: api key expired or revoked", apperrors.ErrUnauthorized)
+	}
+
+	secret, err := s.open(key)
+	if err != nil {
+		return nil, fmt.Errorf("Authenticate: %w", err)
+	}
+	want, _ := hex.DecodeString(SignRequest(secret, req.Timestamp, req.Method, req.Path, req.Body))
+	got, err := hex.DecodeString(req.Signature)
+	if err != nil || !hmac.Equal(got, want) {
+		return nil, fmt.Errorf("%w: signature mismatch", apperrors.ErrUnauthorized)
+	}
+
+	if !ipAllowed(key.AllowedIPList(), req.ClientIP) {
+		return nil, fmt.Errorf("%w: %s is not on this key's allowlist", apperrors.ErrForbidden, req.ClientIP)
+	}
+
+	// Only a valid signature claims its nonce, so forged requests can't
+	// burn a legitimate client's.
+	// Keyed on the decoded signature, so re-encoding it (upper-case
+	// hex, say) doesn't make a replay look new.
+	fresh, err := s.cache.SetNonce(ctx, "sig:"+hex.EncodeToString(want), 2*SignatureWindow)
+	if err != nil {
+		return nil, fmt.Errorf("Authenticate: %w", err)
+	}
+	if !fresh {
+		return nil, fmt.Errorf("%w: replayed request", apperrors.ErrUnauthorized)
+	}
+	return key, nil
+}
+
+// ─── Helpers ──────────────────────────────────────────────────────────────────
+
+func (s *APIKeyService) seal(publicKey, secret string) ([]byte, error) {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return s.aead.Seal(nonce, nonce, []byte(secret), []byte(publicKey)), nil
+}
+
+func (s *APIKeyService) open(key *models.APIKey) (string, error) {
+	n := s.aead.NonceSize()
+	if len(key.SecretEncrypted) < n {
+		return "", fmt.Errorf("secret for key %s is truncated", key.Key)
+	}
+	nonce, sealed := key.SecretEncrypted[:n], key.SecretEncrypted[n:]
+	secret, err := s.aead.Open(nil, nonce, sealed, []byte(key.Key))
+	if err != nil {
+		return "", fmt.Errorf("decrypt secret for key %s: %w", key.Key, err)
+	}
+	return string(secret), nil
+}
+
+func normaliseScopes(scopes []string) ([]string, error) {
+	var out []string
+	for _, scope := range scopes {
+		scope = strings.ToLower(strings.TrimSpace(scope))
+		if !models.IsValidScope(scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q", apperrors.ErrInvalidAPIKeyReq, scope)
+		}
+		if !slices.Contains(out, scope) {
+			out = append(out, scope)
+		}
+	}
+	return out, nil
+}
+
+// normaliseIPs accepts IPs and CIDRs and stores each in canonical form.
+func normaliseIPs(entries []string) ([]string, error) {
+	out := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			out = append(out, network.String())
+			continue
+		}
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return nil, fmt.Errorf("%w: %q is not an IP or CIDR", apperrors.ErrInvalidAPIKeyReq, entry)
+		}
+		out = append(out, ip.String())
+	}
+	return out, nil
+}
+
+// ipAllowed reports whether ip matches the allowlist. An empty list
+// allows any address.
+func ipAllowed(allowlist []string, ip string) bool {
+	if len(allowlist) == 0 {
+		return true
+	}
+	addr := net.ParseIP(ip)
+	if addr == nil {
+		return false
+	}
+	for _, entry := range allowlist {
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			if network.Contains(addr) {
+				return true
+			}
+		} else if other := net.ParseIP(entry); other != nil && other.Equal(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func randomHex(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
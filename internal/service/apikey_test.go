@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/cache"
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+type apiKeyRepo struct {
+	keys map[uuid.UUID]*models.APIKey
+}
+
+func (r *apiKeyRepo) CreateAPIKey(k *models.APIKey) error {
+	r.keys[k.ID] = k
+	return nil
+}
+
+func (r *apiKeyRepo) GetAPIKeyByID(id uuid.UUID) (*models.APIKey, error) {
+	if k, ok := r.keys[id]; ok {
+		return k, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (r *apiKeyRepo) GetAPIKeyByKey(key string) (*models.APIKey, error) {
+	for _, k := range r.keys {
+		if k.Key == key {
+			return k, nil
+		}
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (r *apiKeyRepo) ListAPIKeysByUserID(uuid.UUID) ([]*models.APIKey, error) { return nil, nil }
+
+func (r *apiKeyRepo) RevokeAPIKey(id uuid.UUID) error {
+	now := time.Now()
+	r.keys[id].RevokedAt = &now
+	return nil
+}
+
+func TestAPIKeySignedRequests(t *testing.T) {
+	ctx := context.Background()
+	mem := cache.NewMemory()
+	defer mem.Close()
+	svc, err := NewAPIKeyService(&apiKeyRepo{keys: map[uuid.UUID]*models.APIKey{}}, mem, "test-key")
+	if err != nil {
+		t.Fatalf("NewAPIKeyService: %v", err)
+	}
+
+	user := uuid.New()
+	created, err := svc.Create(ctx, user, models.CreateAPIKeyRequest{
+		Name: "bot", Scopes: []string{"Read", "trade", "read"}, AllowedIPs: []string{"10.0.0.0/8"},
+	})
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if len(created.Scopes) != 2 || !created.HasScope(models.ScopeTrade) {
+		t.Errorf("expected scopes normalised to [read trade], got %v", created.Scopes)
+	}
+	if string(created.SecretEncrypted) == created.Secret {
+		t.Error("secret stored in plain text")
+	}
+
+	signed := func(ts time.Time, body string) SignedRequest {
+		stamp := strconv.FormatInt(ts.UnixMilli(), 10)
+		return SignedRequest{
+			Key: created.Key, Timestamp: stamp, Method: "POST", Path: "/api/v1/orders",
+			Body: []byte(body), ClientIP: "10.1.2.3",
+			Signature: SignRequest(created.Secret, stamp, "POST", "/api/v1/orders", []byte(body)),
+		}
+	}
+
+	req := signed(time.Now(), `{"symbol":"BTC-USD"}`)
+	key, err := svc.Authenticate(ctx, req)
+	if err != nil || key.UserID != user {
+		t.Fatalf("Authenticate: %v", err)
+	}
+
+	tampered := signed(time.Now().Add(time.Millisecond), `{"symbol":"BTC-USD"}`)
+	tampered.Body = []byte(`{"symbol":"ETH-USD"}`)
+	outsideIP := signed(time.Now().Add(2*time.Millisecond), "")
+	outsideIP.ClientIP = "192.168.1.1"
+	upperCase := req
+	upperCase.Signature = strings.ToUpper(req.Signature)
+
+	for name, tc := range map[string]struct {
+		req  SignedRequest
+		want error
+	}{
+		"replay":       {req, apperrors.ErrUnauthorized},
+		"re-encoded":   {upperCase, apperrors.ErrUnauthorized},
+		"tampered":     {tampered, apperrors.ErrUnauthorized},
+		"stale":        {signed(time.Now().Add(-time.Minute), ""), apperrors.ErrUnauthorized},
+		"ip allowlist": {outsideIP, apperrors.ErrForbidden},
+	} {
+		if _, err := svc.Authenticate(ctx, tc.req); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", name, tc.want, err)
+		}
+	}
+
+	if err := svc.Revoke(ctx, uuid.New(), created.ID); !errors.Is(err, apperrors.ErrAPIKeyNotFound) {
+		t.Errorf("expected another user's key to look missing, got %v", err)
+	}
+	if err := svc.Revoke(ctx, user, created.ID); err != nil {
+		t.Fatalf("Revoke: %v", err)
+	}
+	if _, err := svc.Authenticate(ctx, signed(time.Now().Add(3*time.Millisecond), "")); !errors.Is(err, apperrors.ErrUnauthorized) {
+		t.Errorf("expected a revoked key to be rejected, got %v", err)
+	}
+}
@@ -2,45 +2,69 @@ package service
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Im-Manav/ome/internal/config"
 	"github.com/Im-Manav/ome/internal/ports"
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/logger"
 	"github.com/Im-Manav/ome/pkg/models"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type AuthService struct {
 	userRepo ports.UserRepository
+	sessions ports.SessionRepository
 	cache    ports.Cache
 	cfg      *config.Config
 }
 
 func NewAuthService(
 	userRepo ports.UserRepository,
+	sessions ports.SessionRepository,
 	cache ports.Cache,
 	cfg *config.Config,
 ) *AuthService {
 	return &AuthService{
 		userRepo: userRepo,
+		sessions: sessions,
 		cache:    cache,
 		cfg:      cfg,
 	}
 }
 
+// Claims carry the user's role as of the last login or refresh, so a
+// role change takes effect within one access token lifetime. Freezing
+// and session revocation are checked per request instead, so they apply
+// at once.
 type Claims struct {
-	UserID string `json:"user_id"`
-	Email  string `json:"email"`
-	JTI    string `json:"jti"`
+	UserID    string      `json:"user_id"`
+	Email     string      `json:"email"`
+	Role      models.Role `json:"role"`
+	JTI       string      `json:"jti"`
+	SessionID string      `json:"sid,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// Client describes where a login comes from, for the session list.
+type Client struct {
+	UserAgent string
+	IP        string
+}
+
 func (s *AuthService) Register(
 	ctx context.Context,
 	req models.RegisterRequest,
+	client Client,
 ) (*models.AuthResponse, error) {
 	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), 12)
 	if err != nil {
@@ -51,23 +75,20 @@ func (s *AuthService) Register(
 		ID:           uuid.New(),
 		Email:        req.Email,
 		PasswordHash: string(hash),
+		Role:         models.RoleUser,
 	}
 
 	if err := s.userRepo.CreateUser(user); err != nil {
 		return nil, fmt.Errorf("create user: %w", err)
 	}
 
-	token, err := s.generateToken(user)
-	if err != nil {
-		return nil, err
-	}
-
-	return &models.AuthResponse{Token: token, User: *user}, nil
+	return s.startSession(user, client)
 }
 
 func (s *AuthService) Login(
 	ctx context.Context,
 	req models.LoginRequest,
+	client Client,
 ) (*models.AuthResponse, error) {
 	user, err := s.userRepo.GetUserByEmail(req.Email)
 	if err != nil {
@@ -80,21 +101,96 @@ func (s *AuthService) Login(
 	); err != nil {
 		return nil, fmt.Errorf("invalid credentials")
 	}
+	if user.FrozenAt != nil {
+		return nil, apperrors.ErrAccountFrozen
+	}
+
+	return s.startSession(user, client)
+}
 
-	token, err := s.generateToken(user)
+// Refresh trades a refresh token for a new access token and a new
+// refresh token. Each refresh token works once: presenting any that has
+// already been used means it leaked, so the session is revoked and both
+// holders have to log in again.
+func (s *AuthService) Refresh(
+	ctx context.Context,
+	refreshToken string,
+	client Client,
+) (*models.AuthResponse, error) {
+	parts := strings.Split(refreshToken, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed refresh token", apperrors.ErrUnauthorized)
+	}
+	sessionID, err := uuid.Parse(parts[0])
+	generation, genErr := strconv.Atoi(parts[1])
+	if err != nil || genErr != nil || generation < 0 {
+		return nil, fmt.Errorf("%w: malformed refresh token", apperrors.ErrUnauthorized)
+	}
+	// A secret that was never issued is just a bad token.
+	secret := parts[2]
+	if !hmac.Equal([]byte(secret), []byte(s.refreshSecret(sessionID, generation))) {
+		return nil, fmt.Errorf("%w: invalid refresh token", apperrors.ErrUnauthorized)
+	}
+	session, err := s.sessions.GetSession(sessionID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("%w: unknown session", apperrors.ErrUnauthorized)
+	}
+	if !session.Active(time.Now()) {
+		return nil, fmt.Errorf("%w: session expired or revoked", apperrors.ErrUnauthorized)
 	}
 
-	return &models.AuthResponse{Token: token, User: *user}, nil
+	user, err := s.userRepo.GetUserByID(session.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("Refresh: %w", err)
+	}
+	if user.FrozenAt != nil {
+		return nil, apperrors.ErrAccountFrozen
+	}
+
+	if generation > session.Generation {
+		return nil, fmt.Errorf("%w: invalid refresh token", apperrors.ErrUnauthorized)
+	}
+	// A token from an earlier generation, or one rotated away by a
+	// request racing this one, was copied.
+	rotated := false
+	if generation == session.Generation {
+		next := s.refreshSecret(session.ID, generation+1)
+		rotated, err = s.sessions.RotateSession(session.ID, hashSecret(secret), hashSecret(next), client.IP, time.Now().UTC())
+		if err != nil {
+			return nil, fmt.Errorf("Refresh: %w", err)
+		}
+	}
+	if !rotated {
+		logger.Warn("refresh token reused, revoking session",
+			zap.String("session_id", session.ID.String()),
+			zap.String("user_id", session.UserID.String()),
+			zap.String("ip", client.IP),
+		)
+		if err := s.revoke(ctx, session.ID, "refresh token reused"); err != nil {
+			return nil, fmt.Errorf("Refresh: %w", err)
+		}
+		return nil, fmt.Errorf("%w: refresh token already used, session revoked", apperrors.ErrUnauthorized)
+	}
+
+	return s.issue(user, session.ID, generation+1)
 }
 
+// Logout blocklists the access token and ends its session, so the
+// refresh token stops working too.
 func (s *AuthService) Logout(ctx context.Context, tokenString string) error {
 	claims, err := s.ValidateToken(tokenString)
 	if err != nil {
 		return err
 	}
 
+	if claims.SessionID != "" {
+		if sessionID, err := uuid.Parse(claims.SessionID); err == nil {
+			if err := s.revoke(ctx, sessionID, "logout"); err != nil {
+				return err
+			}
+		}
+	}
+
 	remaining := time.Until(claims.ExpiresAt.Time)
 	if remaining <= 0 {
 		return nil
@@ -103,6 +199,78 @@ func (s *AuthService) Logout(ctx context.Context, tokenString string) error {
 	return s.cache.SetWithExpiry(ctx, claims.JTI, "blocked", remaining)
 }
 
+// ─── Sessions ─────────────────────────────────────────────────────────────────
+
+// ListSessions returns the user's active sessions, flagging the one
+// with ID current.
+func (s *AuthService) ListSessions(ctx context.Context, userID uuid.UUID, current string) ([]*models.Session, error) {
+	sessions, err := s.sessions.ListActiveSessions(userID, time.Now().UTC())
+	if err != nil {
+		return nil, fmt.Errorf("ListSessions: %w", err)
+	}
+	for _, session := range sessions {
+		session.Current = session.ID.String() == current
+	}
+	return sessions, nil
+}
+
+// RevokeSession ends one of the user's sessions. Another user's session
+// looks the same as a missing one.
+func (s *AuthService) RevokeSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	session, err := s.sessions.GetSession(sessionID)
+	if err != nil || session.UserID != userID {
+		return apperrors.ErrSessionNotFound
+	}
+	if session.RevokedAt != nil {
+		return nil
+	}
+	return s.revoke(ctx, sessionID, "revoked by user")
+}
+
+// RevokeAllSessions ends every session the user has, the current one
+// included.
+func (s *AuthService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	ids, err := s.sessions.RevokeUserSessions(userID, "revoked by user")
+	if err != nil {
+		return fmt.Errorf("RevokeAllSessions: %w", err)
+	}
+	for _, id := range ids {
+		if err := s.cache.RevokeSession(ctx, id.String(), s.cfg.AccessTokenTTL); err != nil {
+			return fmt.Errorf("RevokeAllSessions: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *AuthService) IsSessionRevoked(ctx context.Context, sessionID string) (bool, error) {
+	return s.cache.IsSessionRevoked(ctx, sessionID)
+}
+
+// SyncRevokedSessions restores the cache markers for sessions revoked
+// within the last access token lifetime — the ones whose tokens could
+// still be in use — for a cache that was flushed or lives in memory.
+func (s *AuthService) SyncRevokedSessions(ctx context.Context) error {
+	ids, err := s.sessions.ListSessionsRevokedSince(time.Now().Add(-s.cfg.AccessTokenTTL))
+	if err != nil {
+		return fmt.Errorf("SyncRevokedSessions: %w", err)
+	}
+	for _, id := range ids {
+		if err := s.cache.RevokeSession(ctx, id.String(), s.cfg.AccessTokenTTL); err != nil {
+			return fmt.Errorf("SyncRevokedSessions: %w", err)
+		}
+	}
+	return nil
+}
+
+// revoke marks the session revoked in the database, then in the cache
+// where the auth middleware sees it.
+func (s *AuthService) revoke(ctx context.Context, sessionID uuid.UUID, reason string) error {
+	if err := s.sessions.RevokeSession(sessionID, reason); err != nil {
+		return err
+	}
+	return s.cache.RevokeSession(ctx, sessionID.String(), s.cfg.AccessTokenTTL)
+}
+
 func (s *AuthService) ValidateToken(tokenString string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenString,
@@ -134,22 +302,80 @@ func (s *AuthService) IsBlocklisted(ctx context.Context, jti string) (bool, erro
 	return val == "blocked", nil
 }
 
-func (s *AuthService) generateToken(user *models.User) (string, error) {
+// IsFrozen reports whether an admin has frozen the user's account.
+func (s *AuthService) IsFrozen(ctx context.Context, userID uuid.UUID) (bool, error) {
+	return s.cache.IsUserFrozen(ctx, userID.String())
+}
+
+// startSession records a new session for the user and issues its first
+// token pair.
+func (s *AuthService) startSession(user *models.User, client Client) (*models.AuthResponse, error) {
+	now := time.Now().UTC()
+	id := uuid.New()
+	session := &models.Session{
+		ID:          id,
+		UserID:      user.ID,
+		RefreshHash: hashSecret(s.refreshSecret(id, 0)),
+		UserAgent:   client.UserAgent,
+		IP:          client.IP,
+		CreatedAt:   now,
+		LastUsedAt:  now,
+		ExpiresAt:   now.Add(s.cfg.RefreshTokenTTL),
+	}
+	if err := s.sessions.CreateSession(session); err != nil {
+		return nil, fmt.Errorf("startSession: %w", err)
+	}
+	return s.issue(user, session.ID, 0)
+}
+
+// issue returns a new access token and the session's refresh token for
+// generation.
+func (s *AuthService) issue(user *models.User, sessionID uuid.UUID, generation int) (*models.AuthResponse, error) {
+	expiresAt := time.Now().Add(s.cfg.AccessTokenTTL)
+	token, err := s.generateToken(user, sessionID, expiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return &models.AuthResponse{
+		Token:        token,
+		ExpiresAt:    expiresAt.UTC(),
+		RefreshToken: sessionID.String() + "." + strconv.Itoa(generation) + "." + s.refreshSecret(sessionID, generation),
+		User:         *user,
+	}, nil
+}
+
+func (s *AuthService) generateToken(user *models.User, sessionID uuid.UUID, expiresAt time.Time) (string, error) {
 	jti := uuid.New().String()
 
 	claims := &Claims{
-		UserID: user.ID.String(),
-		Email:  user.Email,
-		JTI:    jti,
+		UserID:    user.ID.String(),
+		Email:     user.Email,
+		Role:      user.Role,
+		JTI:       jti,
+		SessionID: sessionID.String(),
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(
-				time.Now().Add(time.Duration(s.cfg.JWTExpiryHours) * time.Hour),
-			),
-			IssuedAt: jwt.NewNumericDate(time.Now()),
-			Issuer:   "ome",
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			Issuer:    "ome",
 		},
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	return token.SignedString([]byte(s.cfg.JWTSecret))
 }
+
+// refreshSecret is the secret for one generation of a session: a MAC
+// under the JWT secret, so it can be recomputed to recognise a spent
+// token but not forged without the key.
+func (s *AuthService) refreshSecret(sessionID uuid.UUID, generation int) string {
+	mac := hmac.New(sha256.New, []byte(s.cfg.JWTSecret))
+	fmt.Fprintf(mac, "refresh:%s:%d", sessionID, generation)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// hashSecret is how refresh secrets are stored. They are 256-bit MACs,
+// so a fast hash is enough — there is nothing to brute-force.
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,203 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/cache"
+	"github.com/Im-Manav/ome/internal/config"
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+type sessionRepo struct {
+	sessions map[uuid.UUID]*models.Session
+}
+
+func (r *sessionRepo) CreateSession(s *models.Session) error {
+	r.sessions[s.ID] = s
+	return nil
+}
+
+func (r *sessionRepo) GetSession(id uuid.UUID) (*models.Session, error) {
+	if s, ok := r.sessions[id]; ok {
+		cp := *s
+		return &cp, nil
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (r *sessionRepo) RotateSession(id uuid.UUID, oldHash, newHash, ip string, usedAt time.Time) (bool, error) {
+	s := r.sessions[id]
+	if s.RefreshHash != oldHash || s.RevokedAt != nil {
+		return false, nil
+	}
+	s.RefreshHash, s.IP, s.LastUsedAt = newHash, ip, usedAt
+	s.Generation++
+	return true, nil
+}
+
+func (r *sessionRepo) ListActiveSessions(userID uuid.UUID, now time.Time) ([]*models.Session, error) {
+	var out []*models.Session
+	for _, s := range r.sessions {
+		if s.UserID == userID && s.Active(now) {
+			cp := *s
+			out = append(out, &cp)
+		}
+	}
+	return out, nil
+}
+
+func (r *sessionRepo) RevokeSession(id uuid.UUID, reason string) error {
+	now := time.Now()
+	r.sessions[id].RevokedAt, r.sessions[id].RevokedReason = &now, reason
+	return nil
+}
+
+func (r *sessionRepo) RevokeUserSessions(userID uuid.UUID, reason string) ([]uuid.UUID, error) {
+	var ids []uuid.UUID
+	for id, s := range r.sessions {
+		if s.UserID == userID && s.RevokedAt == nil {
+			r.RevokeSession(id, reason)
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
+
+func (r *sessionRepo) ListSessionsRevokedSince(time.Time) ([]uuid.UUID, error) { return nil, nil }
+
+func TestAuthRefreshRotation(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	cfg := &config.Config{JWTSecret: "test", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}
+	sessions := &sessionRepo{sessions: map[uuid.UUID]*models.Session{}}
+	svc := NewAuthService(&userRepo{users: map[uuid.UUID]*models.User{}}, sessions, mem, cfg)
+
+	laptop := Client{UserAgent: "laptop", IP: "10.0.0.1"}
+	first, err := svc.Register(ctx, models.RegisterRequest{Email: "a@example.com", Password: "password123"}, laptop)
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	claims, err := svc.ValidateToken(first.Token)
+	if err != nil || claims.SessionID == "" {
+		t.Fatalf("expected a session id in the token, got %+v (%v)", claims, err)
+	}
+
+	second, err := svc.Refresh(ctx, first.RefreshToken, laptop)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken {
+		t.Fatal("expected the refresh token to rotate")
+	}
+	third, err := svc.Refresh(ctx, second.RefreshToken, laptop)
+	if err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+
+	// Replaying a spent token — even one rotated away twice — revokes
+	// the session, so the current refresh token and access tokens stop
+	// working too.
+	if _, err := svc.Refresh(ctx, first.RefreshToken, laptop); !errors.Is(err, apperrors.ErrUnauthorized) {
+		t.Fatalf("reuse: got %v, want ErrUnauthorized", err)
+	}
+	if _, err := svc.Refresh(ctx, third.RefreshToken, laptop); !errors.Is(err, apperrors.ErrUnauthorized) {
+		t.Errorf("after reuse: got %v, want ErrUnauthorized", err)
+	}
+	if revoked, _ := svc.IsSessionRevoked(ctx, claims.SessionID); !revoked {
+		t.Error("expected the session to be marked revoked in the cache")
+	}
+}
+
+func TestAuthRefreshWrongSecretKeepsSession(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	cfg := &config.Config{JWTSecret: "test", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}
+	svc := NewAuthService(&userRepo{users: map[uuid.UUID]*models.User{}},
+		&sessionRepo{sessions: map[uuid.UUID]*models.Session{}}, mem, cfg)
+
+	auth, err := svc.Register(ctx, models.RegisterRequest{Email: "a@example.com", Password: "password123"}, Client{})
+	if err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	sessionID, _, _ := strings.Cut(auth.RefreshToken, ".")
+
+	// A secret that was never issued is rejected, but nobody's token
+	// was copied, so the session lives on — even claiming an earlier
+	// generation.
+	for _, token := range []string{sessionID + ".0.garbage", sessionID + ".garbage"} {
+		if _, err := svc.Refresh(ctx, token, Client{}); !errors.Is(err, apperrors.ErrUnauthorized) {
+			t.Fatalf("garbage secret: got %v, want ErrUnauthorized", err)
+		}
+	}
+	if _, err := svc.Refresh(ctx, auth.RefreshToken, Client{}); err != nil {
+		t.Fatalf("Refresh: %v", err)
+	}
+	if _, err := svc.Refresh(ctx, sessionID+".0.garbage", Client{}); !errors.Is(err, apperrors.ErrUnauthorized) {
+		t.Fatalf("garbage secret for a spent generation: got %v, want ErrUnauthorized", err)
+	}
+	if revoked, _ := svc.IsSessionRevoked(ctx, sessionID); revoked {
+		t.Error("expected the session not to be revoked")
+	}
+	if revoked, _ := svc.IsSessionRevoked(ctx, sessionID); revoked {
+		t.Error("expected the session not to be revoked by a forged spent token")
+	}
+}
+
+func TestAuthRevokeSessions(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	cfg := &config.Config{JWTSecret: "test", AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour}
+	users := &userRepo{users: map[uuid.UUID]*models.User{}}
+	svc := NewAuthService(users, &sessionRepo{sessions: map[uuid.UUID]*models.Session{}}, mem, cfg)
+
+	req := models.RegisterRequest{Email: "a@example.com", Password: "password123"}
+	if _, err := svc.Register(ctx, req, Client{UserAgent: "laptop"}); err != nil {
+		t.Fatalf("Register: %v", err)
+	}
+	phone, err := svc.Login(ctx, models.LoginRequest{Email: req.Email, Password: req.Password}, Client{UserAgent: "phone"})
+	if err != nil {
+		t.Fatalf("Login: %v", err)
+	}
+	userID := phone.User.ID
+	claims, _ := svc.ValidateToken(phone.Token)
+
+	list, err := svc.ListSessions(ctx, userID, claims.SessionID)
+	if err != nil || len(list) != 2 {
+		t.Fatalf("ListSessions: %d (%v)", len(list), err)
+	}
+	for _, s := range list {
+		if s.Current != (s.UserAgent == "phone") {
+			t.Errorf("session %s: current = %v", s.UserAgent, s.Current)
+		}
+	}
+
+	if err := svc.RevokeSession(ctx, uuid.New(), list[0].ID); !errors.Is(err, apperrors.ErrSessionNotFound) {
+		t.Errorf("revoking another user's session: got %v, want ErrSessionNotFound", err)
+	}
+	if err := svc.RevokeAllSessions(ctx, userID); err != nil {
+		t.Fatalf("RevokeAllSessions: %v", err)
+	}
+	if list, _ := svc.ListSessions(ctx, userID, ""); len(list) != 0 {
+		t.Errorf("expected no sessions left, got %d", len(list))
+	}
+	if revoked, _ := svc.IsSessionRevoked(ctx, claims.SessionID); !revoked {
+		t.Error("expected the phone session to be marked revoked")
+	}
+}
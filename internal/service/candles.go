@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/internal/tracing"
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/models"
+)
+
+const (
+	defaultCandleLimit = 300
+	maxCandleLimit     = 1000
+)
+
+// candleIntervals maps the API's interval names to the interval strings
+// the repository buckets by.
+var candleIntervals = map[string]struct {
+	bucket string
+	width  time.Duration
+}{
+	"1m":  {"1 minute", time.Minute},
+	"5m":  {"5 minutes", 5 * time.Minute},
+	"15m": {"15 minutes", 15 * time.Minute},
+	"1h":  {"1 hour", time.Hour},
+	"1d":  {"1 day", 24 * time.Hour},
+}
+
+// CandleService serves historical candles from the stored one-minute bars.
+type CandleService struct {
+	repo ports.OHLCVRepository
+}
+
+func NewCandleService(repo ports.OHLCVRepository) *CandleService {
+	return &CandleService{repo: repo}
+}
+
+// CandleQuery is a request for candles. Zero times are unbounded.
+type CandleQuery struct {
+	Symbol   string
+	Interval string // 1m | 5m | 15m | 1h | 1d
+	From     time.Time
+	To       time.Time
+	Limit    int
+	Fill     bool // emit flat, zero-volume candles for buckets with no trades
+}
+
+// GetCandles returns up to q.Limit of the newest candles in the range,
+// oldest first as charting libraries expect.
+func (s *CandleService) GetCandles(ctx context.Context, q CandleQuery) (*models.CandleSeries, error) {
+	if q.Symbol == "" {
+		return nil, apperrors.ErrSymbolRequired
+	}
+	iv, ok := candleIntervals[q.Interval]
+	if !ok {
+		return nil, fmt.Errorf("%w: interval must be one of 1m, 5m, 15m, 1h, 1d", apperrors.ErrInvalidQuery)
+	}
+	if !q.From.IsZero() && !q.To.IsZero() && !q.From.Before(q.To) {
+		return nil, fmt.Errorf("%w: from must be before to", apperrors.ErrInvalidQuery)
+	}
+	switch {
+	case q.Limit <= 0:
+		q.Limit = defaultCandleLimit
+	case q.Limit > maxCandleLimit:
+		q.Limit = maxCandleLimit
+	}
+
+	// Widen the range to whole buckets so the first and last candles
+	// aren't built from a fraction of their bars.
+	from, to := q.From, q.To
+	if !from.IsZero() {
+		from = from.UTC().Truncate(iv.width)
+	}
+	if !to.IsZero() && !to.UTC().Truncate(iv.width).Equal(to) {
+		to = to.UTC().Truncate(iv.width).Add(iv.width)
+	}
+
+	var bars []models.OHLCV
+	err := tracing.WithSpan(ctx, "db.GetOHLCVRange", func(context.Context) error {
+		var err error
+		bars, err = s.repo.GetOHLCVRange(q.Symbol, iv.bucket, from, to, q.Limit)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get candles: %w", err)
+	}
+	slices.Reverse(bars) // repository returns newest first
+
+	candles := make([]models.Candle, 0, len(bars))
+	for i, bar := range bars {
+		if q.Fill && i > 0 {
+			candles = fillGap(candles, bars[i-1], bar.Time, iv.width)
+		}
+		candles = append(candles, models.CandleFromOHLCV(bar))
+	}
+	if len(candles) > q.Limit {
+		candles = candles[len(candles)-q.Limit:]
+	}
+
+	return &models.CandleSeries{Symbol: q.Symbol, Interval: q.Interval, Candles: candles}, nil
+}
+
+// fillGap appends a flat candle at prev's close for every empty bucket
+// between prev and next.
+func fillGap(candles []models.Candle, prev models.OHLCV, next time.Time, width time.Duration) []models.Candle {
+	for t := prev.Time.Add(width); t.Before(next); t = t.Add(width) {
+		candles = append(candles, models.Candle{
+			Time: t.Unix(), Open: prev.Close, High: prev.Close, Low: prev.Close, Close: prev.Close,
+		})
+	}
+	return candles
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/models"
+)
+
+// ohlcvRepo returns fixed bars, newest first, and records the range asked for.
+type ohlcvRepo struct {
+	bars     []models.OHLCV
+	from, to time.Time
+}
+
+func (r *ohlcvRepo) UpsertOHLCV(*models.OHLCV) error { return nil }
+func (r *ohlcvRepo) GetOHLCV(string, string, int) ([]models.OHLCV, error) {
+	return r.bars, nil
+}
+func (r *ohlcvRepo) GetOHLCVRange(_, _ string, from, to time.Time, _ int) ([]models.OHLCV, error) {
+	r.from, r.to = from, to
+	return r.bars, nil
+}
+
+func TestGetCandlesAscendingWithGapsFilled(t *testing.T) {
+	base := time.Date(2025, 1, 2, 10, 0, 0, 0, time.UTC)
+	repo := &ohlcvRepo{bars: []models.OHLCV{
+		{Time: base.Add(15 * time.Minute), Open: 103, High: 104, Low: 102, Close: 103, Volume: 1},
+		{Time: base, Open: 100, High: 101, Low: 99, Close: 101, Volume: 2},
+	}}
+	svc := NewCandleService(repo)
+
+	series, err := svc.GetCandles(context.Background(), CandleQuery{
+		Symbol: "BTC-USD", Interval: "5m", Fill: true,
+		From: base.Add(2 * time.Minute), To: base.Add(17 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("GetCandles: %v", err)
+	}
+	if !repo.from.Equal(base) || !repo.to.Equal(base.Add(20*time.Minute)) {
+		t.Errorf("expected range widened to whole buckets, got %v – %v", repo.from, repo.to)
+	}
+
+	got := series.Candles
+	if len(got) != 4 {
+		t.Fatalf("expected 4 candles with 2 filled, got %d", len(got))
+	}
+	for i, c := range got {
+		if want := base.Add(time.Duration(i) * 5 * time.Minute).Unix(); c.Time != want {
+			t.Errorf("candle %d: time %d, want %d", i, c.Time, want)
+		}
+	}
+	if gap := got[1]; gap.Open != 101 || gap.Close != 101 || gap.Volume != 0 {
+		t.Errorf("expected a flat candle at the previous close, got %+v", gap)
+	}
+
+	_, err = svc.GetCandles(context.Background(), CandleQuery{Symbol: "BTC-USD", Interval: "7m"})
+	if !errors.Is(err, apperrors.ErrInvalidQuery) {
+		t.Errorf("expected ErrInvalidQuery for an unknown interval, got %v", err)
+	}
+}
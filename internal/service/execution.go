@@ -0,0 +1,92 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+// ExecutionWaiter lets a gateway wait for the engine's result for an
+// order it placed. It holds one subscription to the executions channel
+// and hands each execution to the request waiting on that order ID, if
+// any; every gateway sees every execution and ignores the rest.
+type ExecutionWaiter struct {
+	cache   ports.Cache
+	timeout time.Duration
+
+	mu      sync.Mutex
+	waiters map[uuid.UUID]chan models.Execution
+}
+
+func NewExecutionWaiter(cache ports.Cache, timeout time.Duration) *ExecutionWaiter {
+	return &ExecutionWaiter{
+		cache:   cache,
+		timeout: timeout,
+		waiters: make(map[uuid.UUID]chan models.Execution),
+	}
+}
+
+// Start subscribes to executions. Call it before serving requests; the
+// subscription lives until ctx is cancelled.
+func (w *ExecutionWaiter) Start(ctx context.Context) error {
+	execs, err := w.cache.SubscribeExecutions(ctx)
+	if err != nil {
+		return fmt.Errorf("ExecutionWaiter.Start: %w", err)
+	}
+	go func() {
+		for exec := range execs {
+			w.deliver(exec)
+		}
+		logger.Info("execution subscription closed")
+	}()
+	return nil
+}
+
+// expect registers interest in an order's execution. Register before
+// the order is published, or the result can arrive before anyone is
+// listening. The caller must call done when it stops waiting.
+func (w *ExecutionWaiter) expect(orderID uuid.UUID) (result <-chan models.Execution, done func()) {
+	ch := make(chan models.Execution, 1)
+
+	w.mu.Lock()
+	w.waiters[orderID] = ch
+	w.mu.Unlock()
+
+	return ch, func() {
+		w.mu.Lock()
+		delete(w.waiters, orderID)
+		w.mu.Unlock()
+	}
+}
+
+func (w *ExecutionWaiter) deliver(exec models.Execution) {
+	w.mu.Lock()
+	ch, ok := w.waiters[exec.Order.ID]
+	delete(w.waiters, exec.Order.ID)
+	w.mu.Unlock()
+
+	if ok {
+		ch <- exec // buffered, and each waiter gets at most one
+	}
+}
+
+// wait blocks until the execution arrives, the timeout passes or ctx is
+// done, and reports whether it arrived.
+func (w *ExecutionWaiter) wait(ctx context.Context, result <-chan models.Execution) (models.Execution, bool) {
+	timer := time.NewTimer(w.timeout)
+	defer timer.Stop()
+
+	select {
+	case exec := <-result:
+		return exec, true
+	case <-timer.C:
+	case <-ctx.Done():
+	}
+	return models.Execution{}, false
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/cache"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+func TestExecutionWaiter(t *testing.T) {
+	logger.InitForTest()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mem := cache.NewMemory()
+	defer mem.Close()
+
+	w := NewExecutionWaiter(mem, 50*time.Millisecond)
+	if err := w.Start(ctx); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+
+	mine, other := uuid.New(), uuid.New()
+	result, done := w.expect(mine)
+	defer done()
+
+	mem.PublishExecution(ctx, models.Execution{Order: models.Order{ID: other}})
+	mem.PublishExecution(ctx, models.Execution{Order: models.Order{ID: mine, Status: models.StatusFilled}})
+	exec, ok := w.wait(ctx, result)
+	if !ok || exec.Order.ID != mine || exec.Order.Status != models.StatusFilled {
+		t.Fatalf("expected my filled order, got %+v (%v)", exec.Order, ok)
+	}
+
+	// Nothing published for this one: the wait gives up after the timeout.
+	result, done = w.expect(uuid.New())
+	defer done()
+	start := time.Now()
+	if _, ok := w.wait(ctx, result); ok {
+		t.Fatal("expected a timeout")
+	}
+	if elapsed := time.Since(start); elapsed < 50*time.Millisecond {
+		t.Errorf("gave up after %s, before the timeout", elapsed)
+	}
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/ports"
+	apperrors "github.com/Im-Manav/ome/pkg/errors"
+	"github.com/Im-Manav/ome/pkg/models"
+)
+
+// InstrumentService manages the instrument list. Symbols the table
+// doesn't know are still accepted, so only a deliberate halt stops
+// trading.
+type InstrumentService struct {
+	repo ports.InstrumentRepository
+}
+
+func NewInstrumentService(repo ports.InstrumentRepository) *InstrumentService {
+	return &InstrumentService{repo: repo}
+}
+
+// Seed adds the configured symbols that aren't in the table yet.
+// Existing instruments keep whatever status an operator gave them.
+func (s *InstrumentService) Seed(symbols []string) error {
+	var insts []models.Instrument
+	for _, symbol := range symbols {
+		if symbol = strings.TrimSpace(symbol); symbol != "" {
+			insts = append(insts, models.NewInstrument(symbol))
+		}
+	}
+	if err := s.repo.SeedInstruments(insts); err != nil {
+		return fmt.Errorf("Seed: %w", err)
+	}
+	return nil
+}
+
+func (s *InstrumentService) List(ctx context.Context) ([]models.Instrument, error) {
+	insts, err := s.repo.ListInstruments()
+	if err != nil {
+		return nil, fmt.Errorf("List: %w", err)
+	}
+	return insts, nil
+}
+
+// Upsert creates an instrument or updates the fields set in req.
+func (s *InstrumentService) Upsert(
+	ctx context.Context,
+	symbol string,
+	req models.UpsertInstrumentRequest,
+) (*models.Instrument, error) {
+	if symbol == "" {
+		return nil, apperrors.ErrSymbolRequired
+	}
+	inst, err := s.repo.GetInstrument(symbol)
+	if err != nil {
+		return nil, fmt.Errorf("Upsert: %w", err)
+	}
+	if inst == nil {
+		created := models.NewInstrument(symbol)
+		created.CreatedAt = time.Now().UTC()
+		inst = &created
+	}
+	if req.Base != "" {
+		inst.Base = req.Base
+	}
+	if req.Quote != "" {
+		inst.Quote = req.Quote
+	}
+	if req.Status != "" {
+		inst.Status = req.Status
+	}
+	inst.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.UpsertInstrument(inst); err != nil {
+		return nil, fmt.Errorf("Upsert: %w", err)
+	}
+	return inst, nil
+}
+
+// CheckTradable returns ErrSymbolHalted for a halted instrument.
+func (s *InstrumentService) CheckTradable(ctx context.Context, symbol string) error {
+	inst, err := s.repo.GetInstrument(symbol)
+	if err != nil {
+		return fmt.Errorf("CheckTradable: %w", err)
+	}
+	if inst != nil && !inst.Tradable() {
+		return fmt.Errorf("%w: %s is %s", apperrors.ErrSymbolHalted, symbol, inst.Status)
+	}
+	return nil
+}
@@ -2,10 +2,13 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/Im-Manav/ome/internal/metrics"
 	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/internal/tracing"
 	apperrors "github.com/Im-Manav/ome/pkg/errors"
 	"github.com/Im-Manav/ome/pkg/logger"
 	"github.com/Im-Manav/ome/pkg/models"
@@ -14,11 +17,30 @@ import (
 )
 
 type OrderService struct {
-	orderRepo ports.OrderRepository
-	tradeRepo ports.TradeRepository
-	publisher ports.EventPublisher
-	cache     ports.Cache
-	broadcast ports.Broadcaster
+	orderRepo   ports.OrderRepository
+	tradeRepo   ports.TradeRepository
+	publisher   ports.EventPublisher
+	cache       ports.Cache
+	broadcast   ports.Broadcaster
+	fees        FeeSchedule
+	instruments *InstrumentService // nil accepts every symbol
+	executions  *ExecutionWaiter   // nil makes PlaceOrderAndWait return at once
+	outbox      *OutboxRelay       // nil publishes orders straight to Kafka
+}
+
+// FeeSchedule is charged per fill in basis points of notional.
+type FeeSchedule struct {
+	MakerBps float64
+	TakerBps float64
+}
+
+// Fee returns the fee on one fill in the quote currency.
+func (f FeeSchedule) Fee(trade models.Trade, liquidity string) float64 {
+	bps := f.TakerBps
+	if liquidity == models.LiquidityMaker {
+		bps = f.MakerBps
+	}
+	return trade.Price * trade.Quantity * bps / 10_000
 }
 
 func NewOrderService(
@@ -37,32 +59,151 @@ func NewOrderService(
 	}
 }
 
+// PlaceOrder accepts an order and returns once it is queued for the
+// engine. The outcome arrives over WebSocket.
 func (s *OrderService) PlaceOrder(
 	ctx context.Context,
 	req models.PlaceOrderRequest,
 	userID uuid.UUID,
 ) (*models.PlaceOrderResponse, error) {
-	if err := validateOrderRequest(req); err != nil {
+	return s.placeOrder(ctx, req, userID, false)
+}
+
+// PlaceOrderAndWait also waits for the engine to match the order and
+// returns its final state and fills. If the result doesn't arrive in
+// time the order is still live: the response is the accepted order,
+// marked Pending.
+func (s *OrderService) PlaceOrderAndWait(
+	ctx context.Context,
+	req models.PlaceOrderRequest,
+	userID uuid.UUID,
+) (*models.PlaceOrderResponse, error) {
+	return s.placeOrder(ctx, req, userID, true)
+}
+
+func (s *OrderService) placeOrder(
+	ctx context.Context,
+	req models.PlaceOrderRequest,
+	userID uuid.UUID,
+	wait bool,
+) (*models.PlaceOrderResponse, error) {
+	order, duplicate, err := s.acceptOrder(ctx, req, userID)
+	if err != nil {
 		return nil, err
 	}
+	if duplicate {
+		return &models.PlaceOrderResponse{Order: order, Duplicate: true}, nil
+	}
+
+	var result <-chan models.Execution
+	if wait && s.executions != nil {
+		var done func()
+		result, done = s.executions.expect(order.ID)
+		defer done()
+	}
+
+	if err := s.publishAccepted(ctx, []models.Order{order}); err != nil {
+		return nil, fmt.Errorf("publish order: %w", err)
+	}
+
+	if result != nil {
+		if exec, ok := s.executions.wait(ctx, result); ok {
+			return &models.PlaceOrderResponse{Order: exec.Order, Trades: exec.Trades}, nil
+		}
+	}
+
+	return &models.PlaceOrderResponse{
+		Order:   order,
+		Trades:  nil, // trades arrive async via WebSocket
+		Pending: wait,
+	}, nil
+}
+
+// PlaceOrderBatch validates and persists each order on its own, then
+// publishes every accepted one to the engine in a single write. Results
+// are in request order; an item that fails doesn't affect the others.
+// Items repeating a client order ID return the existing order and
+// aren't published again. The error is only for the publish, which
+// fails the whole batch.
+func (s *OrderService) PlaceOrderBatch(
+	ctx context.Context,
+	reqs []models.PlaceOrderRequest,
+	userID uuid.UUID,
+) ([]models.BatchResult, error) {
+	results := make([]models.BatchResult, len(reqs))
+	var accepted []models.Order
+	var indexes []int
+	for i, req := range reqs {
+		results[i].Index = i
+		order, duplicate, err := s.acceptOrder(ctx, req, userID)
+		if err != nil {
+			results[i].Error = apperrors.ToHTTP(err).Message
+			continue
+		}
+		if duplicate {
+			results[i].OrderID = order.ID.String()
+			results[i].Order = &order
+			continue
+		}
+		accepted = append(accepted, order)
+		indexes = append(indexes, i)
+	}
+	if len(accepted) == 0 {
+		return results, nil
+	}
+
+	if err := s.publishAccepted(ctx, accepted); err != nil {
+		return nil, fmt.Errorf("publish order batch: %w", err)
+	}
+
+	for j, i := range indexes {
+		results[i].OrderID = accepted[j].ID.String()
+		results[i].Order = &accepted[j]
+	}
+	return results, nil
+}
+
+// acceptOrder validates an order request and saves the order as open.
+// If the request repeats a client order ID it returns the existing order
+// instead, with duplicate set.
+func (s *OrderService) acceptOrder(
+	ctx context.Context,
+	req models.PlaceOrderRequest,
+	userID uuid.UUID,
+) (order models.Order, duplicate bool, err error) {
+	metrics.OrdersReceived.WithLabelValues(req.Side.String(), req.Type.String()).Inc()
+
+	// Checked before validation: a retry gets the original order even if
+	// the symbol has been halted since.
+	existing, err := s.clientOrder(userID, req)
+	if err != nil {
+		return models.Order{}, false, err
+	}
+	if existing != nil {
+		return *existing, true, nil
+	}
 
-	order := models.Order{
-		ID:           uuid.New(),
-		UserID:       userID,
-		Symbol:       req.Symbol,
-		Side:         req.Side,
-		Type:         req.Type,
-		Price:        req.Price,
-		Quantity:     req.Quantity,
-		FilledQty:    0,
-		RemainingQty: req.Quantity,
-		Status:       models.StatusOpen,
-		CreatedAt:    time.Now().UTC(),
-		UpdatedAt:    time.Now().UTC(),
+	if err := s.checkOrder(ctx, req); err != nil {
+		return models.Order{}, false, err
 	}
 
-	if err := s.orderRepo.SaveOrder(&order); err != nil {
-		return nil, fmt.Errorf("persist order: %w", err)
+	order = newOrder(req, userID)
+	err = tracing.WithSpan(ctx, "db.SaveOrder", func(context.Context) error {
+		if s.outbox != nil {
+			return s.outbox.saveOrder(&order)
+		}
+		return s.orderRepo.SaveOrder(&order)
+	})
+	if err != nil {
+		// A concurrent submit with the same client order ID got there
+		// first and the unique index refused this one.
+		if existing, lookupErr := s.clientOrder(userID, req); existing != nil {
+			return *existing, true, nil
+		} else if errors.Is(lookupErr, apperrors.ErrDuplicateClientID) {
+			return models.Order{}, false, lookupErr
+		}
+		metrics.OrdersRejected.WithLabelValues(metrics.StageGateway, "persist_failed").Inc()
+		return models.Order{}, false, fmt.Errorf("persist order: %w", err)
 	}
 
 	logger.Info("order saved",
@@ -70,19 +211,93 @@ func (s *OrderService) PlaceOrder(
 		zap.String("symbol", order.Symbol),
 		zap.String("side", order.Side.String()),
 	)
+	return order, false, nil
+}
 
-	if err := s.publisher.PublishOrder(ctx, order); err != nil {
-		// Kafka publish failed — mark the order as rejected in DB
-		// so the user isn't left with a phantom open order
-		order.Status = models.StatusRejected
-		_ = s.orderRepo.UpdateOrder(&order)
-		return nil, fmt.Errorf("publish order: %w", err)
+// checkOrder validates an order request and checks its symbol is
+// trading, counting a rejection if not.
+func (s *OrderService) checkOrder(ctx context.Context, req models.PlaceOrderRequest) error {
+	err := validateOrderRequest(req)
+	if err == nil && s.instruments != nil {
+		err = s.instruments.CheckTradable(ctx, req.Symbol)
 	}
+	if err != nil {
+		metrics.OrdersRejected.WithLabelValues(metrics.StageGateway, rejectReason(err)).Inc()
+	}
+	return err
+}
 
-	return &models.PlaceOrderResponse{
-		Order:  order,
-		Trades: nil, // trades arrive async via WebSocket
-	}, nil
+// newOrder builds an open order from a checked request.
+func newOrder(req models.PlaceOrderRequest, userID uuid.UUID) models.Order {
+	now := time.Now().UTC()
+	return models.Order{
+		ID:            uuid.New(),
+		UserID:        userID,
+		ClientOrderID: req.ClientOrderID,
+		Symbol:        req.Symbol,
+		Side:          req.Side,
+		Type:          req.Type,
+		Price:         req.Price,
+		Quantity:      req.Quantity,
+		FilledQty:     0,
+		RemainingQty:  req.Quantity,
+		Status:        models.StatusOpen,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// clientOrder returns the user's order with the request's client order
+// ID, or nil if the request has none or it is unused. Reusing an ID for
+// a different order is an error rather than a retry.
+func (s *OrderService) clientOrder(userID uuid.UUID, req models.PlaceOrderRequest) (*models.Order, error) {
+	if req.ClientOrderID == "" {
+		return nil, nil
+	}
+	order, err := s.orderRepo.GetOrderByClientOrderID(userID, req.ClientOrderID)
+	if err != nil {
+		return nil, fmt.Errorf("look up client order id: %w", err)
+	}
+	if order == nil {
+		return nil, nil
+	}
+	if order.Symbol != req.Symbol || order.Side != req.Side || order.Type != req.Type ||
+		order.Price != req.Price || order.Quantity != req.Quantity {
+		return nil, apperrors.ErrDuplicateClientID
+	}
+	return order, nil
+}
+
+// publishAccepted sends saved orders to the engine. With an outbox
+// their events were committed with them and the relay just needs a
+// nudge; it keeps retrying, so the orders stay open. Without one they
+// are published here, in one write, and rejected if that fails.
+func (s *OrderService) publishAccepted(ctx context.Context, orders []models.Order) error {
+	if s.outbox != nil {
+		s.outbox.Notify()
+		return nil
+	}
+	if err := s.publisher.PublishOrders(ctx, orders); err != nil {
+		for i := range orders {
+			s.rejectUnpublished(ctx, &orders[i])
+		}
+		return err
+	}
+	return nil
+}
+
+// rejectUnpublished marks a saved order rejected after the Kafka
+// publish failed, so the user isn't left with a phantom open order.
+func (s *OrderService) rejectUnpublished(ctx context.Context, order *models.Order) {
+	order.Status = models.StatusRejected
+	err := tracing.WithSpan(ctx, "db.UpdateOrder", func(context.Context) error {
+		return s.orderRepo.UpdateOrder(order)
+	})
+	if err != nil {
+		logger.Error("failed to reject unpublished order",
+			logger.Err(err), zap.String("order_id", order.ID.String()))
+	}
+	metrics.OrdersRejected.WithLabelValues(metrics.StageGateway, "publish_failed").Inc()
 }
 
 func (s *OrderService) CancelOrder(
@@ -97,27 +312,226 @@ func (s *OrderService) CancelOrder(
 	if order.UserID != userID {
 		return apperrors.ErrUnauthorized
 	}
-	if order.Status == models.StatusFilled {
-		return apperrors.ErrOrderAlreadyFilled
+	return s.cancel(ctx, order)
+}
+
+// CancelOrderByClientOrderID is CancelOrder by the user's own order ID.
+func (s *OrderService) CancelOrderByClientOrderID(
+	ctx context.Context,
+	clientOrderID string,
+	userID uuid.UUID,
+) (*models.Order, error) {
+	order, err := s.orderRepo.GetOrderByClientOrderID(userID, clientOrderID)
+	if err != nil || order == nil {
+		return nil, apperrors.ErrOrderNotFound
 	}
-	if order.Status == models.StatusCancelled {
-		return apperrors.ErrOrderCancelled
+	if err := s.cancel(ctx, order); err != nil {
+		return nil, err
 	}
+	return order, nil
+}
 
-	// Update DB first
-	if err := s.orderRepo.CancelOrder(orderID); err != nil {
-		return fmt.Errorf("cancel order in db: %w", err)
+// AmendOrder changes the price or quantity of one of the user's resting
+// limit orders. The engine can't modify an order in place, so it is
+// cancelled and replaced by a new order with a new ID, which loses the
+// original's time priority. The replacement is fully checked first, and
+// the cancel and insert commit together: if the amend fails the
+// original is left as it was. The engine is sent the cancel before the
+// replacement, so the two never rest in the book together.
+func (s *OrderService) AmendOrder(
+	ctx context.Context,
+	orderID uuid.UUID,
+	req models.AmendOrderRequest,
+	userID uuid.UUID,
+) (*models.PlaceOrderResponse, error) {
+	order, err := s.orderRepo.GetOrderByID(orderID)
+	if err != nil {
+		return nil, apperrors.ErrOrderNotFound
+	}
+	if order.UserID != userID {
+		return nil, apperrors.ErrUnauthorized
+	}
+	if err := amendable(order); err != nil {
+		return nil, err
 	}
 
-	// Publish cancellation — the Kafka consumer will call
-	// book.Cancel(orderID) to remove it from the heap
+	replacement := models.PlaceOrderRequest{
+		Symbol:        order.Symbol,
+		Side:          order.Side,
+		Type:          order.Type,
+		Price:         order.Price,
+		Quantity:      order.RemainingQty,
+		ClientOrderID: req.ClientOrderID,
+	}
+	if req.Price != 0 {
+		replacement.Price = req.Price
+	}
+	if req.Quantity != 0 {
+		replacement.Quantity = req.Quantity
+	}
+	metrics.OrdersReceived.WithLabelValues(replacement.Side.String(), replacement.Type.String()).Inc()
+	if err := s.checkOrder(ctx, replacement); err != nil {
+		return nil, err
+	}
+	// A client order ID names one order, so the replacement needs an
+	// unused one; an amend is never a retry of an earlier order.
+	if replacement.ClientOrderID != "" {
+		existing, err := s.orderRepo.GetOrderByClientOrderID(userID, replacement.ClientOrderID)
+		if err != nil {
+			return nil, fmt.Errorf("look up client order id: %w", err)
+		}
+		if existing != nil {
+			return nil, apperrors.ErrDuplicateClientID
+		}
+	}
+
+	placed := newOrder(replacement, userID)
+	err = tracing.WithSpan(ctx, "db.ReplaceOrder", func(context.Context) error {
+		if s.outbox != nil {
+			return s.outbox.replaceOrder(order, &placed)
+		}
+		return s.orderRepo.ReplaceOrder(order.ID, &placed)
+	})
+	if err != nil {
+		return nil, s.replaceFailed(userID, order.ID, replacement.ClientOrderID, err)
+	}
 	order.Status = models.StatusCancelled
+
+	// Without an outbox the original must leave the book before the
+	// replacement is published; if it can't, the replacement isn't sent.
+	if s.outbox == nil {
+		if err := s.publisher.PublishCancels(ctx, []models.Order{*order}); err != nil {
+			s.rejectUnpublished(ctx, &placed)
+			return nil, fmt.Errorf("publish cancel: %w", err)
+		}
+	}
 	if err := s.publisher.PublishOrderEvent(ctx, *order); err != nil {
-		// Non-fatal: DB is source of truth, engine will skip
-		// the cancelled order when it surfaces at the top of the heap
+		// Non-fatal, as for a plain cancel
 		logger.Error("failed to publish cancel event", logger.Err(err))
 	}
+	if err := s.publishAccepted(ctx, []models.Order{placed}); err != nil {
+		return nil, fmt.Errorf("publish order: %w", err)
+	}
+	return &models.PlaceOrderResponse{Order: placed}, nil
+}
+
+// amendable reports why an order can't be amended, if it can't.
+func amendable(order *models.Order) error {
+	switch {
+	case order.Status == models.StatusFilled:
+		return apperrors.ErrOrderAlreadyFilled
+	case order.Status == models.StatusCancelled:
+		return apperrors.ErrOrderCancelled
+	case order.Type != models.Limit:
+		return apperrors.ErrNotAmendable
+	}
+	return nil
+}
+
+// replaceFailed explains a failed replace: the original was filled or
+// cancelled meanwhile, or a concurrent request took the client order ID.
+func (s *OrderService) replaceFailed(userID, orderID uuid.UUID, clientOrderID string, err error) error {
+	if order, lookupErr := s.orderRepo.GetOrderByID(orderID); lookupErr == nil {
+		if reason := amendable(order); reason != nil {
+			return reason
+		}
+	}
+	if clientOrderID != "" {
+		if existing, _ := s.orderRepo.GetOrderByClientOrderID(userID, clientOrderID); existing != nil {
+			return apperrors.ErrDuplicateClientID
+		}
+	}
+	return fmt.Errorf("replace order: %w", err)
+}
+
+// ForceCancelOrder cancels any user's order, for the admin API.
+func (s *OrderService) ForceCancelOrder(ctx context.Context, orderID uuid.UUID) error {
+	order, err := s.orderRepo.GetOrderByID(orderID)
+	if err != nil {
+		return apperrors.ErrOrderNotFound
+	}
+	return s.cancel(ctx, order)
+}
+
+// CancelOrderBatch cancels each of the user's orders on its own, then
+// publishes the cancellations in a single write. Results are in request
+// order; orders that are missing, someone else's or already closed fail
+// alone.
+func (s *OrderService) CancelOrderBatch(
+	ctx context.Context,
+	orderIDs []uuid.UUID,
+	userID uuid.UUID,
+) []models.BatchResult {
+	results := make([]models.BatchResult, len(orderIDs))
+	var cancelled []models.Order
+	for i, id := range orderIDs {
+		results[i] = models.BatchResult{Index: i, OrderID: id.String()}
+
+		order, err := s.orderRepo.GetOrderByID(id)
+		if err != nil || order.UserID != userID {
+			results[i].Error = apperrors.ToHTTP(apperrors.ErrOrderNotFound).Message
+			continue
+		}
+		if err := s.cancelInDB(ctx, order); err != nil {
+			results[i].Error = apperrors.ToHTTP(err).Message
+			continue
+		}
+		results[i].Order = order
+		cancelled = append(cancelled, *order)
+	}
+
+	s.publishCancelled(ctx, cancelled)
+	return results
+}
+
+func (s *OrderService) cancel(ctx context.Context, order *models.Order) error {
+	if err := s.cancelInDB(ctx, order); err != nil {
+		return err
+	}
+	s.publishCancelled(ctx, []models.Order{*order})
+	return nil
+}
+
+// publishCancelled tells the engine to take cancelled orders out of
+// its books, then publishes their status change. With an outbox the
+// cancel requests were committed with the cancels. Without one a
+// failed publish is only logged: the orders are cancelled in the
+// database, the source of truth, whatever the engine does.
+func (s *OrderService) publishCancelled(ctx context.Context, orders []models.Order) {
+	if len(orders) == 0 {
+		return
+	}
+	if s.outbox != nil {
+		s.outbox.Notify()
+	} else if err := s.publisher.PublishCancels(ctx, orders); err != nil {
+		logger.Error("failed to publish cancel requests", logger.Err(err), zap.Int("orders", len(orders)))
+	}
+	if err := s.publisher.PublishOrderEvents(ctx, orders); err != nil {
+		logger.Error("failed to publish cancel events", logger.Err(err), zap.Int("orders", len(orders)))
+	}
+}
+
+// cancelInDB cancels an open order in the database — the source of
+// truth — and sets its status to match. With an outbox the request
+// that takes it out of the engine's book commits with it.
+func (s *OrderService) cancelInDB(ctx context.Context, order *models.Order) error {
+	if order.Status == models.StatusFilled {
+		return apperrors.ErrOrderAlreadyFilled
+	}
+	if order.Status == models.StatusCancelled {
+		return apperrors.ErrOrderCancelled
+	}
 
+	err := tracing.WithSpan(ctx, "db.CancelOrder", func(context.Context) error {
+		if s.outbox != nil {
+			return s.outbox.cancelOrder(order)
+		}
+		return s.orderRepo.CancelOrder(order.ID)
+	})
+	if err != nil {
+		return fmt.Errorf("cancel order in db: %w", err)
+	}
+	order.Status = models.StatusCancelled
 	return nil
 }
 
@@ -145,24 +559,259 @@ func (s *OrderService) GetOrderBook(
 	return empty, nil
 }
 
+// GetTicker returns a symbol's 24h ticker with the current best bid and
+// ask. A symbol that hasn't traded in the window gets an empty ticker,
+// the same way GetOrderBook answers for an empty book.
+func (s *OrderService) GetTicker(ctx context.Context, symbol string) (*models.Ticker, error) {
+	ticker, err := s.cache.GetTicker(ctx, symbol)
+	if err != nil {
+		return nil, fmt.Errorf("GetTicker: %w", err)
+	}
+	if ticker == nil {
+		ticker = &models.Ticker{Symbol: symbol, UpdatedAt: time.Now().UTC()}
+	}
+	s.addTopOfBook(ctx, ticker)
+	return ticker, nil
+}
+
+// GetTickers returns the ticker of every symbol that has traded, sorted
+// by symbol.
+func (s *OrderService) GetTickers(ctx context.Context) ([]models.Ticker, error) {
+	tickers, err := s.cache.GetTickers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("GetTickers: %w", err)
+	}
+	for i := range tickers {
+		s.addTopOfBook(ctx, &tickers[i])
+	}
+	return tickers, nil
+}
+
+// addTopOfBook fills in best bid and ask from the engine's book snapshot.
+// The market data service only sees trades, not the book.
+func (s *OrderService) addTopOfBook(ctx context.Context, ticker *models.Ticker) {
+	snap, err := s.GetOrderBook(ctx, ticker.Symbol)
+	if err != nil {
+		return
+	}
+	if len(snap.Bids) > 0 {
+		ticker.BestBid = snap.Bids[0].Price
+	}
+	if len(snap.Asks) > 0 {
+		ticker.BestAsk = snap.Asks[0].Price
+	}
+}
+
+// SetFees sets the schedule used to report fees on fills. No fees
+// are reported until it is called.
+func (s *OrderService) SetFees(fees FeeSchedule) {
+	s.fees = fees
+}
+
+// SetInstruments makes PlaceOrder reject orders for halted instruments.
+func (s *OrderService) SetInstruments(instruments *InstrumentService) {
+	s.instruments = instruments
+}
+
+// SetExecutions lets PlaceOrderAndWait wait for the engine's result.
+func (s *OrderService) SetExecutions(executions *ExecutionWaiter) {
+	s.executions = executions
+}
+
+// SetOutbox makes order placement save each order with an outbox event
+// in one transaction, for the relay to publish.
+func (s *OrderService) SetOutbox(outbox *OutboxRelay) {
+	s.outbox = outbox
+}
+
+// GetOrderDetail returns an order with its fills, average fill price,
+// fees and lifecycle. Orders owned by someone else are reported as
+// not found, so IDs can't be probed for existence.
+func (s *OrderService) GetOrderDetail(
+	ctx context.Context,
+	orderID uuid.UUID,
+	userID uuid.UUID,
+) (*models.OrderDetail, error) {
+	order, err := s.orderRepo.GetOrderByID(orderID)
+	if err != nil || order.UserID != userID {
+		return nil, apperrors.ErrOrderNotFound
+	}
+	return s.orderDetail(ctx, order)
+}
+
+// GetOrderDetailByClientOrderID is GetOrderDetail by the user's own
+// order ID.
+func (s *OrderService) GetOrderDetailByClientOrderID(
+	ctx context.Context,
+	clientOrderID string,
+	userID uuid.UUID,
+) (*models.OrderDetail, error) {
+	order, err := s.orderRepo.GetOrderByClientOrderID(userID, clientOrderID)
+	if err != nil || order == nil {
+		return nil, apperrors.ErrOrderNotFound
+	}
+	return s.orderDetail(ctx, order)
+}
+
+func (s *OrderService) orderDetail(ctx context.Context, order *models.Order) (*models.OrderDetail, error) {
+	var trades []models.Trade
+	err := tracing.WithSpan(ctx, "db.GetTradesByOrderID", func(context.Context) error {
+		var err error
+		trades, err = s.tradeRepo.GetTradesByOrderID(order.ID)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get order fills: %w", err)
+	}
+
+	detail := &models.OrderDetail{
+		Order: *order,
+		Fills: make([]models.Fill, 0, len(trades)),
+		Timeline: []models.OrderEvent{
+			{Time: order.CreatedAt, Event: "created", Status: models.StatusOpen},
+		},
+	}
+
+	var filled, notional, fees float64
+	feesKnown := true
+	for _, t := range trades {
+		fill := s.fill(t, order.Side)
+		detail.Fills = append(detail.Fills, fill)
+		if fill.Fee != nil {
+			fees += *fill.Fee
+		} else {
+			feesKnown = false
+		}
+
+		filled += t.Quantity
+		notional += t.Price * t.Quantity
+
+		status := models.StatusPartial
+		if order.Quantity-filled <= 0 {
+			status = models.StatusFilled
+		}
+		tradeID := t.ID
+		detail.Timeline = append(detail.Timeline, models.OrderEvent{
+			Time: t.ExecutedAt, Event: "fill", Status: status, FilledQty: filled, TradeID: &tradeID,
+		})
+	}
+	if filled > 0 {
+		detail.AvgFillPrice = notional / filled
+	}
+	if feesKnown {
+		detail.Fees = &fees
+	}
+
+	// Terminal states other than filled carry no trade — the order's
+	// last update is when it happened.
+	switch order.Status {
+	case models.StatusCancelled:
+		detail.Timeline = append(detail.Timeline, models.OrderEvent{
+			Time: order.UpdatedAt, Event: "cancelled", Status: order.Status, FilledQty: filled,
+		})
+	case models.StatusRejected:
+		detail.Timeline = append(detail.Timeline, models.OrderEvent{
+			Time: order.UpdatedAt, Event: "rejected", Status: order.Status, FilledQty: filled,
+		})
+	}
+	return detail, nil
+}
+
+// GetUserTrades returns one page of the trades a user was on either
+// side of, each with the user's role, liquidity and fee. A self-trade
+// is listed once, from the buyer's side.
+func (s *OrderService) GetUserTrades(
+	ctx context.Context,
+	userID uuid.UUID,
+	filter models.TradeFilter,
+) (*models.TradePage, error) {
+	limit := models.ClampPageLimit(filter.Limit)
+	filter.Limit = limit + 1
+
+	var trades []models.Trade
+	err := tracing.WithSpan(ctx, "db.ListTradesByUserID", func(context.Context) error {
+		var err error
+		trades, err = s.tradeRepo.ListTradesByUserID(userID, filter)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("get user trades: %w", err)
+	}
+
+	page := &models.TradePage{}
+	if len(trades) > limit {
+		trades = trades[:limit]
+		last := trades[limit-1]
+		page.NextCursor = models.Cursor{Time: last.ExecutedAt, ID: last.ID}.Encode()
+	}
+
+	page.Trades = make([]models.UserTrade, 0, len(trades))
+	for _, t := range trades {
+		ut := models.UserTrade{Role: models.RoleBuyer, OrderID: t.BuyOrderID}
+		side := models.Buy
+		if t.BuyUserID != userID {
+			ut.Role, ut.OrderID, side = models.RoleSeller, t.SellOrderID, models.Sell
+		}
+		ut.Fill = s.fill(t, side)
+		page.Trades = append(page.Trades, ut)
+	}
+	return page, nil
+}
+
+// fill describes a trade from the side of the order on side. Without
+// a taker side neither the liquidity nor the fee is known.
+func (s *OrderService) fill(t models.Trade, side models.Side) models.Fill {
+	if t.TakerSide == nil {
+		return models.Fill{Trade: t, Liquidity: models.LiquidityUnknown}
+	}
+	liquidity := models.LiquidityMaker
+	if *t.TakerSide == side {
+		liquidity = models.LiquidityTaker
+	}
+	fee := s.fees.Fee(t, liquidity)
+	return models.Fill{Trade: t, Liquidity: liquidity, Fee: &fee}
+}
+
+// GetUserOrders returns one page of a user's order history. One extra
+// row is fetched to tell whether another page follows.
 func (s *OrderService) GetUserOrders(
 	ctx context.Context,
 	userID uuid.UUID,
-) ([]*models.Order, error) {
-	orders, err := s.orderRepo.GetOrdersByUserID(userID)
+	filter models.OrderFilter,
+) (*models.OrderPage, error) {
+	limit := models.ClampPageLimit(filter.Limit)
+	filter.Limit = limit + 1
+
+	orders, err := s.orderRepo.ListOrdersByUserID(userID, filter)
 	if err != nil {
 		return nil, fmt.Errorf("get user orders: %w", err)
 	}
-	return orders, nil
+
+	page := &models.OrderPage{Orders: orders}
+	if len(orders) > limit {
+		page.Orders = orders[:limit]
+		last := page.Orders[limit-1]
+		page.NextCursor = models.Cursor{Time: last.CreatedAt, ID: last.ID}.Encode()
+	}
+	return page, nil
 }
 
+// Recent trades returned per symbol when no limit is given, and at most.
+const (
+	defaultRecentTrades = 50
+	maxRecentTrades     = 100
+)
+
 func (s *OrderService) GetRecentTrades(
 	ctx context.Context,
 	symbol string,
 	limit int,
 ) ([]models.Trade, error) {
-	if limit <= 100 || limit > 100 {
-		limit = 50
+	switch {
+	case limit <= 0:
+		limit = defaultRecentTrades
+	case limit > maxRecentTrades:
+		limit = maxRecentTrades
 	}
 	trades, err := s.tradeRepo.GetTradesBySymbol(symbol, limit)
 	if err != nil {
@@ -171,20 +820,79 @@ func (s *OrderService) GetRecentTrades(
 	return trades, nil
 }
 
+// SubscribeExecutions streams the engine's results as they affect one
+// user, until ctx is cancelled.
+func (s *OrderService) SubscribeExecutions(
+	ctx context.Context,
+	userID uuid.UUID,
+) (<-chan models.UserExecution, error) {
+	execs, err := s.cache.SubscribeExecutions(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("subscribe executions: %w", err)
+	}
+
+	out := make(chan models.UserExecution, 64)
+	go func() {
+		defer close(out)
+		for exec := range execs {
+			ue, ok := s.userExecution(exec, userID)
+			if !ok {
+				continue
+			}
+			select {
+			case out <- ue:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// userExecution picks out the parts of exec that concern userID and
+// reports whether there were any. A self-trade gives a fill per side.
+func (s *OrderService) userExecution(exec models.Execution, userID uuid.UUID) (models.UserExecution, bool) {
+	var ue models.UserExecution
+	if exec.Order.UserID == userID {
+		order := exec.Order
+		ue.Order = &order
+	}
+	for _, t := range exec.Trades {
+		if t.BuyUserID == userID {
+			ue.Fills = append(ue.Fills, models.UserTrade{
+				Fill: s.fill(t, models.Buy), Role: models.RoleBuyer, OrderID: t.BuyOrderID,
+			})
+		}
+		if t.SellUserID == userID {
+			ue.Fills = append(ue.Fills, models.UserTrade{
+				Fill: s.fill(t, models.Sell), Role: models.RoleSeller, OrderID: t.SellOrderID,
+			})
+		}
+	}
+	return ue, ue.Order != nil || len(ue.Fills) > 0
+}
+
 func (s *OrderService) PostMatchHandler(
 	ctx context.Context,
 	order models.Order,
 	trades []models.Trade,
 ) error {
 	if len(trades) > 0 {
-		if err := s.tradeRepo.SaveTrades(trades); err != nil {
+		err := tracing.WithSpan(ctx, "db.SaveTrades", func(context.Context) error {
+			return s.tradeRepo.SaveTrades(trades)
+		})
+		if err != nil {
 			logger.Error("failed to save trades", logger.Err(err))
 		}
 	}
 
-	if err := s.orderRepo.UpdateOrder(&order); err != nil {
+	err := tracing.WithSpan(ctx, "db.UpdateOrder", func(context.Context) error {
+		return s.orderRepo.UpdateOrder(&order)
+	})
+	if err != nil {
 		logger.Error("failed to update order status", logger.Err(err))
 	}
+	s.updateRestingOrders(ctx, order, trades)
 
 	for _, trade := range trades {
 		event := models.TradeEvent{
@@ -193,10 +901,70 @@ func (s *OrderService) PostMatchHandler(
 			SellerFilled: order.Side == models.Sell && order.Status == models.StatusFilled,
 		}
 		s.broadcast.BroadcastTrade(event)
+		if err := s.cache.PublishTrade(ctx, event); err != nil {
+			logger.Error("failed to publish trade", logger.Err(err), zap.String("trade_id", trade.ID.String()))
+		}
+	}
+
+	// Last, so a gateway waiting on this order reads what was just saved
+	exec := models.Execution{Order: order, Trades: trades}
+	if err := s.cache.PublishExecution(ctx, exec); err != nil {
+		logger.Error("failed to publish execution", logger.Err(err), zap.String("order_id", order.ID.String()))
 	}
 	return nil
 }
 
+// updateRestingOrders applies each fill to the resting order on the
+// other side of it. The matcher only hands back the incoming order,
+// so without this a maker's row would stay open after it was filled.
+func (s *OrderService) updateRestingOrders(ctx context.Context, order models.Order, trades []models.Trade) {
+	if len(trades) == 0 {
+		return
+	}
+	var fills []models.OrderFill
+	index := make(map[uuid.UUID]int)
+	for _, t := range trades {
+		id := t.SellOrderID
+		if order.Side == models.Sell {
+			id = t.BuyOrderID
+		}
+		i, seen := index[id]
+		if !seen {
+			i = len(fills)
+			index[id] = i
+			fills = append(fills, models.OrderFill{OrderID: id})
+		}
+		fills[i].Quantity += t.Quantity
+	}
+
+	err := tracing.WithSpan(ctx, "db.FillOrders", func(context.Context) error {
+		return s.orderRepo.FillOrders(fills)
+	})
+	if err != nil {
+		logger.Error("failed to update resting orders", logger.Err(err), zap.Int("orders", len(fills)))
+	}
+}
+
+// rejectReason is the metrics label for a validation error.
+func rejectReason(err error) string {
+	switch err {
+	case apperrors.ErrSymbolRequired:
+		return "symbol_required"
+	case apperrors.ErrInvalidQuantity:
+		return "invalid_quantity"
+	case apperrors.ErrInvalidPrice:
+		return "invalid_price"
+	case apperrors.ErrInvalidSide:
+		return "invalid_side"
+	case apperrors.ErrInvalidOrderType:
+		return "invalid_type"
+	case apperrors.ErrSymbolHalted:
+		return "symbol_halted"
+	default:
+		return "invalid"
+	}
+}
+
 func validateOrderRequest(req models.PlaceOrderRequest) error {
 	if req.Symbol == "" {
 		return apperrors.ErrSymbolRequired
@@ -210,5 +978,8 @@ func validateOrderRequest(req models.PlaceOrderRequest) error {
 	if req.Side != models.Buy && req.Side != models.Sell {
 		return apperrors.ErrInvalidSide
 	}
+	if req.Type != models.Limit && req.Type != models.Market {
+		return apperrors.ErrInvalidOrderType
+	}
 	return nil
 }
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/pkg/models"
+)
+
+// limitRecorder remembers the limit each trade lookup asked for.
+type limitRecorder struct {
+	ports.TradeRepository
+	limit int
+}
+
+func (r *limitRecorder) GetTradesBySymbol(_ string, limit int) ([]models.Trade, error) {
+	r.limit = limit
+	return nil, nil
+}
+
+func TestGetRecentTradesClampsLimit(t *testing.T) {
+	for _, tc := range []struct{ limit, want int }{
+		{0, defaultRecentTrades},
+		{-1, defaultRecentTrades},
+		{20, 20},
+		{maxRecentTrades, maxRecentTrades},
+		{maxRecentTrades + 1, maxRecentTrades},
+	} {
+		trades := &limitRecorder{}
+		svc := NewOrderService(nil, trades, nil, nil, nil)
+		if _, err := svc.GetRecentTrades(context.Background(), "BTC-USD", tc.limit); err != nil {
+			t.Fatalf("GetRecentTrades(%d): %v", tc.limit, err)
+		}
+		if trades.limit != tc.want {
+			t.Errorf("GetRecentTrades(%d) asked for %d trades, want %d", tc.limit, trades.limit, tc.want)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"go.uber.org/zap"
+)
+
+const (
+	outboxBatchSize = 100
+	// Sent events are kept for a day for debugging, then pruned.
+	outboxRetention     = 24 * time.Hour
+	outboxPruneInterval = time.Hour
+)
+
+// OutboxRelay publishes the events the order service saves alongside
+// its orders. An order and its event commit together, so an accepted
+// order always reaches the engine eventually, even if the gateway
+// crashes before publishing it. Delivery is at least once: a crash
+// between publishing and marking events sent publishes them again, as
+// does a relay on another gateway picking up the same batch, and the
+// engine drops the repeats.
+type OutboxRelay struct {
+	repo      ports.OutboxRepository
+	publisher ports.EventPublisher
+	interval  time.Duration
+	wake      chan struct{}
+}
+
+func NewOutboxRelay(repo ports.OutboxRepository, publisher ports.EventPublisher, interval time.Duration) *OutboxRelay {
+	return &OutboxRelay{
+		repo:      repo,
+		publisher: publisher,
+		interval:  interval,
+		wake:      make(chan struct{}, 1),
+	}
+}
+
+// Start relays until ctx is cancelled: whenever Notify is called, and
+// every interval for events saved by other gateways or left behind by
+// a crash. It runs a pass straight away to publish anything pending.
+func (r *OutboxRelay) Start(ctx context.Context) {
+	poll := time.NewTicker(r.interval)
+	defer poll.Stop()
+	prune := time.NewTicker(outboxPruneInterval)
+	defer prune.Stop()
+
+	for {
+		r.drain(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-r.wake:
+		case <-poll.C:
+		case <-prune.C:
+			if n, err := r.repo.DeleteSentEvents(time.Now().Add(-outboxRetention)); err != nil {
+				logger.Error("outbox prune failed", logger.Err(err))
+			} else if n > 0 {
+				logger.Info("outbox pruned", zap.Int64("events", n))
+			}
+		}
+	}
+}
+
+// Notify asks the relay to run a pass now, without waiting for the
+// next poll. It never blocks.
+func (r *OutboxRelay) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default: // a pass is already due
+	}
+}
+
+// drain relays until the outbox is empty or a pass fails. A failed
+// pass is retried on the next wake-up or poll.
+func (r *OutboxRelay) drain(ctx context.Context) {
+	for ctx.Err() == nil {
+		n, err := r.RelayOnce(ctx)
+		if err != nil {
+			logger.Error("outbox relay failed", logger.Err(err))
+			return
+		}
+		if n < outboxBatchSize {
+			return
+		}
+	}
+}
+
+// RelayOnce publishes one batch of unsent events in order and returns
+// how many were sent. No transaction is held while publishing; if it
+// fails nothing is marked sent.
+func (r *OutboxRelay) RelayOnce(ctx context.Context) (int, error) {
+	events, err := r.repo.UnsentEvents(outboxBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("OutboxRelay.RelayOnce: %w", err)
+	}
+	if len(events) == 0 {
+		return 0, nil
+	}
+	if err := r.publish(ctx, events); err != nil {
+		return 0, fmt.Errorf("OutboxRelay.RelayOnce: %w", err)
+	}
+	ids := make([]int64, len(events))
+	for i, e := range events {
+		ids[i] = e.ID
+	}
+	if err := r.repo.MarkEventsSent(ids); err != nil {
+		return 0, fmt.Errorf("OutboxRelay.RelayOnce: %w", err)
+	}
+	return len(events), nil
+}
+
+// publish writes each run of same-type events in one call, keeping
+// their order: a cancel published ahead of a placement stays ahead of
+// it. Events that don't decode or have an unknown type are logged and
+// skipped rather than blocking the outbox behind them.
+func (r *OutboxRelay) publish(ctx context.Context, events []models.OutboxEvent) error {
+	var (
+		run     []models.Order
+		runType string
+	)
+	flush := func() error {
+		if len(run) == 0 {
+			return nil
+		}
+		var err error
+		if runType == models.OutboxOrderCancelRequested {
+			err = r.publisher.PublishCancels(ctx, run)
+		} else {
+			err = r.publisher.PublishOrders(ctx, run)
+		}
+		run = nil
+		return err
+	}
+
+	for _, e := range events {
+		if e.Type != models.OutboxOrderPlaced && e.Type != models.OutboxOrderCancelRequested {
+			logger.Error("skipping outbox event of unknown type",
+				zap.Int64("event_id", e.ID), zap.String("type", e.Type))
+			continue
+		}
+		var order models.Order
+		if err := json.Unmarshal(e.Payload, &order); err != nil {
+			logger.Error("skipping undecodable outbox event",
+				zap.Int64("event_id", e.ID), logger.Err(err))
+			continue
+		}
+		if e.Type != runType {
+			if err := flush(); err != nil {
+				return err
+			}
+			runType = e.Type
+		}
+		run = append(run, order)
+	}
+	return flush()
+}
+
+// saveOrder saves an order together with the event that publishes it.
+func (r *OutboxRelay) saveOrder(order *models.Order) error {
+	event, err := orderEvent(models.OutboxOrderPlaced, order)
+	if err != nil {
+		return err
+	}
+	return r.repo.SaveOrderWithEvent(order, event)
+}
+
+// cancelOrder cancels a live order and saves the event that takes it
+// out of the engine's book, in one transaction.
+func (r *OutboxRelay) cancelOrder(order *models.Order) error {
+	event, err := orderEvent(models.OutboxOrderCancelRequested, order)
+	if err != nil {
+		return err
+	}
+	return r.repo.CancelOrderWithEvent(order.ID, event)
+}
+
+// replaceOrder cancels a live order and saves its replacement, all in
+// one transaction, with events that take the original out of the book
+// and then publish the replacement.
+func (r *OutboxRelay) replaceOrder(old *models.Order, replacement *models.Order) error {
+	cancel, err := orderEvent(models.OutboxOrderCancelRequested, old)
+	if err != nil {
+		return err
+	}
+	placed, err := orderEvent(models.OutboxOrderPlaced, replacement)
+	if err != nil {
+		return err
+	}
+	return r.repo.ReplaceOrderWithEvents(old.ID, replacement, []*models.OutboxEvent{cancel, placed})
+}
+
+func orderEvent(eventType string, order *models.Order) (*models.OutboxEvent, error) {
+	payload, err := json.Marshal(order)
+	if err != nil {
+		return nil, fmt.Errorf("marshal outbox event: %w", err)
+	}
+	return &models.OutboxEvent{
+		Type:      eventType,
+		Key:       order.Symbol,
+		Payload:   payload,
+		CreatedAt: time.Now(),
+	}, nil
+}
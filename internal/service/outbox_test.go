@@ -0,0 +1,359 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/cache"
+	"github.com/Im-Manav/ome/internal/db"
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/internal/eventbus"
+	"github.com/Im-Manav/ome/internal/kafka"
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+	"github.com/google/uuid"
+)
+
+// outboxRepo keeps orders and their events in memory. The fail flags
+// stand in for a crash at one step of placing or relaying an order.
+type outboxRepo struct {
+	ports.OrderRepository // only the outbox methods are used
+
+	orders   map[uuid.UUID]models.Order
+	events   []models.OutboxEvent
+	failSave bool // the transaction never commits
+	failMark bool // events are published but never marked sent
+}
+
+func (r *outboxRepo) SaveOrderWithEvent(order *models.Order, event *models.OutboxEvent) error {
+	if r.failSave {
+		return errors.New("connection lost")
+	}
+	r.orders[order.ID] = *order
+	r.addEvent(event)
+	return nil
+}
+
+func (r *outboxRepo) CancelOrderWithEvent(id uuid.UUID, event *models.OutboxEvent) error {
+	if r.failSave {
+		return errors.New("connection lost")
+	}
+	r.cancel(id)
+	r.addEvent(event)
+	return nil
+}
+
+func (r *outboxRepo) ReplaceOrderWithEvents(oldID uuid.UUID, order *models.Order, events []*models.OutboxEvent) error {
+	if r.failSave {
+		return errors.New("connection lost")
+	}
+	r.cancel(oldID)
+	r.orders[order.ID] = *order
+	for _, event := range events {
+		r.addEvent(event)
+	}
+	return nil
+}
+
+func (r *outboxRepo) cancel(id uuid.UUID) {
+	order := r.orders[id]
+	order.Status = models.StatusCancelled
+	r.orders[id] = order
+}
+
+func (r *outboxRepo) addEvent(event *models.OutboxEvent) {
+	event.ID = int64(len(r.events) + 1)
+	r.events = append(r.events, *event)
+}
+
+func (r *outboxRepo) UnsentEvents(limit int) ([]models.OutboxEvent, error) {
+	var unsent []models.OutboxEvent
+	for _, e := range r.events {
+		if e.SentAt == nil && len(unsent) < limit {
+			unsent = append(unsent, e)
+		}
+	}
+	return unsent, nil
+}
+
+func (r *outboxRepo) MarkEventsSent(ids []int64) error {
+	if r.failMark {
+		return errors.New("connection lost")
+	}
+	now := time.Now()
+	for _, id := range ids {
+		r.events[id-1].SentAt = &now
+	}
+	return nil
+}
+
+func (r *outboxRepo) DeleteSentEvents(time.Time) (int64, error) { return 0, nil }
+
+func (r *outboxRepo) unsent() int {
+	n := 0
+	for _, e := range r.events {
+		if e.SentAt == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// orderPublisher records published orders, or fails like a broker that is down.
+type orderPublisher struct {
+	ports.EventPublisher // only PublishOrders and PublishCancels are used
+
+	published []models.Order
+	cancelled []models.Order
+	calls     []string // which method published each write
+	fail      bool
+}
+
+func (p *orderPublisher) PublishCancels(_ context.Context, orders []models.Order) error {
+	if p.fail {
+		return errors.New("broker unavailable")
+	}
+	p.cancelled = append(p.cancelled, orders...)
+	p.calls = append(p.calls, "cancels")
+	return nil
+}
+
+func (p *orderPublisher) PublishOrders(_ context.Context, orders []models.Order) error {
+	if p.fail {
+		return errors.New("broker unavailable")
+	}
+	p.published = append(p.published, orders...)
+	p.calls = append(p.calls, "orders")
+	return nil
+}
+
+func TestOutboxSurvivesCrashes(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	repo := &outboxRepo{orders: map[uuid.UUID]models.Order{}}
+	pub := &orderPublisher{}
+
+	// The relay isn't started: each step runs by hand, the way a
+	// restarted gateway's relay would pick up where the last one died.
+	relay := NewOutboxRelay(repo, pub, time.Hour)
+	svc := NewOrderService(repo, nil, pub, nil, nil)
+	svc.SetOutbox(relay)
+	req := models.PlaceOrderRequest{Symbol: "BTC-USD", Side: models.Buy, Type: models.Limit, Price: 100, Quantity: 1}
+
+	// Crash before commit: neither the order nor its event exist.
+	repo.failSave = true
+	if _, err := svc.PlaceOrder(ctx, req, uuid.New()); err == nil {
+		t.Fatal("expected PlaceOrder to fail")
+	}
+	repo.failSave = false
+	if len(repo.orders) != 0 || len(repo.events) != 0 {
+		t.Fatalf("expected nothing saved, got %d orders and %d events", len(repo.orders), len(repo.events))
+	}
+
+	// Crash after commit, before publishing: the order is open and its
+	// event waits in the outbox.
+	resp, err := svc.PlaceOrder(ctx, req, uuid.New())
+	if err != nil {
+		t.Fatalf("PlaceOrder: %v", err)
+	}
+	if resp.Order.Status != models.StatusOpen || len(pub.published) != 0 || repo.unsent() != 1 {
+		t.Fatalf("expected an open, unpublished order with its event pending, got %s, %d published, %d unsent",
+			resp.Order.Status, len(pub.published), repo.unsent())
+	}
+
+	// Kafka is down: the event stays unsent and the order stays open,
+	// instead of being rejected.
+	pub.fail = true
+	if _, err := relay.RelayOnce(ctx); err == nil {
+		t.Fatal("expected the relay to fail")
+	}
+	if repo.unsent() != 1 || repo.orders[resp.Order.ID].Status != models.StatusOpen {
+		t.Fatalf("expected the event to stay pending and the order open")
+	}
+
+	// Crash after publishing, before marking sent: the next pass
+	// publishes it again, which the engine's consumer drops.
+	pub.fail, repo.failMark = false, true
+	if _, err := relay.RelayOnce(ctx); err == nil {
+		t.Fatal("expected marking sent to fail")
+	}
+	repo.failMark = false
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 1 {
+		t.Fatalf("RelayOnce: sent %d (%v), want 1", n, err)
+	}
+	if len(pub.published) != 2 || pub.published[0].ID != resp.Order.ID || pub.published[1].ID != resp.Order.ID {
+		t.Fatalf("expected the order published twice, got %d", len(pub.published))
+	}
+
+	// Once marked sent, it is never published again.
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 0 || repo.unsent() != 0 {
+		t.Errorf("expected an empty outbox, sent %d (%v) with %d unsent", n, err, repo.unsent())
+	}
+}
+
+func TestOutboxRelaysCancelsInOrder(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	repo := &outboxRepo{orders: map[uuid.UUID]models.Order{}}
+	pub := &orderPublisher{}
+	relay := NewOutboxRelay(repo, pub, time.Hour)
+
+	order := func() *models.Order {
+		return &models.Order{ID: uuid.New(), Symbol: "BTC-USD", Status: models.StatusOpen}
+	}
+	original, replacement, other := order(), order(), order()
+	for _, o := range []*models.Order{original, other} {
+		if err := relay.saveOrder(o); err != nil {
+			t.Fatalf("saveOrder: %v", err)
+		}
+	}
+	if err := relay.replaceOrder(original, replacement); err != nil {
+		t.Fatalf("replaceOrder: %v", err)
+	}
+	if err := relay.cancelOrder(other); err != nil {
+		t.Fatalf("cancelOrder: %v", err)
+	}
+	if repo.orders[original.ID].Status != models.StatusCancelled || repo.orders[other.ID].Status != models.StatusCancelled {
+		t.Fatal("expected both orders cancelled with their events")
+	}
+
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 5 {
+		t.Fatalf("RelayOnce: sent %d (%v), want 5", n, err)
+	}
+	// The original leaves the book before its replacement is published.
+	want := []string{"orders", "cancels", "orders", "cancels"}
+	if fmt.Sprint(pub.calls) != fmt.Sprint(want) {
+		t.Errorf("expected writes %v, got %v", want, pub.calls)
+	}
+	if len(pub.cancelled) != 2 || pub.cancelled[0].ID != original.ID || pub.cancelled[1].ID != other.ID {
+		t.Errorf("expected the original and the other order cancelled, got %+v", pub.cancelled)
+	}
+}
+
+// crashingRelayRepo is a real repository whose relay can die after
+// publishing, before marking events sent.
+type crashingRelayRepo struct {
+	*db.SQLiteRepository
+	failMark bool
+}
+
+func (r *crashingRelayRepo) MarkEventsSent(ids []int64) error {
+	if r.failMark {
+		return errors.New("connection lost")
+	}
+	return r.SQLiteRepository.MarkEventsSent(ids)
+}
+
+type nopBroadcaster struct{}
+
+func (nopBroadcaster) BroadcastTrade(models.TradeEvent)                  {}
+func (nopBroadcaster) BroadcastOrderBookUpdate(models.OrderBookSnapshot) {}
+
+func TestEngineRestartDropsRedeliveredOrders(t *testing.T) {
+	logger.InitForTest()
+	ctx := context.Background()
+	database, err := db.NewSQLiteConnection(":memory:")
+	if err != nil {
+		t.Fatalf("NewSQLiteConnection: %v", err)
+	}
+	if err := db.Migrate(database); err != nil {
+		t.Fatalf("Migrate: %v", err)
+	}
+	repo := &crashingRelayRepo{SQLiteRepository: db.NewSQLiteRepository(database)}
+	mem := cache.NewMemory()
+	defer mem.Close()
+	bus := eventbus.New(1)
+
+	relay := NewOutboxRelay(repo, bus.Publisher(kafka.SourceGateway, nil), time.Hour)
+	gateway := NewOrderService(repo, repo, nil, mem, nopBroadcaster{})
+	gateway.SetOutbox(relay)
+
+	// startEngine starts an engine process the way cmd/engine does. It
+	// sends every order it matches on the returned channel.
+	startEngine := func() (*engine.Matcher, <-chan models.Order, func()) {
+		ctx, cancel := context.WithCancel(ctx)
+		matcher := engine.NewMatcher()
+		if _, err := RestoreBooks(ctx, repo, matcher); err != nil {
+			t.Fatalf("RestoreBooks: %v", err)
+		}
+		pub := bus.Publisher(kafka.SourceEngine, nil)
+		consumer := kafka.NewOrderConsumerFromReader(bus.Reader(kafka.TopicOrders, kafka.GroupEngine), matcher, pub)
+		consumer.SetOrders(repo)
+		consumer.AddHandler(NewOrderService(repo, repo, pub, mem, nopBroadcaster{}).PostMatchHandler)
+		matched := make(chan models.Order, 8)
+		consumer.AddHandler(func(_ context.Context, order models.Order, _ []models.Trade) error {
+			matched <- order
+			return nil
+		})
+		go consumer.Start(ctx)
+		return matcher, matched, func() {
+			cancel()
+			consumer.Close()
+		}
+	}
+	next := func(matched <-chan models.Order) models.Order {
+		t.Helper()
+		select {
+		case order := <-matched:
+			return order
+		case <-time.After(2 * time.Second):
+			t.Fatal("timed out waiting for the engine")
+			return models.Order{}
+		}
+	}
+
+	_, matched, stop := startEngine()
+
+	place := func(side models.Side, price, qty float64) models.Order {
+		t.Helper()
+		resp, err := gateway.PlaceOrder(ctx, models.PlaceOrderRequest{
+			Symbol: "BTC-USD", Side: side, Type: models.Limit, Price: price, Quantity: qty,
+		}, uuid.New())
+		if err != nil {
+			t.Fatalf("PlaceOrder: %v", err)
+		}
+		return resp.Order
+	}
+	sell := place(models.Sell, 100, 2) // rests, then half fills
+	buy := place(models.Buy, 100, 1)   // fills against the sell
+	bid := place(models.Buy, 90, 1)    // rests untouched
+
+	// The relay publishes all three and dies before marking them sent.
+	repo.failMark = true
+	if _, err := relay.RelayOnce(ctx); err == nil {
+		t.Fatal("expected marking sent to fail")
+	}
+	repo.failMark = false
+	for range 3 {
+		next(matched)
+	}
+	stop()
+
+	// The engine restarts and the gateway's relay publishes the batch
+	// again. Only the untouched bid could be new to the engine, so it
+	// is the only one matched.
+	matcher, matched, stop := startEngine()
+	defer stop()
+	if n, err := relay.RelayOnce(ctx); err != nil || n != 3 {
+		t.Fatalf("RelayOnce: sent %d (%v), want 3", n, err)
+	}
+	if got := next(matched); got.ID != bid.ID {
+		t.Errorf("expected only the untouched bid matched again, got %s", got.ID)
+	}
+
+	trades, err := repo.GetTradesByOrderID(buy.ID)
+	if err != nil || len(trades) != 1 {
+		t.Errorf("expected the buy filled once, got %d trades (%v)", len(trades), err)
+	}
+	resting, ok := matcher.BookFor("BTC-USD").Get(sell.ID)
+	if !ok || resting.RemainingQty != 1 {
+		t.Errorf("expected the sell resting with 1 left, got %+v", resting)
+	}
+	if stats, _ := matcher.Stats("BTC-USD"); stats.RestingOrders != 2 {
+		t.Errorf("expected the sell and bid resting, got %d orders", stats.RestingOrders)
+	}
+}
@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/internal/tracing"
+)
+
+// RestoreBooks rebuilds the matcher's books from the open orders in the
+// database. Run it before the order consumer starts — the books are
+// otherwise empty after a restart and resting liquidity is lost.
+func RestoreBooks(ctx context.Context, orders ports.OrderRepository, matcher *engine.Matcher) (int, error) {
+	restored := 0
+	err := tracing.WithSpan(ctx, "engine.RestoreBooks", func(context.Context) error {
+		open, err := orders.GetOpenOrders()
+		if err != nil {
+			return err
+		}
+		restored = matcher.Restore(open)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("RestoreBooks: %w", err)
+	}
+	return restored, nil
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Im-Manav/ome/internal/engine"
+	"github.com/Im-Manav/ome/internal/ports"
+	"github.com/Im-Manav/ome/pkg/logger"
+	"github.com/Im-Manav/ome/pkg/models"
+)
+
+const (
+	snapshotDepth = 20
+	snapshotTTL   = 30 * time.Second
+)
+
+// SnapshotPublisher caches the engine's aggregated book for a symbol
+// and pushes it to subscribers. Runs in the engine process — it reads
+// the matcher's books directly, so it is safe to call from any goroutine.
+type SnapshotPublisher struct {
+	matcher   *engine.Matcher
+	cache     ports.Cache
+	broadcast ports.Broadcaster
+}
+
+func NewSnapshotPublisher(
+	matcher *engine.Matcher,
+	cache ports.Cache,
+	broadcast ports.Broadcaster,
+) *SnapshotPublisher {
+	return &SnapshotPublisher{
+		matcher:   matcher,
+		cache:     cache,
+		broadcast: broadcast,
+	}
+}
+
+// PostMatchHandler refreshes the snapshot for the order's symbol after every match.
+func (p *SnapshotPublisher) PostMatchHandler(
+	ctx context.Context,
+	order models.Order,
+	trades []models.Trade,
+) error {
+	return p.Publish(ctx, order.Symbol)
+}
+
+// CancelHandler refreshes the snapshot after an order leaves the book.
+func (p *SnapshotPublisher) CancelHandler(ctx context.Context, order models.Order) error {
+	return p.Publish(ctx, order.Symbol)
+}
+
+// Publish builds a fresh snapshot for symbol, caches it for
+// GET /orderbook reads and publishes it on the order book channel.
+func (p *SnapshotPublisher) Publish(ctx context.Context, symbol string) error {
+	bids, asks := p.matcher.Depth(symbol, snapshotDepth)
+	snap := models.OrderBookSnapshot{
+		Symbol:    symbol,
+		Bids:      bids,
+		Asks:      asks,
+		Timestamp: time.Now().UTC(),
+	}
+	if err := p.cache.SetOrderBookSnapshot(ctx, symbol, snap, snapshotTTL); err != nil {
+		logger.Error("failed to cache orderbook snapshot", logger.Err(err))
+	}
+	p.broadcast.BroadcastOrderBookUpdate(snap)
+	return p.cache.PublishOrderBookUpdate(ctx, snap)
+}
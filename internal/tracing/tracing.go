@@ -0,0 +1,106 @@
+// Package tracing sets up OpenTelemetry for a service and holds the
+// small helpers the rest of the code uses to create spans.
+//
+// Trace context follows an order from the gateway's HTTP request, through
+// the Kafka headers written by Producer.publish, into the engine's
+// OrderConsumer and on to the trades consumers. With no exporter
+// configured the global provider stays a no-op and spans cost nothing.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.40.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/Im-Manav/ome"
+
+// Exporter names accepted by Init (OTEL_TRACES_EXPORTER).
+const (
+	ExporterNone   = "none"
+	ExporterOTLP   = "otlp"   // OTLP/HTTP, endpoint from OTEL_EXPORTER_OTLP_ENDPOINT
+	ExporterStdout = "stdout" // pretty-printed spans on stdout, for local debugging
+)
+
+// Init installs the global tracer provider and W3C propagators for a
+// service. The returned shutdown flushes buffered spans — defer it in main.
+func Init(ctx context.Context, serviceName, exporter string) (shutdown func(context.Context) error, err error) {
+	// Propagators are installed even with tracing off, so a service in the
+	// middle of the flow still forwards trace context it receives.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	var spanExporter sdktrace.SpanExporter
+	switch exporter {
+	case "", ExporterNone:
+		return func(context.Context) error { return nil }, nil
+	case ExporterOTLP:
+		spanExporter, err = otlptracehttp.New(ctx)
+	case ExporterStdout:
+		spanExporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout), stdouttrace.WithPrettyPrint())
+	default:
+		return nil, fmt.Errorf("unknown trace exporter %q", exporter)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("create %s trace exporter: %w", exporter, err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(
+		semconv.SchemaURL,
+		semconv.ServiceName(serviceName),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("trace resource: %w", err)
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(spanExporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+	return tp.Shutdown, nil
+}
+
+// Tracer returns the tracer every package in this module shares.
+func Tracer() trace.Tracer {
+	return otel.Tracer(instrumentationName)
+}
+
+// Start begins a span on the shared tracer.
+func Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return Tracer().Start(ctx, name, opts...)
+}
+
+// RecordError marks span as failed. A nil err is a no-op, so it can be
+// called unconditionally before End.
+func RecordError(span trace.Span, err error) {
+	if err == nil {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// WithSpan runs fn inside a child span named name. The repository ports
+// don't take a context, so services wrap calls like SaveOrder with this
+// to get them onto the request's trace.
+func WithSpan(ctx context.Context, name string, fn func(ctx context.Context) error) error {
+	ctx, span := Start(ctx, name)
+	defer span.End()
+
+	err := fn(ctx)
+	RecordError(span, err)
+	return err
+}
@@ -0,0 +1,20 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInitExporters(t *testing.T) {
+	for _, exporter := range []string{ExporterNone, ExporterStdout, ExporterOTLP} {
+		shutdown, err := Init(context.Background(), "test", exporter)
+		if err != nil {
+			t.Fatalf("%s: %v", exporter, err)
+		}
+		_ = shutdown(context.Background())
+	}
+
+	if _, err := Init(context.Background(), "test", "zipkin"); err == nil {
+		t.Error("expected an error for an unknown exporter")
+	}
+}
@@ -21,6 +21,20 @@ var (
 	ErrKafkaPublish        = errors.New("failed to publish to kafka")
 	ErrInsufficientBalance = errors.New("insufficient balance")
 	ErrSelfTrade           = errors.New("self-trade not permitted")
+	ErrInvalidQuery        = errors.New("invalid query")
+	ErrInvalidCursor       = errors.New("invalid cursor")
+	ErrForbidden           = errors.New("forbidden")
+	ErrAPIKeyNotFound      = errors.New("api key not found")
+	ErrInvalidAPIKeyReq    = errors.New("invalid api key request")
+	ErrAccountFrozen       = errors.New("account frozen")
+	ErrUserNotFound        = errors.New("user not found")
+	ErrInvalidRole         = errors.New("invalid role")
+	ErrSymbolHalted        = errors.New("symbol is not trading")
+	ErrSessionNotFound     = errors.New("session not found")
+	ErrDuplicateClientID   = errors.New("client order id already used for a different order")
+	ErrIdempotencyConflict = errors.New("a request with this idempotency key is still in progress")
+	ErrIdempotencyMismatch = errors.New("idempotency key already used for a different request")
+	ErrNotAmendable        = errors.New("only limit orders can be amended")
 )
 
 // AppError wraps a domain error with an HTTP status code
@@ -46,8 +60,23 @@ func ToHTTP(err error) *AppError {
 	switch {
 	case errors.Is(err, ErrOrderNotFound):
 		return New(http.StatusNotFound, "Order not found", err)
+	case errors.Is(err, ErrAPIKeyNotFound):
+		return New(http.StatusNotFound, "API key not found", err)
+	case errors.Is(err, ErrSessionNotFound):
+		return New(http.StatusNotFound, "Session not found", err)
+	case errors.Is(err, ErrUserNotFound):
+		return New(http.StatusNotFound, "User not found", err)
+	case errors.Is(err, ErrAccountFrozen):
+		return New(http.StatusForbidden, "Account frozen", err)
+	case errors.Is(err, ErrForbidden):
+		return New(http.StatusForbidden, "Forbidden", err)
 	case errors.Is(err, ErrUnauthorized):
 		return New(http.StatusUnauthorized, "Unauthorized", err)
+	case errors.Is(err, ErrDuplicateClientID),
+		errors.Is(err, ErrIdempotencyConflict):
+		return New(http.StatusConflict, err.Error(), err)
+	case errors.Is(err, ErrIdempotencyMismatch):
+		return New(http.StatusUnprocessableEntity, err.Error(), err)
 	case errors.Is(err, ErrRateLimitExceeded):
 		return New(http.StatusTooManyRequests, "Rate limit exceeded", err)
 	case errors.Is(err, ErrOrderAlreadyFilled),
@@ -57,7 +86,13 @@ func ToHTTP(err error) *AppError {
 		errors.Is(err, ErrInvalidQuantity),
 		errors.Is(err, ErrInvalidOrderType),
 		errors.Is(err, ErrSymbolRequired),
-		errors.Is(err, ErrSelfTrade):
+		errors.Is(err, ErrSelfTrade),
+		errors.Is(err, ErrInvalidQuery),
+		errors.Is(err, ErrInvalidCursor),
+		errors.Is(err, ErrInvalidAPIKeyReq),
+		errors.Is(err, ErrInvalidRole),
+		errors.Is(err, ErrSymbolHalted),
+		errors.Is(err, ErrNotAmendable):
 		return New(http.StatusBadRequest, err.Error(), err)
 	default:
 		return New(http.StatusInternalServerError, "Internal server error", err)
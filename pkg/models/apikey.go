@@ -0,0 +1,89 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// API key scopes. A key only reaches the routes its scopes cover;
+// JWT sessions have every scope.
+const (
+	ScopeRead     = "read"
+	ScopeTrade    = "trade"
+	ScopeWithdraw = "withdraw"
+)
+
+var validScopes = []string{ScopeRead, ScopeTrade, ScopeWithdraw}
+
+func IsValidScope(scope string) bool {
+	return slices.Contains(validScopes, scope)
+}
+
+// APIKey lets a program act for a user by signing requests with a
+// secret instead of holding a JWT. The secret has to be recoverable to
+// verify HMACs, so it is stored encrypted rather than hashed.
+//
+// Scopes and AllowedIPs are comma-separated in the table; use the
+// accessor methods rather than splitting them yourself.
+type APIKey struct {
+	ID              uuid.UUID  `json:"id"           gorm:"type:uuid;primaryKey"`
+	UserID          uuid.UUID  `json:"user_id"      gorm:"type:uuid;not null;index"`
+	Key             string     `json:"key"          gorm:"uniqueIndex;not null"` // public identifier sent with each request
+	Name            string     `json:"name"`
+	Scopes          string     `json:"-"            gorm:"not null"`
+	AllowedIPs      string     `json:"-"`
+	SecretEncrypted []byte     `json:"-"            gorm:"not null"`
+	ExpiresAt       *time.Time `json:"expires_at,omitempty"`
+	RevokedAt       *time.Time `json:"revoked_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+}
+
+func (k *APIKey) ScopeList() []string { return splitList(k.Scopes) }
+
+func (k *APIKey) AllowedIPList() []string { return splitList(k.AllowedIPs) }
+
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.ScopeList(), scope)
+}
+
+// Active reports whether the key can still authenticate at now.
+func (k *APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
+
+func splitList(s string) []string {
+	if s == "" {
+		return []string{}
+	}
+	return strings.Split(s, ",")
+}
+
+// APIKeyView is how a key is listed — everything but the secret.
+type APIKeyView struct {
+	*APIKey
+	Scopes     []string `json:"scopes"`
+	AllowedIPs []string `json:"allowed_ips"`
+}
+
+func (k *APIKey) View() APIKeyView {
+	return APIKeyView{APIKey: k, Scopes: k.ScopeList(), AllowedIPs: k.AllowedIPList()}
+}
+
+type CreateAPIKeyRequest struct {
+	Name       string     `json:"name"        binding:"required,max=64"`
+	Scopes     []string   `json:"scopes"      binding:"required,min=1"`
+	AllowedIPs []string   `json:"allowed_ips"` // IPs or CIDRs; empty allows any
+	ExpiresAt  *time.Time `json:"expires_at"`  // nil never expires
+}
+
+// CreateAPIKeyResponse is the only time the secret is ever returned.
+type CreateAPIKeyResponse struct {
+	APIKeyView
+	Secret string `json:"secret"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AuditLog records one request to the admin API — who, what, on which
+// target, and how it ended. Rows are only ever inserted.
+type AuditLog struct {
+	ID         uuid.UUID `json:"id"          gorm:"type:uuid;primaryKey"`
+	ActorID    uuid.UUID `json:"actor_id"    gorm:"type:uuid;not null;index"`
+	ActorEmail string    `json:"actor_email"`
+	Action     string    `json:"action"      gorm:"not null"` // route, e.g. "POST /api/v1/admin/users/:id/freeze"
+	Target     string    `json:"target"`                      // e.g. "user:<id>", "instrument:BTC-USD"
+	Status     int       `json:"status"`                      // HTTP status the action ended with
+	Detail     string    `json:"detail,omitempty"`            // request body or handler-supplied note
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"  gorm:"index"`
+}
@@ -0,0 +1,11 @@
+package models
+
+// IdempotentResponse is a stored response to a request sent with an
+// Idempotency-Key header, replayed when the request is retried.
+// RequestHash fingerprints the original request so the key can't be
+// reused for a different one.
+type IdempotentResponse struct {
+	RequestHash string `json:"request_hash"`
+	Status      int    `json:"status"`
+	Body        []byte `json:"body"`
+}
@@ -0,0 +1,39 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// Instrument statuses. The gateway rejects new orders for a halted
+// instrument; resting orders stay in the book until cancelled.
+const (
+	InstrumentTrading = "trading"
+	InstrumentHalted  = "halted"
+)
+
+// Instrument is a tradable symbol. Pairs like BTC-USD split into base
+// and quote assets; single-name symbols like AAPL have no quote.
+type Instrument struct {
+	Symbol    string    `json:"symbol"          gorm:"primaryKey"`
+	Base      string    `json:"base"            gorm:"not null"`
+	Quote     string    `json:"quote,omitempty"`
+	Status    string    `json:"status"          gorm:"not null;default:trading"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewInstrument(symbol string) Instrument {
+	base, quote, _ := strings.Cut(symbol, "-")
+	return Instrument{Symbol: symbol, Base: base, Quote: quote, Status: InstrumentTrading}
+}
+
+func (i *Instrument) Tradable() bool { return i.Status == InstrumentTrading }
+
+// UpsertInstrumentRequest creates or updates an instrument. Base and
+// quote default to the symbol split on "-".
+type UpsertInstrumentRequest struct {
+	Base   string `json:"base"`
+	Quote  string `json:"quote"`
+	Status string `json:"status" binding:"omitempty,oneof=trading halted"`
+}
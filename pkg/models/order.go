@@ -1,6 +1,8 @@
 package models
 
 import (
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -70,47 +72,188 @@ func (s OrderStatus) String() string {
 	}
 }
 
+// ParseSide accepts a side by name ("buy", "SELL") or by number ("0").
+func ParseSide(s string) (Side, bool) {
+	switch strings.ToUpper(s) {
+	case "BUY", "0":
+		return Buy, true
+	case "SELL", "1":
+		return Sell, true
+	}
+	return 0, false
+}
+
+// ParseOrderStatus accepts a status by name ("open", "FILLED") or by number ("2").
+func ParseOrderStatus(s string) (OrderStatus, bool) {
+	for st := StatusOpen; st <= StatusRejected; st++ {
+		if strings.EqualFold(s, st.String()) || s == strconv.Itoa(int(st)) {
+			return st, true
+		}
+	}
+	return 0, false
+}
+
 // NOTE: For quantity float64 is used for simplicity. Production systems should use
 // fixed-point arithmetic or a decimal library (shopspring/decimal)
 // to avoid IEEE 754 rounding errors in price/quantity comparisons.
 
-// Order is the core domain entity
+// Order is the core domain entity.
+// idx_orders_user_history (user_id, created_at, id) backs the
+// paginated order history — see Repository.ListOrdersByUserID.
+// ClientOrderID is unique per user when set; the partial index is
+// created in db.Migrate.
 type Order struct {
-	ID           uuid.UUID   `json:"id" gorm:"type:uuid;primaryKey"`
-	UserID       uuid.UUID   `json:"user_id"       gorm:"type:uuid;not null;index"`
-	Symbol       string      `json:"symbol"        gorm:"not null;index"`
-	Side         Side        `json:"side"          gorm:"not null"`
-	Type         OrderType   `json:"type"          gorm:"not null"`
-	Price        float64     `json:"price"         gorm:"not null"`  // 0 for market orders
-	Quantity     float64     `json:"quantity"      gorm:"not null"`  // original quantity
-	FilledQty    float64     `json:"filled_qty"    gorm:"default:0"` // how much has been matched
-	RemainingQty float64     `json:"remaining_qty" gorm:"not null"`  // quantity left to fill
-	Status       OrderStatus `json:"status"        gorm:"default:0;index"`
-	CreatedAt    time.Time   `json:"created_at"    gorm:"index"`
-	UpdatedAt    time.Time   `json:"updated_at"`
-}
-
-// PlaceOrderRequest is what the API receives from clients
+	ID            uuid.UUID   `json:"id" gorm:"type:uuid;primaryKey;index:idx_orders_user_history,priority:3"`
+	UserID        uuid.UUID   `json:"user_id"       gorm:"type:uuid;not null;index:idx_orders_user_history,priority:1"`
+	ClientOrderID string      `json:"client_order_id,omitempty" gorm:"size:64"`
+	Symbol        string      `json:"symbol"        gorm:"not null;index"`
+	Side          Side        `json:"side"          gorm:"not null"`
+	Type          OrderType   `json:"type"          gorm:"not null"`
+	Price         float64     `json:"price"         gorm:"not null"`  // 0 for market orders
+	Quantity      float64     `json:"quantity"      gorm:"not null"`  // original quantity
+	FilledQty     float64     `json:"filled_qty"    gorm:"default:0"` // how much has been matched
+	RemainingQty  float64     `json:"remaining_qty" gorm:"not null"`  // quantity left to fill
+	Status        OrderStatus `json:"status"        gorm:"default:0;index"`
+	CreatedAt     time.Time   `json:"created_at"    gorm:"index;index:idx_orders_user_history,priority:2"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+// PlaceOrderRequest is what the API receives from clients.
+// ClientOrderID is optional; resubmitting one returns the existing order.
 type PlaceOrderRequest struct {
-	Symbol   string    `json:"symbol" binding:"required"`
-	Side     Side      `json:"side"     binding:"oneof=0 1"`
-	Type     OrderType `json:"type"     binding:"oneof=0 1"`
-	Price    float64   `json:"price"` // validated in service layer
-	Quantity float64   `json:"quantity" binding:"required,gt=0"`
+	Symbol        string    `json:"symbol" binding:"required"`
+	Side          Side      `json:"side"     binding:"oneof=0 1"`
+	Type          OrderType `json:"type"     binding:"oneof=0 1"`
+	Price         float64   `json:"price"` // validated in service layer
+	Quantity      float64   `json:"quantity" binding:"required,gt=0"`
+	ClientOrderID string    `json:"client_order_id" binding:"omitempty,max=64,printascii"`
 }
 
-// PlaceOrderResponse is what the API returns after matching
+// PlaceOrderResponse is what the API returns after matching. Without
+// ?wait=true, or when the engine doesn't answer in time, Order is the
+// accepted order, Trades is empty and Pending says the result will only
+// arrive over WebSocket. Duplicate says the client order ID was already
+// used and Order is the existing order, as it stands now.
 type PlaceOrderResponse struct {
+	Order     Order   `json:"order"`
+	Trades    []Trade `json:"trades"`
+	Pending   bool    `json:"pending,omitempty"`
+	Duplicate bool    `json:"duplicate,omitempty"`
+}
+
+// Execution is the engine's result for one incoming order: its state
+// after matching and the trades it produced. The engine publishes one
+// per order, after persisting it, for gateways waiting on the result.
+type Execution struct {
 	Order  Order   `json:"order"`
 	Trades []Trade `json:"trades"`
 }
 
+// UserExecution is an Execution as it affected one user. Order is set
+// when it was their incoming order; Fills are their side of each trade,
+// including fills on their resting orders.
+type UserExecution struct {
+	Order *Order      `json:"order,omitempty"`
+	Fills []UserTrade `json:"fills"`
+}
+
+// Liquidity says whether a fill added to the book or took from it.
+// It is unknown for trades with no recorded taker side.
+const (
+	LiquidityMaker   = "maker"
+	LiquidityTaker   = "taker"
+	LiquidityUnknown = "unknown"
+)
+
+// Fill is one trade from the point of view of one of its orders.
+type Fill struct {
+	Trade
+	Liquidity string   `json:"liquidity"` // maker | taker | unknown
+	Fee       *float64 `json:"fee"`       // in the quote currency; null when liquidity is unknown
+}
+
+// OrderFill is a quantity filled against one order.
+type OrderFill struct {
+	OrderID  uuid.UUID
+	Quantity float64
+}
+
+// OrderEvent is one step in an order's lifecycle. Status is the
+// order's status after the step; FilledQty is cumulative.
+type OrderEvent struct {
+	Time      time.Time   `json:"time"`
+	Event     string      `json:"event"` // created | fill | cancelled | rejected
+	Status    OrderStatus `json:"status"`
+	FilledQty float64     `json:"filled_qty"`
+	TradeID   *uuid.UUID  `json:"trade_id,omitempty"`
+}
+
+// OrderDetail is what GET /api/v1/orders/:id returns.
+type OrderDetail struct {
+	Order        Order        `json:"order"`
+	Fills        []Fill       `json:"fills"`
+	AvgFillPrice float64      `json:"avg_fill_price"` // volume-weighted, 0 when unfilled
+	Fees         *float64     `json:"fees"`           // null when any fill's fee is unknown
+	Timeline     []OrderEvent `json:"timeline"`
+}
+
+// AmendOrderRequest changes a resting limit order. Zero keeps the
+// current price or remaining quantity; Quantity is the replacement's
+// quantity. ClientOrderID is for the replacement, since the original
+// keeps its own.
+type AmendOrderRequest struct {
+	Price         float64 `json:"price"`
+	Quantity      float64 `json:"quantity"`
+	ClientOrderID string  `json:"client_order_id" binding:"omitempty,max=64,printascii"`
+}
+
 // CancelOrderResponse confirms a cancellation
 type CancelOrderResponse struct {
 	OrderID string `json:"order_id"`
 	Status  string `json:"status"`
 }
 
+// MaxBatchSize caps the items in one batch request. Each item counts
+// against the caller's rate limit.
+const MaxBatchSize = 50
+
+// PlaceOrderBatchRequest places several orders at once. Items are
+// validated one by one, so a bad item fails alone.
+type PlaceOrderBatchRequest struct {
+	Orders []PlaceOrderRequest `json:"orders" binding:"required,min=1,max=50"`
+}
+
+type CancelOrderBatchRequest struct {
+	OrderIDs []uuid.UUID `json:"order_ids" binding:"required,min=1,max=50"`
+}
+
+// BatchResult is the outcome of one item of a batch, in request order.
+// Error is set when the item failed; the rest of the batch is unaffected.
+type BatchResult struct {
+	Index   int    `json:"index"`
+	OrderID string `json:"order_id,omitempty"`
+	Order   *Order `json:"order,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+type BatchResponse struct {
+	Results   []BatchResult `json:"results"`
+	Succeeded int           `json:"succeeded"`
+	Failed    int           `json:"failed"`
+}
+
+func NewBatchResponse(results []BatchResult) BatchResponse {
+	resp := BatchResponse{Results: results}
+	for _, r := range results {
+		if r.Error != "" {
+			resp.Failed++
+		} else {
+			resp.Succeeded++
+		}
+	}
+	return resp
+}
+
 // OrderBookLevel represents one price level in the order book depth
 type OrderBookLevel struct {
 	Price    float64 `json:"price"`
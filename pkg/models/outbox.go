@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Outbox event types — the same names as the Kafka event types they
+// are published as.
+const (
+	OutboxOrderPlaced          = "order.placed"
+	OutboxOrderCancelRequested = "order.cancel_requested"
+)
+
+// OutboxEvent is an event saved in the same transaction as the rows it
+// describes and published to Kafka afterwards by the outbox relay, so a
+// crash can't leave one without the other. IDs increase in insert
+// order and the relay publishes in ID order.
+type OutboxEvent struct {
+	ID        int64      `json:"id"         gorm:"primaryKey;autoIncrement"`
+	Type      string     `json:"type"       gorm:"not null"`
+	Key       string     `json:"key"        gorm:"not null"`
+	Payload   []byte     `json:"payload"    gorm:"not null"` // JSON
+	CreatedAt time.Time  `json:"created_at"`
+	SentAt    *time.Time `json:"sent_at"    gorm:"index"`
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Page size bounds for cursor-paginated listings.
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+var errMalformedCursor = errors.New("malformed cursor")
+
+// Cursor marks a position in a listing ordered newest first by
+// (time, id). The id breaks ties between rows with the same timestamp,
+// so pages never skip or repeat a row.
+type Cursor struct {
+	Time time.Time
+	ID   uuid.UUID
+}
+
+// Encode returns the opaque token clients send back as ?cursor=.
+func (c Cursor) Encode() string {
+	raw := c.Time.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor parses a token produced by Cursor.Encode.
+func DecodeCursor(token string) (Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return Cursor{}, errMalformedCursor
+	}
+	ts, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return Cursor{}, errMalformedCursor
+	}
+	t, err := time.Parse(time.RFC3339Nano, ts)
+	if err != nil {
+		return Cursor{}, errMalformedCursor
+	}
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return Cursor{}, errMalformedCursor
+	}
+	return Cursor{Time: t, ID: uid}, nil
+}
+
+// ClampPageLimit applies the default to a missing limit and caps it.
+func ClampPageLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return DefaultPageLimit
+	case limit > MaxPageLimit:
+		return MaxPageLimit
+	default:
+		return limit
+	}
+}
+
+// PageQuery is the time range and position shared by paginated
+// listings. Zero values mean "any".
+type PageQuery struct {
+	From  time.Time // timestamp >= From
+	To    time.Time // timestamp < To
+	After *Cursor   // continue after this position
+	Limit int
+}
+
+// OrderFilter narrows a user's order history by created_at.
+type OrderFilter struct {
+	PageQuery
+	Statuses []OrderStatus
+	Symbol   string
+	Side     *Side
+}
+
+// TradeFilter narrows a user's trade history by executed_at.
+type TradeFilter struct {
+	PageQuery
+	Symbol string
+}
+
+// Trade roles from one user's point of view.
+const (
+	RoleBuyer  = "buyer"
+	RoleSeller = "seller"
+)
+
+// UserTrade is a fill as seen by one of its two users.
+type UserTrade struct {
+	Fill
+	Role    string    `json:"role"` // buyer | seller
+	OrderID uuid.UUID `json:"order_id"`
+}
+
+// TradePage is one page of trade history. NextCursor is empty on the
+// last page.
+type TradePage struct {
+	Trades     []UserTrade `json:"trades"`
+	NextCursor string      `json:"next_cursor,omitempty"`
+}
+
+// OrderPage is one page of order history. NextCursor is empty on the
+// last page.
+type OrderPage struct {
+	Orders     []*Order `json:"orders"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
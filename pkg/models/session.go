@@ -0,0 +1,39 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session is one login on one device. Refresh tokens are
+// "<session id>.<generation>.<secret>", and each refresh moves the
+// session to the next generation. Only a hash of the current secret is
+// stored; every secret is a MAC of its session and generation, so one
+// from any earlier generation is still recognised. Presenting it means
+// the token was copied, so the whole session is revoked.
+type Session struct {
+	ID            uuid.UUID  `json:"id"            gorm:"type:uuid;primaryKey"`
+	UserID        uuid.UUID  `json:"-"             gorm:"type:uuid;not null;index"`
+	RefreshHash   string     `json:"-"             gorm:"not null"`
+	Generation    int        `json:"-"             gorm:"not null;default:0"`
+	UserAgent     string     `json:"user_agent"`
+	IP            string     `json:"ip"`
+	CreatedAt     time.Time  `json:"created_at"`
+	LastUsedAt    time.Time  `json:"last_used_at"`
+	ExpiresAt     time.Time  `json:"expires_at"`
+	RevokedAt     *time.Time `json:"-"`
+	RevokedReason string     `json:"-"`
+
+	// Current marks the session the listing request was made from.
+	Current bool `json:"current" gorm:"-"`
+}
+
+// Active reports whether the session can still be refreshed at now.
+func (s *Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Ticker is a symbol's rolling 24-hour market summary. The market data
+// service maintains everything but the best bid and ask, which the
+// gateway fills in from the order book snapshot when it serves one.
+type Ticker struct {
+	Symbol         string    `json:"symbol"`
+	LastPrice      float64   `json:"last_price"`
+	BestBid        float64   `json:"best_bid"` // 0 when the side is empty
+	BestAsk        float64   `json:"best_ask"`
+	Open           float64   `json:"open"` // first trade price in the window
+	High           float64   `json:"high"`
+	Low            float64   `json:"low"`
+	Volume         float64   `json:"volume"`       // base asset traded
+	QuoteVolume    float64   `json:"quote_volume"` // sum of price × quantity
+	PriceChange    float64   `json:"price_change"`
+	PriceChangePct float64   `json:"price_change_pct"`
+	TradeCount     int64     `json:"trade_count"`
+	OpenTime       time.Time `json:"open_time"` // start of the 24h window
+	UpdatedAt      time.Time `json:"updated_at"`
+}
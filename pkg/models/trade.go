@@ -6,16 +6,22 @@ import (
 	"github.com/google/uuid"
 )
 
+// Trade is one fill between a buy and a sell order.
+// idx_trades_buyer_history and idx_trades_seller_history back the
+// paginated trade history — see Repository.ListTradesByUserID.
 type Trade struct {
-	ID          uuid.UUID `json:"id"            gorm:"type:uuid;primaryKey"`
+	ID          uuid.UUID `json:"id"            gorm:"type:uuid;primaryKey;index:idx_trades_buyer_history,priority:3;index:idx_trades_seller_history,priority:3"`
 	Symbol      string    `json:"symbol"        gorm:"not null;index"`
 	BuyOrderID  uuid.UUID `json:"buy_order_id"  gorm:"type:uuid;not null;index"`
 	SellOrderID uuid.UUID `json:"sell_order_id" gorm:"type:uuid;not null;index"`
-	BuyUserID   uuid.UUID `json:"buy_user_id"   gorm:"type:uuid;not null"`
-	SellUserID  uuid.UUID `json:"sell_user_id"  gorm:"type:uuid;not null"`
+	BuyUserID   uuid.UUID `json:"buy_user_id"   gorm:"type:uuid;not null;index:idx_trades_buyer_history,priority:1"`
+	SellUserID  uuid.UUID `json:"sell_user_id"  gorm:"type:uuid;not null;index:idx_trades_seller_history,priority:1"`
 	Price       float64   `json:"price"         gorm:"not null"` // price at which trade executed
 	Quantity    float64   `json:"quantity"      gorm:"not null"` // quantity that traded
-	ExecutedAt  time.Time `json:"executed_at"   gorm:"index"`
+	ExecutedAt  time.Time `json:"executed_at"   gorm:"index;index:idx_trades_buyer_history,priority:2;index:idx_trades_seller_history,priority:2"`
+	// TakerSide is the side of the incoming (liquidity-taking) order,
+	// nil for trades recorded before it was tracked.
+	TakerSide *Side `json:"taker_side"`
 }
 
 // OHLCV is a candlestick bar — stored in TimescaleDB hypertable
@@ -29,6 +35,32 @@ type OHLCV struct {
 	Volume float64   `json:"volume"`
 }
 
+// Candle is one bar in the shape charting libraries take directly
+// (lightweight-charts, ECharts, Highcharts): time is the bucket start
+// in Unix seconds.
+type Candle struct {
+	Time   int64   `json:"time"`
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	Volume float64 `json:"volume"`
+}
+
+func CandleFromOHLCV(bar OHLCV) Candle {
+	return Candle{
+		Time: bar.Time.Unix(), Open: bar.Open, High: bar.High,
+		Low: bar.Low, Close: bar.Close, Volume: bar.Volume,
+	}
+}
+
+// CandleSeries is what GET /api/v1/candles/:symbol returns, oldest first.
+type CandleSeries struct {
+	Symbol   string   `json:"symbol"`
+	Interval string   `json:"interval"`
+	Candles  []Candle `json:"candles"`
+}
+
 // TradeEvent is published to Kafka and broadcast over WebSocket
 // It's a superset of Trade with extra fields for clients
 type TradeEvent struct {
@@ -1,17 +1,48 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// Role decides which admin routes a user can reach. Everyone is a
+// RoleUser unless an admin says otherwise.
+type Role string
+
+const (
+	RoleUser     Role = "user"
+	RoleOperator Role = "operator" // support and market operations
+	RoleAdmin    Role = "admin"    // operators plus account and role management
+)
+
+func ParseRole(s string) (Role, bool) {
+	switch r := Role(strings.ToLower(strings.TrimSpace(s))); r {
+	case RoleUser, RoleOperator, RoleAdmin:
+		return r, true
+	}
+	return "", false
+}
+
 type User struct {
-	ID           uuid.UUID `json:"id"         gorm:"type:uuid;primaryKey"`
-	Email        string    `json:"email"      gorm:"uniqueIndex;not null"`
-	PasswordHash string    `json:"-"          gorm:"not null"`
-	CreatedAt    time.Time `json:"created_at"`
-	UpdatedAt    time.Time `json:"updated_at"`
+	ID           uuid.UUID  `json:"id"         gorm:"type:uuid;primaryKey"`
+	Email        string     `json:"email"      gorm:"uniqueIndex;not null"`
+	PasswordHash string     `json:"-"          gorm:"not null"`
+	Role         Role       `json:"role"       gorm:"not null;default:user"`
+	FrozenAt     *time.Time `json:"frozen_at,omitempty"` // frozen accounts can't log in or call the API
+	FrozenReason string     `json:"frozen_reason,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at"`
+}
+
+// UserFilter narrows the admin user search. Email matches as a
+// case-insensitive substring.
+type UserFilter struct {
+	Email  string
+	Role   Role
+	Frozen *bool
+	Limit  int
 }
 
 type RegisterRequest struct {
@@ -24,7 +55,20 @@ type LoginRequest struct {
 	Password string `json:"password" binding:"required"`
 }
 
+// AuthResponse is returned by register, login and refresh. Token is a
+// short-lived access token; RefreshToken gets the next pair from
+// POST /auth/refresh and works only once.
 type AuthResponse struct {
-	Token string `json:"token"`
-	User  User   `json:"user"`
+	Token        string    `json:"token"`
+	ExpiresAt    time.Time `json:"expires_at"`
+	RefreshToken string    `json:"refresh_token"`
+	User         User      `json:"user"`
+}
+
+type SetRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type FreezeRequest struct {
+	Reason string `json:"reason" binding:"required,max=256"`
 }
@@ -0,0 +1,741 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: ome/events/v1/events.proto
+
+package eventsv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Side int32
+
+const (
+	Side_SIDE_BUY  Side = 0
+	Side_SIDE_SELL Side = 1
+)
+
+// Enum value maps for Side.
+var (
+	Side_name = map[int32]string{
+		0: "SIDE_BUY",
+		1: "SIDE_SELL",
+	}
+	Side_value = map[string]int32{
+		"SIDE_BUY":  0,
+		"SIDE_SELL": 1,
+	}
+)
+
+func (x Side) Enum() *Side {
+	p := new(Side)
+	*p = x
+	return p
+}
+
+func (x Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_ome_events_v1_events_proto_enumTypes[0].Descriptor()
+}
+
+func (Side) Type() protoreflect.EnumType {
+	return &file_ome_events_v1_events_proto_enumTypes[0]
+}
+
+func (x Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Side.Descriptor instead.
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return file_ome_events_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+type OrderType int32
+
+const (
+	OrderType_ORDER_TYPE_LIMIT  OrderType = 0
+	OrderType_ORDER_TYPE_MARKET OrderType = 1
+)
+
+// Enum value maps for OrderType.
+var (
+	OrderType_name = map[int32]string{
+		0: "ORDER_TYPE_LIMIT",
+		1: "ORDER_TYPE_MARKET",
+	}
+	OrderType_value = map[string]int32{
+		"ORDER_TYPE_LIMIT":  0,
+		"ORDER_TYPE_MARKET": 1,
+	}
+)
+
+func (x OrderType) Enum() *OrderType {
+	p := new(OrderType)
+	*p = x
+	return p
+}
+
+func (x OrderType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderType) Descriptor() protoreflect.EnumDescriptor {
+	return file_ome_events_v1_events_proto_enumTypes[1].Descriptor()
+}
+
+func (OrderType) Type() protoreflect.EnumType {
+	return &file_ome_events_v1_events_proto_enumTypes[1]
+}
+
+func (x OrderType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderType.Descriptor instead.
+func (OrderType) EnumDescriptor() ([]byte, []int) {
+	return file_ome_events_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+type OrderStatus int32
+
+const (
+	OrderStatus_ORDER_STATUS_OPEN      OrderStatus = 0
+	OrderStatus_ORDER_STATUS_PARTIAL   OrderStatus = 1
+	OrderStatus_ORDER_STATUS_FILLED    OrderStatus = 2
+	OrderStatus_ORDER_STATUS_CANCELLED OrderStatus = 3
+	OrderStatus_ORDER_STATUS_REJECTED  OrderStatus = 4
+)
+
+// Enum value maps for OrderStatus.
+var (
+	OrderStatus_name = map[int32]string{
+		0: "ORDER_STATUS_OPEN",
+		1: "ORDER_STATUS_PARTIAL",
+		2: "ORDER_STATUS_FILLED",
+		3: "ORDER_STATUS_CANCELLED",
+		4: "ORDER_STATUS_REJECTED",
+	}
+	OrderStatus_value = map[string]int32{
+		"ORDER_STATUS_OPEN":      0,
+		"ORDER_STATUS_PARTIAL":   1,
+		"ORDER_STATUS_FILLED":    2,
+		"ORDER_STATUS_CANCELLED": 3,
+		"ORDER_STATUS_REJECTED":  4,
+	}
+)
+
+func (x OrderStatus) Enum() *OrderStatus {
+	p := new(OrderStatus)
+	*p = x
+	return p
+}
+
+func (x OrderStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (OrderStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_ome_events_v1_events_proto_enumTypes[2].Descriptor()
+}
+
+func (OrderStatus) Type() protoreflect.EnumType {
+	return &file_ome_events_v1_events_proto_enumTypes[2]
+}
+
+func (x OrderStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use OrderStatus.Descriptor instead.
+func (OrderStatus) EnumDescriptor() ([]byte, []int) {
+	return file_ome_events_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+// Order is both the placement and cancel commands on the orders topic
+// (order.placed, order.cancel_requested) and the execution report on order-events
+// (order.accepted, order.partially_filled, order.filled, ...).
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                       // 16-byte UUID
+	UserId        []byte                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"` // 16-byte UUID
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          Side                   `protobuf:"varint,4,opt,name=side,proto3,enum=ome.events.v1.Side" json:"side,omitempty"`
+	Type          OrderType              `protobuf:"varint,5,opt,name=type,proto3,enum=ome.events.v1.OrderType" json:"type,omitempty"`
+	Price         float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FilledQty     float64                `protobuf:"fixed64,8,opt,name=filled_qty,json=filledQty,proto3" json:"filled_qty,omitempty"`
+	RemainingQty  float64                `protobuf:"fixed64,9,opt,name=remaining_qty,json=remainingQty,proto3" json:"remaining_qty,omitempty"`
+	Status        OrderStatus            `protobuf:"varint,10,opt,name=status,proto3,enum=ome.events.v1.OrderStatus" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,13,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_ome_events_v1_events_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_events_v1_events_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_ome_events_v1_events_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Order) GetUserId() []byte {
+	if x != nil {
+		return x.UserId
+	}
+	return nil
+}
+
+func (x *Order) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Order) GetSide() Side {
+	if x != nil {
+		return x.Side
+	}
+	return Side_SIDE_BUY
+}
+
+func (x *Order) GetType() OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return OrderType_ORDER_TYPE_LIMIT
+}
+
+func (x *Order) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Order) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Order) GetFilledQty() float64 {
+	if x != nil {
+		return x.FilledQty
+	}
+	return 0
+}
+
+func (x *Order) GetRemainingQty() float64 {
+	if x != nil {
+		return x.RemainingQty
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return OrderStatus_ORDER_STATUS_OPEN
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+// Trade is one fill between a buy and a sell order.
+type Trade struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Id          []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 16-byte UUID
+	Symbol      string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	BuyOrderId  []byte                 `protobuf:"bytes,3,opt,name=buy_order_id,json=buyOrderId,proto3" json:"buy_order_id,omitempty"`
+	SellOrderId []byte                 `protobuf:"bytes,4,opt,name=sell_order_id,json=sellOrderId,proto3" json:"sell_order_id,omitempty"`
+	BuyUserId   []byte                 `protobuf:"bytes,5,opt,name=buy_user_id,json=buyUserId,proto3" json:"buy_user_id,omitempty"`
+	SellUserId  []byte                 `protobuf:"bytes,6,opt,name=sell_user_id,json=sellUserId,proto3" json:"sell_user_id,omitempty"`
+	Price       float64                `protobuf:"fixed64,7,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity    float64                `protobuf:"fixed64,8,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ExecutedAt  *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	// Side of the incoming order that crossed the spread; unset for
+	// trades recorded before it was tracked.
+	TakerSide     *Side `protobuf:"varint,10,opt,name=taker_side,json=takerSide,proto3,enum=ome.events.v1.Side,oneof" json:"taker_side,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_ome_events_v1_events_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_events_v1_events_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_ome_events_v1_events_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Trade) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Trade) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Trade) GetBuyOrderId() []byte {
+	if x != nil {
+		return x.BuyOrderId
+	}
+	return nil
+}
+
+func (x *Trade) GetSellOrderId() []byte {
+	if x != nil {
+		return x.SellOrderId
+	}
+	return nil
+}
+
+func (x *Trade) GetBuyUserId() []byte {
+	if x != nil {
+		return x.BuyUserId
+	}
+	return nil
+}
+
+func (x *Trade) GetSellUserId() []byte {
+	if x != nil {
+		return x.SellUserId
+	}
+	return nil
+}
+
+func (x *Trade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Trade) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Trade) GetExecutedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return nil
+}
+
+func (x *Trade) GetTakerSide() Side {
+	if x != nil && x.TakerSide != nil {
+		return *x.TakerSide
+	}
+	return Side_SIDE_BUY
+}
+
+// TradeEvent is the trade.executed payload on the trades topic.
+type TradeEvent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trade         *Trade                 `protobuf:"bytes,1,opt,name=trade,proto3" json:"trade,omitempty"`
+	BuyerFilled   bool                   `protobuf:"varint,2,opt,name=buyer_filled,json=buyerFilled,proto3" json:"buyer_filled,omitempty"`
+	SellerFilled  bool                   `protobuf:"varint,3,opt,name=seller_filled,json=sellerFilled,proto3" json:"seller_filled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TradeEvent) Reset() {
+	*x = TradeEvent{}
+	mi := &file_ome_events_v1_events_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TradeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TradeEvent) ProtoMessage() {}
+
+func (x *TradeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_events_v1_events_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TradeEvent.ProtoReflect.Descriptor instead.
+func (*TradeEvent) Descriptor() ([]byte, []int) {
+	return file_ome_events_v1_events_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TradeEvent) GetTrade() *Trade {
+	if x != nil {
+		return x.Trade
+	}
+	return nil
+}
+
+func (x *TradeEvent) GetBuyerFilled() bool {
+	if x != nil {
+		return x.BuyerFilled
+	}
+	return false
+}
+
+func (x *TradeEvent) GetSellerFilled() bool {
+	if x != nil {
+		return x.SellerFilled
+	}
+	return false
+}
+
+// Envelope wraps every binary message. payload holds the encoded
+// Order or TradeEvent named by type.
+type Envelope struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            []byte                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // 16-byte UUID
+	Type          string                 `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Version       string                 `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"` // "major.minor"
+	Source        string                 `protobuf:"bytes,4,opt,name=source,proto3" json:"source,omitempty"`
+	Sequence      uint64                 `protobuf:"varint,5,opt,name=sequence,proto3" json:"sequence,omitempty"` // restarts at 1 with each epoch
+	OccurredAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=occurred_at,json=occurredAt,proto3" json:"occurred_at,omitempty"`
+	ProducedAt    *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=produced_at,json=producedAt,proto3" json:"produced_at,omitempty"`
+	Payload       []byte                 `protobuf:"bytes,8,opt,name=payload,proto3" json:"payload,omitempty"`
+	Epoch         uint64                 `protobuf:"varint,9,opt,name=epoch,proto3" json:"epoch,omitempty"` // producer start time, Unix nanoseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Envelope) Reset() {
+	*x = Envelope{}
+	mi := &file_ome_events_v1_events_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Envelope) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Envelope) ProtoMessage() {}
+
+func (x *Envelope) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_events_v1_events_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Envelope.ProtoReflect.Descriptor instead.
+func (*Envelope) Descriptor() ([]byte, []int) {
+	return file_ome_events_v1_events_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *Envelope) GetId() []byte {
+	if x != nil {
+		return x.Id
+	}
+	return nil
+}
+
+func (x *Envelope) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *Envelope) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *Envelope) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *Envelope) GetSequence() uint64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *Envelope) GetOccurredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.OccurredAt
+	}
+	return nil
+}
+
+func (x *Envelope) GetProducedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ProducedAt
+	}
+	return nil
+}
+
+func (x *Envelope) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *Envelope) GetEpoch() uint64 {
+	if x != nil {
+		return x.Epoch
+	}
+	return 0
+}
+
+var File_ome_events_v1_events_proto protoreflect.FileDescriptor
+
+const file_ome_events_v1_events_proto_rawDesc = "" +
+	"\n" +
+	"\x1aome/events/v1/events.proto\x12\rome.events.v1\x1a\x1fgoogle/protobuf/timestamp.proto\"\xe7\x03\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\fR\x06userId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12'\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x13.ome.events.v1.SideR\x04side\x12,\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x18.ome.events.v1.OrderTypeR\x04type\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x01R\bquantity\x12\x1d\n" +
+	"\n" +
+	"filled_qty\x18\b \x01(\x01R\tfilledQty\x12#\n" +
+	"\rremaining_qty\x18\t \x01(\x01R\fremainingQty\x122\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x1a.ome.events.v1.OrderStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12&\n" +
+	"\x0fclient_order_id\x18\r \x01(\tR\rclientOrderId\"\xee\x02\n" +
+	"\x05Trade\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12 \n" +
+	"\fbuy_order_id\x18\x03 \x01(\fR\n" +
+	"buyOrderId\x12\"\n" +
+	"\rsell_order_id\x18\x04 \x01(\fR\vsellOrderId\x12\x1e\n" +
+	"\vbuy_user_id\x18\x05 \x01(\fR\tbuyUserId\x12 \n" +
+	"\fsell_user_id\x18\x06 \x01(\fR\n" +
+	"sellUserId\x12\x14\n" +
+	"\x05price\x18\a \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\b \x01(\x01R\bquantity\x12;\n" +
+	"\vexecuted_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"executedAt\x127\n" +
+	"\n" +
+	"taker_side\x18\n" +
+	" \x01(\x0e2\x13.ome.events.v1.SideH\x00R\ttakerSide\x88\x01\x01B\r\n" +
+	"\v_taker_side\"\x80\x01\n" +
+	"\n" +
+	"TradeEvent\x12*\n" +
+	"\x05trade\x18\x01 \x01(\v2\x14.ome.events.v1.TradeR\x05trade\x12!\n" +
+	"\fbuyer_filled\x18\x02 \x01(\bR\vbuyerFilled\x12#\n" +
+	"\rseller_filled\x18\x03 \x01(\bR\fsellerFilled\"\xa6\x02\n" +
+	"\bEnvelope\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\fR\x02id\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12\x18\n" +
+	"\aversion\x18\x03 \x01(\tR\aversion\x12\x16\n" +
+	"\x06source\x18\x04 \x01(\tR\x06source\x12\x1a\n" +
+	"\bsequence\x18\x05 \x01(\x04R\bsequence\x12;\n" +
+	"\voccurred_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"occurredAt\x12;\n" +
+	"\vproduced_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"producedAt\x12\x18\n" +
+	"\apayload\x18\b \x01(\fR\apayload\x12\x14\n" +
+	"\x05epoch\x18\t \x01(\x04R\x05epoch*#\n" +
+	"\x04Side\x12\f\n" +
+	"\bSIDE_BUY\x10\x00\x12\r\n" +
+	"\tSIDE_SELL\x10\x01*8\n" +
+	"\tOrderType\x12\x14\n" +
+	"\x10ORDER_TYPE_LIMIT\x10\x00\x12\x15\n" +
+	"\x11ORDER_TYPE_MARKET\x10\x01*\x8e\x01\n" +
+	"\vOrderStatus\x12\x15\n" +
+	"\x11ORDER_STATUS_OPEN\x10\x00\x12\x18\n" +
+	"\x14ORDER_STATUS_PARTIAL\x10\x01\x12\x17\n" +
+	"\x13ORDER_STATUS_FILLED\x10\x02\x12\x1a\n" +
+	"\x16ORDER_STATUS_CANCELLED\x10\x03\x12\x19\n" +
+	"\x15ORDER_STATUS_REJECTED\x10\x04B7Z5github.com/Im-Manav/ome/pkg/pb/ome/events/v1;eventsv1b\x06proto3"
+
+var (
+	file_ome_events_v1_events_proto_rawDescOnce sync.Once
+	file_ome_events_v1_events_proto_rawDescData []byte
+)
+
+func file_ome_events_v1_events_proto_rawDescGZIP() []byte {
+	file_ome_events_v1_events_proto_rawDescOnce.Do(func() {
+		file_ome_events_v1_events_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ome_events_v1_events_proto_rawDesc), len(file_ome_events_v1_events_proto_rawDesc)))
+	})
+	return file_ome_events_v1_events_proto_rawDescData
+}
+
+var file_ome_events_v1_events_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_ome_events_v1_events_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_ome_events_v1_events_proto_goTypes = []any{
+	(Side)(0),                     // 0: ome.events.v1.Side
+	(OrderType)(0),                // 1: ome.events.v1.OrderType
+	(OrderStatus)(0),              // 2: ome.events.v1.OrderStatus
+	(*Order)(nil),                 // 3: ome.events.v1.Order
+	(*Trade)(nil),                 // 4: ome.events.v1.Trade
+	(*TradeEvent)(nil),            // 5: ome.events.v1.TradeEvent
+	(*Envelope)(nil),              // 6: ome.events.v1.Envelope
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+}
+var file_ome_events_v1_events_proto_depIdxs = []int32{
+	0,  // 0: ome.events.v1.Order.side:type_name -> ome.events.v1.Side
+	1,  // 1: ome.events.v1.Order.type:type_name -> ome.events.v1.OrderType
+	2,  // 2: ome.events.v1.Order.status:type_name -> ome.events.v1.OrderStatus
+	7,  // 3: ome.events.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	7,  // 4: ome.events.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	7,  // 5: ome.events.v1.Trade.executed_at:type_name -> google.protobuf.Timestamp
+	0,  // 6: ome.events.v1.Trade.taker_side:type_name -> ome.events.v1.Side
+	4,  // 7: ome.events.v1.TradeEvent.trade:type_name -> ome.events.v1.Trade
+	7,  // 8: ome.events.v1.Envelope.occurred_at:type_name -> google.protobuf.Timestamp
+	7,  // 9: ome.events.v1.Envelope.produced_at:type_name -> google.protobuf.Timestamp
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_ome_events_v1_events_proto_init() }
+func file_ome_events_v1_events_proto_init() {
+	if File_ome_events_v1_events_proto != nil {
+		return
+	}
+	file_ome_events_v1_events_proto_msgTypes[1].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ome_events_v1_events_proto_rawDesc), len(file_ome_events_v1_events_proto_rawDesc)),
+			NumEnums:      3,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_ome_events_v1_events_proto_goTypes,
+		DependencyIndexes: file_ome_events_v1_events_proto_depIdxs,
+		EnumInfos:         file_ome_events_v1_events_proto_enumTypes,
+		MessageInfos:      file_ome_events_v1_events_proto_msgTypes,
+	}.Build()
+	File_ome_events_v1_events_proto = out.File
+	file_ome_events_v1_events_proto_goTypes = nil
+	file_ome_events_v1_events_proto_depIdxs = nil
+}
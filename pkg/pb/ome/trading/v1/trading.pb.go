@@ -0,0 +1,1709 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: ome/trading/v1/trading.proto
+
+package tradingv1
+
+import (
+	v1 "github.com/Im-Manav/ome/pkg/pb/ome/events/v1"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Liquidity int32
+
+const (
+	Liquidity_LIQUIDITY_UNSPECIFIED Liquidity = 0 // the trade's taker side is unknown
+	Liquidity_LIQUIDITY_MAKER       Liquidity = 1
+	Liquidity_LIQUIDITY_TAKER       Liquidity = 2
+)
+
+// Enum value maps for Liquidity.
+var (
+	Liquidity_name = map[int32]string{
+		0: "LIQUIDITY_UNSPECIFIED",
+		1: "LIQUIDITY_MAKER",
+		2: "LIQUIDITY_TAKER",
+	}
+	Liquidity_value = map[string]int32{
+		"LIQUIDITY_UNSPECIFIED": 0,
+		"LIQUIDITY_MAKER":       1,
+		"LIQUIDITY_TAKER":       2,
+	}
+)
+
+func (x Liquidity) Enum() *Liquidity {
+	p := new(Liquidity)
+	*p = x
+	return p
+}
+
+func (x Liquidity) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Liquidity) Descriptor() protoreflect.EnumDescriptor {
+	return file_ome_trading_v1_trading_proto_enumTypes[0].Descriptor()
+}
+
+func (Liquidity) Type() protoreflect.EnumType {
+	return &file_ome_trading_v1_trading_proto_enumTypes[0]
+}
+
+func (x Liquidity) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Liquidity.Descriptor instead.
+func (Liquidity) EnumDescriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{0}
+}
+
+type Order struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,3,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          v1.Side                `protobuf:"varint,4,opt,name=side,proto3,enum=ome.events.v1.Side" json:"side,omitempty"`
+	Type          v1.OrderType           `protobuf:"varint,5,opt,name=type,proto3,enum=ome.events.v1.OrderType" json:"type,omitempty"`
+	Price         float64                `protobuf:"fixed64,6,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,7,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	FilledQty     float64                `protobuf:"fixed64,8,opt,name=filled_qty,json=filledQty,proto3" json:"filled_qty,omitempty"`
+	RemainingQty  float64                `protobuf:"fixed64,9,opt,name=remaining_qty,json=remainingQty,proto3" json:"remaining_qty,omitempty"`
+	Status        v1.OrderStatus         `protobuf:"varint,10,opt,name=status,proto3,enum=ome.events.v1.OrderStatus" json:"status,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Order) Reset() {
+	*x = Order{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Order) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Order) ProtoMessage() {}
+
+func (x *Order) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Order.ProtoReflect.Descriptor instead.
+func (*Order) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Order) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Order) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *Order) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Order) GetSide() v1.Side {
+	if x != nil {
+		return x.Side
+	}
+	return v1.Side(0)
+}
+
+func (x *Order) GetType() v1.OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return v1.OrderType(0)
+}
+
+func (x *Order) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Order) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Order) GetFilledQty() float64 {
+	if x != nil {
+		return x.FilledQty
+	}
+	return 0
+}
+
+func (x *Order) GetRemainingQty() float64 {
+	if x != nil {
+		return x.RemainingQty
+	}
+	return 0
+}
+
+func (x *Order) GetStatus() v1.OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return v1.OrderStatus(0)
+}
+
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+// Trade is a public fill, without the users on either side.
+type Trade struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Symbol        string                 `protobuf:"bytes,2,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	BuyOrderId    string                 `protobuf:"bytes,3,opt,name=buy_order_id,json=buyOrderId,proto3" json:"buy_order_id,omitempty"`
+	SellOrderId   string                 `protobuf:"bytes,4,opt,name=sell_order_id,json=sellOrderId,proto3" json:"sell_order_id,omitempty"`
+	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,6,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	TakerSide     *v1.Side               `protobuf:"varint,7,opt,name=taker_side,json=takerSide,proto3,enum=ome.events.v1.Side,oneof" json:"taker_side,omitempty"` // unset when unknown
+	ExecutedAt    *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=executed_at,json=executedAt,proto3" json:"executed_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Trade) Reset() {
+	*x = Trade{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Trade) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Trade) ProtoMessage() {}
+
+func (x *Trade) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Trade.ProtoReflect.Descriptor instead.
+func (*Trade) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Trade) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Trade) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *Trade) GetBuyOrderId() string {
+	if x != nil {
+		return x.BuyOrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetSellOrderId() string {
+	if x != nil {
+		return x.SellOrderId
+	}
+	return ""
+}
+
+func (x *Trade) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *Trade) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *Trade) GetTakerSide() v1.Side {
+	if x != nil && x.TakerSide != nil {
+		return *x.TakerSide
+	}
+	return v1.Side(0)
+}
+
+func (x *Trade) GetExecutedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExecutedAt
+	}
+	return nil
+}
+
+// Fill is one trade from the point of view of one of the caller's orders.
+type Fill struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Trade         *Trade                 `protobuf:"bytes,1,opt,name=trade,proto3" json:"trade,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Side          v1.Side                `protobuf:"varint,3,opt,name=side,proto3,enum=ome.events.v1.Side" json:"side,omitempty"`
+	Liquidity     Liquidity              `protobuf:"varint,4,opt,name=liquidity,proto3,enum=ome.trading.v1.Liquidity" json:"liquidity,omitempty"`
+	Fee           *float64               `protobuf:"fixed64,5,opt,name=fee,proto3,oneof" json:"fee,omitempty"` // in the quote currency; unset when liquidity is unknown
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Fill) Reset() {
+	*x = Fill{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Fill) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Fill) ProtoMessage() {}
+
+func (x *Fill) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Fill.ProtoReflect.Descriptor instead.
+func (*Fill) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Fill) GetTrade() *Trade {
+	if x != nil {
+		return x.Trade
+	}
+	return nil
+}
+
+func (x *Fill) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *Fill) GetSide() v1.Side {
+	if x != nil {
+		return x.Side
+	}
+	return v1.Side(0)
+}
+
+func (x *Fill) GetLiquidity() Liquidity {
+	if x != nil {
+		return x.Liquidity
+	}
+	return Liquidity_LIQUIDITY_UNSPECIFIED
+}
+
+func (x *Fill) GetFee() float64 {
+	if x != nil && x.Fee != nil {
+		return *x.Fee
+	}
+	return 0
+}
+
+type PlaceOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Side          v1.Side                `protobuf:"varint,2,opt,name=side,proto3,enum=ome.events.v1.Side" json:"side,omitempty"`
+	Type          v1.OrderType           `protobuf:"varint,3,opt,name=type,proto3,enum=ome.events.v1.OrderType" json:"type,omitempty"`
+	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,5,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	ClientOrderId string                 `protobuf:"bytes,6,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"`
+	// Wait for the engine's result, as ?wait=true does over REST.
+	Wait          bool `protobuf:"varint,7,opt,name=wait,proto3" json:"wait,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderRequest) Reset() {
+	*x = PlaceOrderRequest{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderRequest) ProtoMessage() {}
+
+func (x *PlaceOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderRequest.ProtoReflect.Descriptor instead.
+func (*PlaceOrderRequest) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PlaceOrderRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetSide() v1.Side {
+	if x != nil {
+		return x.Side
+	}
+	return v1.Side(0)
+}
+
+func (x *PlaceOrderRequest) GetType() v1.OrderType {
+	if x != nil {
+		return x.Type
+	}
+	return v1.OrderType(0)
+}
+
+func (x *PlaceOrderRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PlaceOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+func (x *PlaceOrderRequest) GetWait() bool {
+	if x != nil {
+		return x.Wait
+	}
+	return false
+}
+
+type PlaceOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Trades        []*Trade               `protobuf:"bytes,2,rep,name=trades,proto3" json:"trades,omitempty"`
+	Pending       bool                   `protobuf:"varint,3,opt,name=pending,proto3" json:"pending,omitempty"`
+	Duplicate     bool                   `protobuf:"varint,4,opt,name=duplicate,proto3" json:"duplicate,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlaceOrderResponse) Reset() {
+	*x = PlaceOrderResponse{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlaceOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlaceOrderResponse) ProtoMessage() {}
+
+func (x *PlaceOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlaceOrderResponse.ProtoReflect.Descriptor instead.
+func (*PlaceOrderResponse) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *PlaceOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *PlaceOrderResponse) GetTrades() []*Trade {
+	if x != nil {
+		return x.Trades
+	}
+	return nil
+}
+
+func (x *PlaceOrderResponse) GetPending() bool {
+	if x != nil {
+		return x.Pending
+	}
+	return false
+}
+
+func (x *PlaceOrderResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
+type CancelOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Ref:
+	//
+	//	*CancelOrderRequest_OrderId
+	//	*CancelOrderRequest_ClientOrderId
+	Ref           isCancelOrderRequest_Ref `protobuf_oneof:"ref"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *CancelOrderRequest) GetRef() isCancelOrderRequest_Ref {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *CancelOrderRequest) GetOrderId() string {
+	if x != nil {
+		if x, ok := x.Ref.(*CancelOrderRequest_OrderId); ok {
+			return x.OrderId
+		}
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		if x, ok := x.Ref.(*CancelOrderRequest_ClientOrderId); ok {
+			return x.ClientOrderId
+		}
+	}
+	return ""
+}
+
+type isCancelOrderRequest_Ref interface {
+	isCancelOrderRequest_Ref()
+}
+
+type CancelOrderRequest_OrderId struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3,oneof"`
+}
+
+type CancelOrderRequest_ClientOrderId struct {
+	ClientOrderId string `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3,oneof"`
+}
+
+func (*CancelOrderRequest_OrderId) isCancelOrderRequest_Ref() {}
+
+func (*CancelOrderRequest_ClientOrderId) isCancelOrderRequest_Ref() {}
+
+type CancelOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        v1.OrderStatus         `protobuf:"varint,2,opt,name=status,proto3,enum=ome.events.v1.OrderStatus" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderResponse) Reset() {
+	*x = CancelOrderResponse{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderResponse) ProtoMessage() {}
+
+func (x *CancelOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderResponse.ProtoReflect.Descriptor instead.
+func (*CancelOrderResponse) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CancelOrderResponse) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *CancelOrderResponse) GetStatus() v1.OrderStatus {
+	if x != nil {
+		return x.Status
+	}
+	return v1.OrderStatus(0)
+}
+
+// AmendOrderRequest changes a resting limit order. The order is
+// cancelled and replaced by a new one, which goes to the back of the
+// queue at its price. Zero keeps the current price or remaining quantity.
+type AmendOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Price         float64                `protobuf:"fixed64,2,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,3,opt,name=quantity,proto3" json:"quantity,omitempty"`                                // the replacement's quantity
+	ClientOrderId string                 `protobuf:"bytes,4,opt,name=client_order_id,json=clientOrderId,proto3" json:"client_order_id,omitempty"` // for the replacement
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AmendOrderRequest) Reset() {
+	*x = AmendOrderRequest{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderRequest) ProtoMessage() {}
+
+func (x *AmendOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderRequest.ProtoReflect.Descriptor instead.
+func (*AmendOrderRequest) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *AmendOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *AmendOrderRequest) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *AmendOrderRequest) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *AmendOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		return x.ClientOrderId
+	}
+	return ""
+}
+
+type AmendOrderResponse struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	CancelledOrderId string                 `protobuf:"bytes,1,opt,name=cancelled_order_id,json=cancelledOrderId,proto3" json:"cancelled_order_id,omitempty"`
+	Order            *Order                 `protobuf:"bytes,2,opt,name=order,proto3" json:"order,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *AmendOrderResponse) Reset() {
+	*x = AmendOrderResponse{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AmendOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmendOrderResponse) ProtoMessage() {}
+
+func (x *AmendOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmendOrderResponse.ProtoReflect.Descriptor instead.
+func (*AmendOrderResponse) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *AmendOrderResponse) GetCancelledOrderId() string {
+	if x != nil {
+		return x.CancelledOrderId
+	}
+	return ""
+}
+
+func (x *AmendOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+type GetOrderRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Ref:
+	//
+	//	*GetOrderRequest_OrderId
+	//	*GetOrderRequest_ClientOrderId
+	Ref           isGetOrderRequest_Ref `protobuf_oneof:"ref"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderRequest) Reset() {
+	*x = GetOrderRequest{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderRequest) ProtoMessage() {}
+
+func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderRequest) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetOrderRequest) GetRef() isGetOrderRequest_Ref {
+	if x != nil {
+		return x.Ref
+	}
+	return nil
+}
+
+func (x *GetOrderRequest) GetOrderId() string {
+	if x != nil {
+		if x, ok := x.Ref.(*GetOrderRequest_OrderId); ok {
+			return x.OrderId
+		}
+	}
+	return ""
+}
+
+func (x *GetOrderRequest) GetClientOrderId() string {
+	if x != nil {
+		if x, ok := x.Ref.(*GetOrderRequest_ClientOrderId); ok {
+			return x.ClientOrderId
+		}
+	}
+	return ""
+}
+
+type isGetOrderRequest_Ref interface {
+	isGetOrderRequest_Ref()
+}
+
+type GetOrderRequest_OrderId struct {
+	OrderId string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3,oneof"`
+}
+
+type GetOrderRequest_ClientOrderId struct {
+	ClientOrderId string `protobuf:"bytes,2,opt,name=client_order_id,json=clientOrderId,proto3,oneof"`
+}
+
+func (*GetOrderRequest_OrderId) isGetOrderRequest_Ref() {}
+
+func (*GetOrderRequest_ClientOrderId) isGetOrderRequest_Ref() {}
+
+type GetOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Fills         []*Fill                `protobuf:"bytes,2,rep,name=fills,proto3" json:"fills,omitempty"`
+	AvgFillPrice  float64                `protobuf:"fixed64,3,opt,name=avg_fill_price,json=avgFillPrice,proto3" json:"avg_fill_price,omitempty"`
+	Fees          *float64               `protobuf:"fixed64,4,opt,name=fees,proto3,oneof" json:"fees,omitempty"` // unset when any fill's fee is unknown
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderResponse) Reset() {
+	*x = GetOrderResponse{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderResponse) ProtoMessage() {}
+
+func (x *GetOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderResponse.ProtoReflect.Descriptor instead.
+func (*GetOrderResponse) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetOrderResponse) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *GetOrderResponse) GetFills() []*Fill {
+	if x != nil {
+		return x.Fills
+	}
+	return nil
+}
+
+func (x *GetOrderResponse) GetAvgFillPrice() float64 {
+	if x != nil {
+		return x.AvgFillPrice
+	}
+	return 0
+}
+
+func (x *GetOrderResponse) GetFees() float64 {
+	if x != nil && x.Fees != nil {
+		return *x.Fees
+	}
+	return 0
+}
+
+type ListOrdersRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Statuses      []v1.OrderStatus       `protobuf:"varint,2,rep,packed,name=statuses,proto3,enum=ome.events.v1.OrderStatus" json:"statuses,omitempty"`
+	Side          *v1.Side               `protobuf:"varint,3,opt,name=side,proto3,enum=ome.events.v1.Side,oneof" json:"side,omitempty"`
+	From          *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Limit         int32                  `protobuf:"varint,6,opt,name=limit,proto3" json:"limit,omitempty"`
+	Cursor        string                 `protobuf:"bytes,7,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersRequest) Reset() {
+	*x = ListOrdersRequest{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersRequest) ProtoMessage() {}
+
+func (x *ListOrdersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersRequest.ProtoReflect.Descriptor instead.
+func (*ListOrdersRequest) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListOrdersRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *ListOrdersRequest) GetStatuses() []v1.OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetSide() v1.Side {
+	if x != nil && x.Side != nil {
+		return *x.Side
+	}
+	return v1.Side(0)
+}
+
+func (x *ListOrdersRequest) GetFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *ListOrdersRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListOrdersRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type ListOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=orders,proto3" json:"orders,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListOrdersResponse) Reset() {
+	*x = ListOrdersResponse{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListOrdersResponse) ProtoMessage() {}
+
+func (x *ListOrdersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListOrdersResponse.ProtoReflect.Descriptor instead.
+func (*ListOrdersResponse) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListOrdersResponse) GetOrders() []*Order {
+	if x != nil {
+		return x.Orders
+	}
+	return nil
+}
+
+func (x *ListOrdersResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type GetOrderBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetOrderBookRequest) Reset() {
+	*x = GetOrderBookRequest{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetOrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetOrderBookRequest) ProtoMessage() {}
+
+func (x *GetOrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetOrderBookRequest.ProtoReflect.Descriptor instead.
+func (*GetOrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *GetOrderBookRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type PriceLevel struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Price         float64                `protobuf:"fixed64,1,opt,name=price,proto3" json:"price,omitempty"`
+	Quantity      float64                `protobuf:"fixed64,2,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	Orders        int32                  `protobuf:"varint,3,opt,name=orders,proto3" json:"orders,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceLevel) Reset() {
+	*x = PriceLevel{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceLevel) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceLevel) ProtoMessage() {}
+
+func (x *PriceLevel) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceLevel.ProtoReflect.Descriptor instead.
+func (*PriceLevel) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *PriceLevel) GetPrice() float64 {
+	if x != nil {
+		return x.Price
+	}
+	return 0
+}
+
+func (x *PriceLevel) GetQuantity() float64 {
+	if x != nil {
+		return x.Quantity
+	}
+	return 0
+}
+
+func (x *PriceLevel) GetOrders() int32 {
+	if x != nil {
+		return x.Orders
+	}
+	return 0
+}
+
+type OrderBook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	Bids          []*PriceLevel          `protobuf:"bytes,2,rep,name=bids,proto3" json:"bids,omitempty"` // high to low
+	Asks          []*PriceLevel          `protobuf:"bytes,3,rep,name=asks,proto3" json:"asks,omitempty"` // low to high
+	Timestamp     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderBook) Reset() {
+	*x = OrderBook{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderBook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderBook) ProtoMessage() {}
+
+func (x *OrderBook) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderBook.ProtoReflect.Descriptor instead.
+func (*OrderBook) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *OrderBook) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+func (x *OrderBook) GetBids() []*PriceLevel {
+	if x != nil {
+		return x.Bids
+	}
+	return nil
+}
+
+func (x *OrderBook) GetAsks() []*PriceLevel {
+	if x != nil {
+		return x.Asks
+	}
+	return nil
+}
+
+func (x *OrderBook) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+type SubscribeTradesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeTradesRequest) Reset() {
+	*x = SubscribeTradesRequest{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeTradesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeTradesRequest) ProtoMessage() {}
+
+func (x *SubscribeTradesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeTradesRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeTradesRequest) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SubscribeTradesRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type SubscribeOrderBookRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Symbol        string                 `protobuf:"bytes,1,opt,name=symbol,proto3" json:"symbol,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeOrderBookRequest) Reset() {
+	*x = SubscribeOrderBookRequest{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeOrderBookRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeOrderBookRequest) ProtoMessage() {}
+
+func (x *SubscribeOrderBookRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeOrderBookRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeOrderBookRequest) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SubscribeOrderBookRequest) GetSymbol() string {
+	if x != nil {
+		return x.Symbol
+	}
+	return ""
+}
+
+type SubscribeExecutionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeExecutionsRequest) Reset() {
+	*x = SubscribeExecutionsRequest{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeExecutionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeExecutionsRequest) ProtoMessage() {}
+
+func (x *SubscribeExecutionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeExecutionsRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeExecutionsRequest) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{18}
+}
+
+// Execution carries one match as it affected the caller. Order is set
+// when it was the caller's incoming order; fills are the caller's side
+// of each trade, including resting orders the match filled.
+type Execution struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Order         *Order                 `protobuf:"bytes,1,opt,name=order,proto3" json:"order,omitempty"`
+	Fills         []*Fill                `protobuf:"bytes,2,rep,name=fills,proto3" json:"fills,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Execution) Reset() {
+	*x = Execution{}
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Execution) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Execution) ProtoMessage() {}
+
+func (x *Execution) ProtoReflect() protoreflect.Message {
+	mi := &file_ome_trading_v1_trading_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Execution.ProtoReflect.Descriptor instead.
+func (*Execution) Descriptor() ([]byte, []int) {
+	return file_ome_trading_v1_trading_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *Execution) GetOrder() *Order {
+	if x != nil {
+		return x.Order
+	}
+	return nil
+}
+
+func (x *Execution) GetFills() []*Fill {
+	if x != nil {
+		return x.Fills
+	}
+	return nil
+}
+
+var File_ome_trading_v1_trading_proto protoreflect.FileDescriptor
+
+const file_ome_trading_v1_trading_proto_rawDesc = "" +
+	"\n" +
+	"\x1come/trading/v1/trading.proto\x12\x0eome.trading.v1\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1aome/events/v1/events.proto\"\xce\x03\n" +
+	"\x05Order\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tR\rclientOrderId\x12\x16\n" +
+	"\x06symbol\x18\x03 \x01(\tR\x06symbol\x12'\n" +
+	"\x04side\x18\x04 \x01(\x0e2\x13.ome.events.v1.SideR\x04side\x12,\n" +
+	"\x04type\x18\x05 \x01(\x0e2\x18.ome.events.v1.OrderTypeR\x04type\x12\x14\n" +
+	"\x05price\x18\x06 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\a \x01(\x01R\bquantity\x12\x1d\n" +
+	"\n" +
+	"filled_qty\x18\b \x01(\x01R\tfilledQty\x12#\n" +
+	"\rremaining_qty\x18\t \x01(\x01R\fremainingQty\x122\n" +
+	"\x06status\x18\n" +
+	" \x01(\x0e2\x1a.ome.events.v1.OrderStatusR\x06status\x129\n" +
+	"\n" +
+	"created_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xac\x02\n" +
+	"\x05Trade\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06symbol\x18\x02 \x01(\tR\x06symbol\x12 \n" +
+	"\fbuy_order_id\x18\x03 \x01(\tR\n" +
+	"buyOrderId\x12\"\n" +
+	"\rsell_order_id\x18\x04 \x01(\tR\vsellOrderId\x12\x14\n" +
+	"\x05price\x18\x05 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x06 \x01(\x01R\bquantity\x127\n" +
+	"\n" +
+	"taker_side\x18\a \x01(\x0e2\x13.ome.events.v1.SideH\x00R\ttakerSide\x88\x01\x01\x12;\n" +
+	"\vexecuted_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"executedAtB\r\n" +
+	"\v_taker_side\"\xcf\x01\n" +
+	"\x04Fill\x12+\n" +
+	"\x05trade\x18\x01 \x01(\v2\x15.ome.trading.v1.TradeR\x05trade\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x12'\n" +
+	"\x04side\x18\x03 \x01(\x0e2\x13.ome.events.v1.SideR\x04side\x127\n" +
+	"\tliquidity\x18\x04 \x01(\x0e2\x19.ome.trading.v1.LiquidityR\tliquidity\x12\x15\n" +
+	"\x03fee\x18\x05 \x01(\x01H\x00R\x03fee\x88\x01\x01B\x06\n" +
+	"\x04_fee\"\xf0\x01\n" +
+	"\x11PlaceOrderRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12'\n" +
+	"\x04side\x18\x02 \x01(\x0e2\x13.ome.events.v1.SideR\x04side\x12,\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x18.ome.events.v1.OrderTypeR\x04type\x12\x14\n" +
+	"\x05price\x18\x04 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x05 \x01(\x01R\bquantity\x12&\n" +
+	"\x0fclient_order_id\x18\x06 \x01(\tR\rclientOrderId\x12\x12\n" +
+	"\x04wait\x18\a \x01(\bR\x04wait\"\xa8\x01\n" +
+	"\x12PlaceOrderResponse\x12+\n" +
+	"\x05order\x18\x01 \x01(\v2\x15.ome.trading.v1.OrderR\x05order\x12-\n" +
+	"\x06trades\x18\x02 \x03(\v2\x15.ome.trading.v1.TradeR\x06trades\x12\x18\n" +
+	"\apending\x18\x03 \x01(\bR\apending\x12\x1c\n" +
+	"\tduplicate\x18\x04 \x01(\bR\tduplicate\"b\n" +
+	"\x12CancelOrderRequest\x12\x1b\n" +
+	"\border_id\x18\x01 \x01(\tH\x00R\aorderId\x12(\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tH\x00R\rclientOrderIdB\x05\n" +
+	"\x03ref\"d\n" +
+	"\x13CancelOrderResponse\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x122\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x1a.ome.events.v1.OrderStatusR\x06status\"\x88\x01\n" +
+	"\x11AmendOrderRequest\x12\x19\n" +
+	"\border_id\x18\x01 \x01(\tR\aorderId\x12\x14\n" +
+	"\x05price\x18\x02 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x03 \x01(\x01R\bquantity\x12&\n" +
+	"\x0fclient_order_id\x18\x04 \x01(\tR\rclientOrderId\"o\n" +
+	"\x12AmendOrderResponse\x12,\n" +
+	"\x12cancelled_order_id\x18\x01 \x01(\tR\x10cancelledOrderId\x12+\n" +
+	"\x05order\x18\x02 \x01(\v2\x15.ome.trading.v1.OrderR\x05order\"_\n" +
+	"\x0fGetOrderRequest\x12\x1b\n" +
+	"\border_id\x18\x01 \x01(\tH\x00R\aorderId\x12(\n" +
+	"\x0fclient_order_id\x18\x02 \x01(\tH\x00R\rclientOrderIdB\x05\n" +
+	"\x03ref\"\xb3\x01\n" +
+	"\x10GetOrderResponse\x12+\n" +
+	"\x05order\x18\x01 \x01(\v2\x15.ome.trading.v1.OrderR\x05order\x12*\n" +
+	"\x05fills\x18\x02 \x03(\v2\x14.ome.trading.v1.FillR\x05fills\x12$\n" +
+	"\x0eavg_fill_price\x18\x03 \x01(\x01R\favgFillPrice\x12\x17\n" +
+	"\x04fees\x18\x04 \x01(\x01H\x00R\x04fees\x88\x01\x01B\a\n" +
+	"\x05_fees\"\xa4\x02\n" +
+	"\x11ListOrdersRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x126\n" +
+	"\bstatuses\x18\x02 \x03(\x0e2\x1a.ome.events.v1.OrderStatusR\bstatuses\x12,\n" +
+	"\x04side\x18\x03 \x01(\x0e2\x13.ome.events.v1.SideH\x00R\x04side\x88\x01\x01\x12.\n" +
+	"\x04from\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\x04from\x12*\n" +
+	"\x02to\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x02to\x12\x14\n" +
+	"\x05limit\x18\x06 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\a \x01(\tR\x06cursorB\a\n" +
+	"\x05_side\"d\n" +
+	"\x12ListOrdersResponse\x12-\n" +
+	"\x06orders\x18\x01 \x03(\v2\x15.ome.trading.v1.OrderR\x06orders\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"-\n" +
+	"\x13GetOrderBookRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"V\n" +
+	"\n" +
+	"PriceLevel\x12\x14\n" +
+	"\x05price\x18\x01 \x01(\x01R\x05price\x12\x1a\n" +
+	"\bquantity\x18\x02 \x01(\x01R\bquantity\x12\x16\n" +
+	"\x06orders\x18\x03 \x01(\x05R\x06orders\"\xbd\x01\n" +
+	"\tOrderBook\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\x12.\n" +
+	"\x04bids\x18\x02 \x03(\v2\x1a.ome.trading.v1.PriceLevelR\x04bids\x12.\n" +
+	"\x04asks\x18\x03 \x03(\v2\x1a.ome.trading.v1.PriceLevelR\x04asks\x128\n" +
+	"\ttimestamp\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\ttimestamp\"0\n" +
+	"\x16SubscribeTradesRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"3\n" +
+	"\x19SubscribeOrderBookRequest\x12\x16\n" +
+	"\x06symbol\x18\x01 \x01(\tR\x06symbol\"\x1c\n" +
+	"\x1aSubscribeExecutionsRequest\"d\n" +
+	"\tExecution\x12+\n" +
+	"\x05order\x18\x01 \x01(\v2\x15.ome.trading.v1.OrderR\x05order\x12*\n" +
+	"\x05fills\x18\x02 \x03(\v2\x14.ome.trading.v1.FillR\x05fills*P\n" +
+	"\tLiquidity\x12\x19\n" +
+	"\x15LIQUIDITY_UNSPECIFIED\x10\x00\x12\x13\n" +
+	"\x0fLIQUIDITY_MAKER\x10\x01\x12\x13\n" +
+	"\x0fLIQUIDITY_TAKER\x10\x022\x98\x06\n" +
+	"\x0eTradingService\x12S\n" +
+	"\n" +
+	"PlaceOrder\x12!.ome.trading.v1.PlaceOrderRequest\x1a\".ome.trading.v1.PlaceOrderResponse\x12V\n" +
+	"\vCancelOrder\x12\".ome.trading.v1.CancelOrderRequest\x1a#.ome.trading.v1.CancelOrderResponse\x12S\n" +
+	"\n" +
+	"AmendOrder\x12!.ome.trading.v1.AmendOrderRequest\x1a\".ome.trading.v1.AmendOrderResponse\x12M\n" +
+	"\bGetOrder\x12\x1f.ome.trading.v1.GetOrderRequest\x1a .ome.trading.v1.GetOrderResponse\x12S\n" +
+	"\n" +
+	"ListOrders\x12!.ome.trading.v1.ListOrdersRequest\x1a\".ome.trading.v1.ListOrdersResponse\x12N\n" +
+	"\fGetOrderBook\x12#.ome.trading.v1.GetOrderBookRequest\x1a\x19.ome.trading.v1.OrderBook\x12R\n" +
+	"\x0fSubscribeTrades\x12&.ome.trading.v1.SubscribeTradesRequest\x1a\x15.ome.trading.v1.Trade0\x01\x12\\\n" +
+	"\x12SubscribeOrderBook\x12).ome.trading.v1.SubscribeOrderBookRequest\x1a\x19.ome.trading.v1.OrderBook0\x01\x12^\n" +
+	"\x13SubscribeExecutions\x12*.ome.trading.v1.SubscribeExecutionsRequest\x1a\x19.ome.trading.v1.Execution0\x01B9Z7github.com/Im-Manav/ome/pkg/pb/ome/trading/v1;tradingv1b\x06proto3"
+
+var (
+	file_ome_trading_v1_trading_proto_rawDescOnce sync.Once
+	file_ome_trading_v1_trading_proto_rawDescData []byte
+)
+
+func file_ome_trading_v1_trading_proto_rawDescGZIP() []byte {
+	file_ome_trading_v1_trading_proto_rawDescOnce.Do(func() {
+		file_ome_trading_v1_trading_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_ome_trading_v1_trading_proto_rawDesc), len(file_ome_trading_v1_trading_proto_rawDesc)))
+	})
+	return file_ome_trading_v1_trading_proto_rawDescData
+}
+
+var file_ome_trading_v1_trading_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_ome_trading_v1_trading_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
+var file_ome_trading_v1_trading_proto_goTypes = []any{
+	(Liquidity)(0),                     // 0: ome.trading.v1.Liquidity
+	(*Order)(nil),                      // 1: ome.trading.v1.Order
+	(*Trade)(nil),                      // 2: ome.trading.v1.Trade
+	(*Fill)(nil),                       // 3: ome.trading.v1.Fill
+	(*PlaceOrderRequest)(nil),          // 4: ome.trading.v1.PlaceOrderRequest
+	(*PlaceOrderResponse)(nil),         // 5: ome.trading.v1.PlaceOrderResponse
+	(*CancelOrderRequest)(nil),         // 6: ome.trading.v1.CancelOrderRequest
+	(*CancelOrderResponse)(nil),        // 7: ome.trading.v1.CancelOrderResponse
+	(*AmendOrderRequest)(nil),          // 8: ome.trading.v1.AmendOrderRequest
+	(*AmendOrderResponse)(nil),         // 9: ome.trading.v1.AmendOrderResponse
+	(*GetOrderRequest)(nil),            // 10: ome.trading.v1.GetOrderRequest
+	(*GetOrderResponse)(nil),           // 11: ome.trading.v1.GetOrderResponse
+	(*ListOrdersRequest)(nil),          // 12: ome.trading.v1.ListOrdersRequest
+	(*ListOrdersResponse)(nil),         // 13: ome.trading.v1.ListOrdersResponse
+	(*GetOrderBookRequest)(nil),        // 14: ome.trading.v1.GetOrderBookRequest
+	(*PriceLevel)(nil),                 // 15: ome.trading.v1.PriceLevel
+	(*OrderBook)(nil),                  // 16: ome.trading.v1.OrderBook
+	(*SubscribeTradesRequest)(nil),     // 17: ome.trading.v1.SubscribeTradesRequest
+	(*SubscribeOrderBookRequest)(nil),  // 18: ome.trading.v1.SubscribeOrderBookRequest
+	(*SubscribeExecutionsRequest)(nil), // 19: ome.trading.v1.SubscribeExecutionsRequest
+	(*Execution)(nil),                  // 20: ome.trading.v1.Execution
+	(v1.Side)(0),                       // 21: ome.events.v1.Side
+	(v1.OrderType)(0),                  // 22: ome.events.v1.OrderType
+	(v1.OrderStatus)(0),                // 23: ome.events.v1.OrderStatus
+	(*timestamppb.Timestamp)(nil),      // 24: google.protobuf.Timestamp
+}
+var file_ome_trading_v1_trading_proto_depIdxs = []int32{
+	21, // 0: ome.trading.v1.Order.side:type_name -> ome.events.v1.Side
+	22, // 1: ome.trading.v1.Order.type:type_name -> ome.events.v1.OrderType
+	23, // 2: ome.trading.v1.Order.status:type_name -> ome.events.v1.OrderStatus
+	24, // 3: ome.trading.v1.Order.created_at:type_name -> google.protobuf.Timestamp
+	24, // 4: ome.trading.v1.Order.updated_at:type_name -> google.protobuf.Timestamp
+	21, // 5: ome.trading.v1.Trade.taker_side:type_name -> ome.events.v1.Side
+	24, // 6: ome.trading.v1.Trade.executed_at:type_name -> google.protobuf.Timestamp
+	2,  // 7: ome.trading.v1.Fill.trade:type_name -> ome.trading.v1.Trade
+	21, // 8: ome.trading.v1.Fill.side:type_name -> ome.events.v1.Side
+	0,  // 9: ome.trading.v1.Fill.liquidity:type_name -> ome.trading.v1.Liquidity
+	21, // 10: ome.trading.v1.PlaceOrderRequest.side:type_name -> ome.events.v1.Side
+	22, // 11: ome.trading.v1.PlaceOrderRequest.type:type_name -> ome.events.v1.OrderType
+	1,  // 12: ome.trading.v1.PlaceOrderResponse.order:type_name -> ome.trading.v1.Order
+	2,  // 13: ome.trading.v1.PlaceOrderResponse.trades:type_name -> ome.trading.v1.Trade
+	23, // 14: ome.trading.v1.CancelOrderResponse.status:type_name -> ome.events.v1.OrderStatus
+	1,  // 15: ome.trading.v1.AmendOrderResponse.order:type_name -> ome.trading.v1.Order
+	1,  // 16: ome.trading.v1.GetOrderResponse.order:type_name -> ome.trading.v1.Order
+	3,  // 17: ome.trading.v1.GetOrderResponse.fills:type_name -> ome.trading.v1.Fill
+	23, // 18: ome.trading.v1.ListOrdersRequest.statuses:type_name -> ome.events.v1.OrderStatus
+	21, // 19: ome.trading.v1.ListOrdersRequest.side:type_name -> ome.events.v1.Side
+	24, // 20: ome.trading.v1.ListOrdersRequest.from:type_name -> google.protobuf.Timestamp
+	24, // 21: ome.trading.v1.ListOrdersRequest.to:type_name -> google.protobuf.Timestamp
+	1,  // 22: ome.trading.v1.ListOrdersResponse.orders:type_name -> ome.trading.v1.Order
+	15, // 23: ome.trading.v1.OrderBook.bids:type_name -> ome.trading.v1.PriceLevel
+	15, // 24: ome.trading.v1.OrderBook.asks:type_name -> ome.trading.v1.PriceLevel
+	24, // 25: ome.trading.v1.OrderBook.timestamp:type_name -> google.protobuf.Timestamp
+	1,  // 26: ome.trading.v1.Execution.order:type_name -> ome.trading.v1.Order
+	3,  // 27: ome.trading.v1.Execution.fills:type_name -> ome.trading.v1.Fill
+	4,  // 28: ome.trading.v1.TradingService.PlaceOrder:input_type -> ome.trading.v1.PlaceOrderRequest
+	6,  // 29: ome.trading.v1.TradingService.CancelOrder:input_type -> ome.trading.v1.CancelOrderRequest
+	8,  // 30: ome.trading.v1.TradingService.AmendOrder:input_type -> ome.trading.v1.AmendOrderRequest
+	10, // 31: ome.trading.v1.TradingService.GetOrder:input_type -> ome.trading.v1.GetOrderRequest
+	12, // 32: ome.trading.v1.TradingService.ListOrders:input_type -> ome.trading.v1.ListOrdersRequest
+	14, // 33: ome.trading.v1.TradingService.GetOrderBook:input_type -> ome.trading.v1.GetOrderBookRequest
+	17, // 34: ome.trading.v1.TradingService.SubscribeTrades:input_type -> ome.trading.v1.SubscribeTradesRequest
+	18, // 35: ome.trading.v1.TradingService.SubscribeOrderBook:input_type -> ome.trading.v1.SubscribeOrderBookRequest
+	19, // 36: ome.trading.v1.TradingService.SubscribeExecutions:input_type -> ome.trading.v1.SubscribeExecutionsRequest
+	5,  // 37: ome.trading.v1.TradingService.PlaceOrder:output_type -> ome.trading.v1.PlaceOrderResponse
+	7,  // 38: ome.trading.v1.TradingService.CancelOrder:output_type -> ome.trading.v1.CancelOrderResponse
+	9,  // 39: ome.trading.v1.TradingService.AmendOrder:output_type -> ome.trading.v1.AmendOrderResponse
+	11, // 40: ome.trading.v1.TradingService.GetOrder:output_type -> ome.trading.v1.GetOrderResponse
+	13, // 41: ome.trading.v1.TradingService.ListOrders:output_type -> ome.trading.v1.ListOrdersResponse
+	16, // 42: ome.trading.v1.TradingService.GetOrderBook:output_type -> ome.trading.v1.OrderBook
+	2,  // 43: ome.trading.v1.TradingService.SubscribeTrades:output_type -> ome.trading.v1.Trade
+	16, // 44: ome.trading.v1.TradingService.SubscribeOrderBook:output_type -> ome.trading.v1.OrderBook
+	20, // 45: ome.trading.v1.TradingService.SubscribeExecutions:output_type -> ome.trading.v1.Execution
+	37, // [37:46] is the sub-list for method output_type
+	28, // [28:37] is the sub-list for method input_type
+	28, // [28:28] is the sub-list for extension type_name
+	28, // [28:28] is the sub-list for extension extendee
+	0,  // [0:28] is the sub-list for field type_name
+}
+
+func init() { file_ome_trading_v1_trading_proto_init() }
+func file_ome_trading_v1_trading_proto_init() {
+	if File_ome_trading_v1_trading_proto != nil {
+		return
+	}
+	file_ome_trading_v1_trading_proto_msgTypes[1].OneofWrappers = []any{}
+	file_ome_trading_v1_trading_proto_msgTypes[2].OneofWrappers = []any{}
+	file_ome_trading_v1_trading_proto_msgTypes[5].OneofWrappers = []any{
+		(*CancelOrderRequest_OrderId)(nil),
+		(*CancelOrderRequest_ClientOrderId)(nil),
+	}
+	file_ome_trading_v1_trading_proto_msgTypes[9].OneofWrappers = []any{
+		(*GetOrderRequest_OrderId)(nil),
+		(*GetOrderRequest_ClientOrderId)(nil),
+	}
+	file_ome_trading_v1_trading_proto_msgTypes[10].OneofWrappers = []any{}
+	file_ome_trading_v1_trading_proto_msgTypes[11].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_ome_trading_v1_trading_proto_rawDesc), len(file_ome_trading_v1_trading_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   20,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_ome_trading_v1_trading_proto_goTypes,
+		DependencyIndexes: file_ome_trading_v1_trading_proto_depIdxs,
+		EnumInfos:         file_ome_trading_v1_trading_proto_enumTypes,
+		MessageInfos:      file_ome_trading_v1_trading_proto_msgTypes,
+	}.Build()
+	File_ome_trading_v1_trading_proto = out.File
+	file_ome_trading_v1_trading_proto_goTypes = nil
+	file_ome_trading_v1_trading_proto_depIdxs = nil
+}